@@ -1,18 +1,29 @@
 // The iextool command prints stock data for a list of stock symbols.
 // go run cmd/iextool/iextool.go -token TOKEN
+//
+// The screen command prints the cached symbols whose daily charts match a screener expression.
+// go run cmd/iextool/iextool.go -token TOKEN screen "close > sma50 and sma50 > sma200"
+//
+// The backtest command simulates a strategy over the cached daily charts of the symbols.
+// go run cmd/iextool/iextool.go -token TOKEN backtest -entry "close > ema21 and low <= ema21" -stop 0.07 AAPL MSFT
 package main
 
 import (
 	"context"
+	"errors"
 	"expvar"
 	_ "expvar"
 	"flag"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/backtest"
+	"github.com/btmura/ponzi2/internal/screener"
 	"github.com/btmura/ponzi2/internal/stock/iex"
 )
 
@@ -42,6 +53,20 @@ func main() {
 	// 	}
 	// }()
 
+	if flag.Arg(0) == "screen" {
+		if err := screen(*token, strings.Join(flag.Args()[1:], " ")); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "backtest" {
+		if err := runBacktest(*token, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ctx := context.Background()
 
 	var client *iex.Client
@@ -228,6 +253,176 @@ func clearCacheData(ctx context.Context, cache chartCache, symbols []string, tok
 	}
 }
 
+// screen prints the symbols in the chart cache whose daily charts match the expression.
+func screen(token, expr string) error {
+	f, err := screener.Parse(expr)
+	if err != nil {
+		return err
+	}
+
+	cache, err := iex.OpenGOBChartCache()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	var matches []string
+	for _, key := range cache.Keys() {
+		if key.Token != token || key.Interval != iex.DailyInterval {
+			continue
+		}
+
+		val, err := cache.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if val == nil || val.Chart == nil {
+			continue
+		}
+
+		if f.Match(modelChart(val.Chart)) {
+			matches = append(matches, key.Symbol)
+		}
+	}
+
+	sort.Strings(matches)
+	for _, s := range matches {
+		fmt.Println(s)
+	}
+
+	return nil
+}
+
+// runBacktest simulates the strategy in the args over the cached daily charts of the symbols in the args.
+func runBacktest(token string, args []string) error {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	entry := fs.String("entry", "", "Screener expression to buy at the next open.")
+	exit := fs.String("exit", "", "Screener expression to sell at the next open. Empty to only exit at the stop.")
+	stop := fs.Float64("stop", 0, "Stop loss below the entry price as a fraction like 0.07 for 7%.")
+	slippage := fs.Float64("slippage", 0, "Slippage of each fill as a fraction like 0.001 for 0.1%.")
+	equity := fs.Bool("equity", false, "Whether to print the equity curve.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *entry == "" {
+		return errors.New("entry cannot be empty")
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("symbols cannot be empty")
+	}
+
+	entryFilter, err := screener.Parse(*entry)
+	if err != nil {
+		return fmt.Errorf("entry: %v", err)
+	}
+
+	strategy := &backtest.Strategy{
+		Entry:    backtest.FilterRule(entryFilter),
+		StopLoss: float32(*stop),
+	}
+
+	if *exit != "" {
+		exitFilter, err := screener.Parse(*exit)
+		if err != nil {
+			return fmt.Errorf("exit: %v", err)
+		}
+		strategy.Exit = backtest.FilterRule(exitFilter)
+	}
+
+	cache, err := iex.OpenGOBChartCache()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	formatDate := func(t time.Time) string {
+		return t.Format("1/2/06")
+	}
+
+	for _, symbol := range fs.Args() {
+		symbol = strings.ToUpper(symbol)
+
+		val, err := cache.Get(ctx, iex.ChartCacheKey{
+			Token:    token,
+			Symbol:   symbol,
+			Interval: iex.DailyInterval,
+		})
+		if err != nil {
+			return err
+		}
+
+		if val == nil || val.Chart == nil {
+			fmt.Printf("%s: no cached chart\n\n", symbol)
+			continue
+		}
+
+		res, err := backtest.Run(modelChart(val.Chart).TradingSessionSeries, strategy, backtest.Options{Slippage: float32(*slippage)})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: %d trades\n", symbol, len(res.Trades))
+		for i, t := range res.Trades {
+			fmt.Printf("\t%d: %s %.2f -> %s %.2f %s %+.2f%%\n",
+				i,
+				formatDate(t.EntryDate),
+				t.EntryPrice,
+				formatDate(t.ExitDate),
+				t.ExitPrice,
+				t.ExitReason,
+				t.Return*100)
+		}
+
+		fmt.Printf("\tWin rate: %.2f%% Expectancy: %+.2f%% Max drawdown: %.2f%%",
+			res.WinRate*100,
+			res.Expectancy*100,
+			res.MaxDrawdown*100)
+		if n := len(res.EquityCurve); n != 0 {
+			fmt.Printf(" Equity: %.4f", res.EquityCurve[n-1].Equity)
+		}
+		fmt.Println()
+
+		if *equity {
+			for _, p := range res.EquityCurve {
+				fmt.Printf("\t%s %.4f\n", formatDate(p.Date), p.Equity)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// modelChart converts the chart points into a daily chart that the screener can match.
+func modelChart(ch *iex.Chart) *model.Chart {
+	ts := &model.TradingSessionSeries{}
+	for _, p := range ch.ChartPoints {
+		ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{
+			Date:          p.Date,
+			Open:          p.Open,
+			High:          p.High,
+			Low:           p.Low,
+			Close:         p.Close,
+			Volume:        p.Volume,
+			Change:        p.Change,
+			PercentChange: p.ChangePercent,
+		})
+	}
+	sort.Slice(ts.TradingSessions, func(i, j int) bool {
+		return ts.TradingSessions[i].Date.Before(ts.TradingSessions[j].Date)
+	})
+	return &model.Chart{
+		Interval:             model.Daily,
+		TradingSessionSeries: ts,
+	}
+}
+
 func pick(prompt string, choices ...interface{}) interface{} {
 	selected := 0
 	for {
@@ -0,0 +1,81 @@
+// The notifytool command sends a test notification to check that notifications work.
+// It uses the notification settings in the user's config unless flags are given.
+// go run cmd/notifytool/notifytool.go -desktop -webhook_url URL
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/config"
+	"github.com/btmura/ponzi2/internal/notify"
+)
+
+var (
+	desktop    = flag.Bool("desktop", false, "Whether to send a desktop notification.")
+	webhookURL = flag.String("webhook_url", "", "URL to post a JSON notification to.")
+	message    = flag.String("message", "Notifications work.", "Message of the test notification.")
+)
+
+func main() {
+	flag.Parse()
+
+	settings := config.NotifySettings{
+		Desktop:    *desktop,
+		WebhookURL: *webhookURL,
+	}
+
+	if !settings.Desktop && settings.WebhookURL == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatal(err)
+		}
+		settings = cfg.Settings.NotifySettings
+	}
+
+	notifiers := map[string]notify.Notifier{}
+
+	if settings.Desktop {
+		d, err := notify.NewDesktopNotifier()
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifiers["desktop"] = d
+	}
+
+	if u := settings.WebhookURL; u != "" {
+		w, err := notify.NewWebhookNotifier(u)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifiers["webhook"] = w
+	}
+
+	if len(notifiers) == 0 {
+		log.Fatal("no notifiers in flags or config")
+	}
+
+	e := &notify.Event{
+		Kind:    notify.Test,
+		Title:   "ponzi2 test",
+		Message: *message,
+		Time:    time.Now(),
+	}
+
+	failed := false
+	for name, n := range notifiers {
+		if err := n.Notify(context.Background(), e); err != nil {
+			fmt.Printf("%s: %v\n", name, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: sent\n", name)
+	}
+
+	if failed {
+		log.Fatal("some notifications failed")
+	}
+}
@@ -0,0 +1,331 @@
+// Package alert checks conditions like a price crossing a level against a symbol's daily trading sessions.
+//
+// Alerts are parsed from specs like the following:
+//
+//	above 150         the close crosses above 150
+//	below 150         the close crosses below 150
+//	up 5%             the close is up 5% or more from the previous close
+//	down 5%           the close is down 5% or more from the previous close
+//	above sma50       the close crosses above the 50 day simple moving average
+//	below ema20       the close crosses below the 20 day exponential moving average
+//	volume 2x         the volume is 2 or more times the 50 day average volume
+//
+// Specs can end with a re-arm policy of once, daily, or reset. The default is daily.
+package alert
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/indicator"
+)
+
+// averageVolumePeriods is how many sessions before the last session to average the volume over.
+const averageVolumePeriods = 50
+
+// Condition is the condition that triggers an alert.
+type Condition int
+
+// Condition values.
+const (
+	ConditionUnspecified Condition = iota
+	PriceAbove
+	PriceBelow
+	PercentUp
+	PercentDown
+	AverageAbove
+	AverageBelow
+	VolumeAbove
+)
+
+// RearmPolicy is when a triggered alert can trigger again.
+type RearmPolicy int
+
+// RearmPolicy values.
+const (
+	RearmPolicyUnspecified RearmPolicy = iota
+
+	// RearmOnce never triggers again.
+	RearmOnce
+
+	// RearmDaily triggers again on the next trading session.
+	RearmDaily
+
+	// RearmReset triggers again after the condition is no longer met.
+	RearmReset
+)
+
+// Alert triggers when a symbol's trading sessions meet a condition.
+// Fields are exported for gob encoding and decoding.
+type Alert struct {
+	// Symbol is the symbol to check.
+	Symbol string
+
+	// Condition is the condition to check.
+	Condition Condition
+
+	// Value is the price level, percent, or volume multiple depending on the condition.
+	Value float32
+
+	// AverageType is the type of moving average for the AverageAbove and AverageBelow conditions.
+	AverageType model.AverageType
+
+	// Periods is the number of sessions of the moving average.
+	Periods int
+
+	// Rearm is when the alert can trigger again.
+	Rearm RearmPolicy
+
+	// Disarmed is true if the alert triggered and cannot trigger again until it is re-armed.
+	Disarmed bool
+
+	// TriggerDate is the date of the session that last triggered the alert.
+	TriggerDate time.Time
+}
+
+// Trigger is a record of an alert that triggered.
+type Trigger struct {
+	// Symbol is the symbol of the alert.
+	Symbol string
+
+	// Message describes the condition that was met like "AAPL crossed above 150.00".
+	Message string
+
+	// Time is when the alert triggered.
+	Time time.Time
+}
+
+// specRegexp matches specs like "above 150", "down 5%", "above sma50", or "volume 2x daily".
+var specRegexp = regexp.MustCompile(`^(above|below|up|down|volume)\s+(?:([0-9.]+)(%|x)?|(sma|ema|wma)([0-9]+))(?:\s+(once|daily|reset))?$`)
+
+// averageTypes maps moving average names to their types.
+var averageTypes = map[string]model.AverageType{
+	"sma": model.Simple,
+	"ema": model.Exponential,
+	"wma": model.Weighted,
+}
+
+// rearmPolicies maps re-arm policy names to policies.
+var rearmPolicies = map[string]RearmPolicy{
+	"once":  RearmOnce,
+	"daily": RearmDaily,
+	"reset": RearmReset,
+}
+
+// Parse parses a case insensitive spec like "above 150" into an alert for the symbol.
+func Parse(symbol, spec string) (*Alert, error) {
+	if err := model.ValidateSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	m := specRegexp.FindStringSubmatch(strings.ToLower(strings.Join(strings.Fields(spec), " ")))
+	if m == nil {
+		return nil, errs.Errorf("bad alert: %q", spec)
+	}
+
+	verb, number, unit, avgName, avgPeriods, rearm := m[1], m[2], m[3], m[4], m[5], m[6]
+
+	a := &Alert{
+		Symbol: symbol,
+		Rearm:  RearmDaily,
+	}
+
+	if rearm != "" {
+		a.Rearm = rearmPolicies[rearm]
+	}
+
+	if avgName != "" {
+		periods, err := strconv.Atoi(avgPeriods)
+		if err != nil || periods <= 0 {
+			return nil, errs.Errorf("bad moving average periods: %q", avgPeriods)
+		}
+
+		switch verb {
+		case "above":
+			a.Condition = AverageAbove
+		case "below":
+			a.Condition = AverageBelow
+		default:
+			return nil, errs.Errorf("bad alert: %q, want above or below a moving average", spec)
+		}
+
+		a.AverageType = averageTypes[avgName]
+		a.Periods = periods
+		return a, nil
+	}
+
+	value, err := strconv.ParseFloat(number, 32)
+	if err != nil || value <= 0 {
+		return nil, errs.Errorf("bad alert value: %q", number)
+	}
+	a.Value = float32(value)
+
+	switch {
+	case verb == "above" && unit == "":
+		a.Condition = PriceAbove
+	case verb == "below" && unit == "":
+		a.Condition = PriceBelow
+	case verb == "up" && unit == "%":
+		a.Condition = PercentUp
+	case verb == "down" && unit == "%":
+		a.Condition = PercentDown
+	case verb == "volume" && unit == "x":
+		a.Condition = VolumeAbove
+	default:
+		return nil, errs.Errorf("bad alert: %q", spec)
+	}
+
+	return a, nil
+}
+
+// String returns the spec of the alert like "above 150.00 daily".
+func (a *Alert) String() string {
+	return fmt.Sprintf("%s %s", a.condition(), rearmName(a.Rearm))
+}
+
+// Message returns a description of the met condition like "AAPL crossed above 150.00".
+func (a *Alert) Message() string {
+	switch a.Condition {
+	case PriceAbove, PriceBelow, AverageAbove, AverageBelow:
+		return fmt.Sprintf("%s crossed %s", a.Symbol, a.condition())
+	case PercentUp, PercentDown:
+		return fmt.Sprintf("%s is %s", a.Symbol, a.condition())
+	case VolumeAbove:
+		return fmt.Sprintf("%s traded %.1fx its average volume", a.Symbol, a.Value)
+	default:
+		return fmt.Sprintf("%s triggered an alert", a.Symbol)
+	}
+}
+
+// condition returns the condition part of the spec like "above 150.00".
+func (a *Alert) condition() string {
+	switch a.Condition {
+	case PriceAbove:
+		return fmt.Sprintf("above %.2f", a.Value)
+	case PriceBelow:
+		return fmt.Sprintf("below %.2f", a.Value)
+	case PercentUp:
+		return fmt.Sprintf("up %g%%", a.Value)
+	case PercentDown:
+		return fmt.Sprintf("down %g%%", a.Value)
+	case AverageAbove:
+		return fmt.Sprintf("above %s%d", averageName(a.AverageType), a.Periods)
+	case AverageBelow:
+		return fmt.Sprintf("below %s%d", averageName(a.AverageType), a.Periods)
+	case VolumeAbove:
+		return fmt.Sprintf("volume %gx", a.Value)
+	default:
+		return "?"
+	}
+}
+
+// Check returns true if the alert is armed and the last session meets the condition.
+// It disarms the alert when it triggers and re-arms it according to the re-arm policy.
+func (a *Alert) Check(ts *model.TradingSessionSeries) (triggered bool) {
+	if ts == nil || len(ts.TradingSessions) == 0 {
+		return false
+	}
+
+	lastDate := ts.TradingSessions[len(ts.TradingSessions)-1].Date
+
+	if !a.met(ts.TradingSessions) {
+		if a.Rearm == RearmReset {
+			a.Disarmed = false
+		}
+		return false
+	}
+
+	if a.Disarmed {
+		if a.Rearm != RearmDaily || !lastDate.After(a.TriggerDate) {
+			return false
+		}
+		a.Disarmed = false
+	}
+
+	a.Disarmed = true
+	a.TriggerDate = lastDate
+	return true
+}
+
+// met returns true if the last session meets the alert's condition.
+func (a *Alert) met(ts []*model.TradingSession) bool {
+	if len(ts) < 2 {
+		return false
+	}
+
+	prev, last := ts[len(ts)-2], ts[len(ts)-1]
+
+	switch a.Condition {
+	case PriceAbove:
+		return prev.Close < a.Value && last.Close >= a.Value
+
+	case PriceBelow:
+		return prev.Close > a.Value && last.Close <= a.Value
+
+	case PercentUp:
+		return last.PercentChange >= a.Value
+
+	case PercentDown:
+		return last.PercentChange <= -a.Value
+
+	case AverageAbove, AverageBelow:
+		if len(ts) <= a.Periods {
+			return false
+		}
+
+		ind := indicator.NewMovingAverage(a.AverageType, a.Periods)
+		series := ind.Compute(&model.TradingSessionSeries{TradingSessions: ts})
+		if len(series) == 0 || len(series[0].Values) != len(ts) {
+			return false
+		}
+
+		values := series[0].Values
+		prevAvg, lastAvg := values[len(values)-2].Value, values[len(values)-1].Value
+		if prevAvg == 0 || lastAvg == 0 {
+			return false
+		}
+
+		if a.Condition == AverageAbove {
+			return prev.Close < prevAvg && last.Close >= lastAvg
+		}
+		return prev.Close > prevAvg && last.Close <= lastAvg
+
+	case VolumeAbove:
+		if len(ts) <= averageVolumePeriods {
+			return false
+		}
+
+		var sum float32
+		for _, s := range ts[len(ts)-1-averageVolumePeriods : len(ts)-1] {
+			sum += float32(s.Volume)
+		}
+		avg := sum / averageVolumePeriods
+		return avg > 0 && float32(last.Volume) >= a.Value*avg
+
+	default:
+		return false
+	}
+}
+
+func averageName(avgType model.AverageType) string {
+	for name, t := range averageTypes {
+		if t == avgType {
+			return name
+		}
+	}
+	return "?"
+}
+
+func rearmName(rearm RearmPolicy) string {
+	for name, r := range rearmPolicies {
+		if r == rearm {
+			return name
+		}
+	}
+	return "?"
+}
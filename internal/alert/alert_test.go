@@ -0,0 +1,197 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		input   string
+		want    *Alert
+		wantErr bool
+	}{
+		{
+			desc:  "price above",
+			input: "above 150",
+			want:  &Alert{Symbol: "AAPL", Condition: PriceAbove, Value: 150, Rearm: RearmDaily},
+		},
+		{
+			desc:  "price below once",
+			input: "Below 99.5 ONCE",
+			want:  &Alert{Symbol: "AAPL", Condition: PriceBelow, Value: 99.5, Rearm: RearmOnce},
+		},
+		{
+			desc:  "percent down",
+			input: "down  5%",
+			want:  &Alert{Symbol: "AAPL", Condition: PercentDown, Value: 5, Rearm: RearmDaily},
+		},
+		{
+			desc:  "moving average cross",
+			input: "above ema20 reset",
+			want:  &Alert{Symbol: "AAPL", Condition: AverageAbove, AverageType: model.Exponential, Periods: 20, Rearm: RearmReset},
+		},
+		{
+			desc:  "volume multiple",
+			input: "volume 2x",
+			want:  &Alert{Symbol: "AAPL", Condition: VolumeAbove, Value: 2, Rearm: RearmDaily},
+		},
+		{
+			desc:    "percent without direction",
+			input:   "above 5%",
+			wantErr: true,
+		},
+		{
+			desc:    "volume without multiple",
+			input:   "volume 2",
+			wantErr: true,
+		},
+		{
+			desc:    "up a moving average",
+			input:   "up sma50",
+			wantErr: true,
+		},
+		{
+			desc:    "zero periods",
+			input:   "above sma0",
+			wantErr: true,
+		},
+		{
+			desc:    "unknown policy",
+			input:   "above 150 twice",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := Parse("AAPL", tt.input)
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Fatalf("got error: %v, wanted err: %t", gotErr, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAlertString(t *testing.T) {
+	for _, spec := range []string{
+		"above 150.00 daily",
+		"down 2.5% once",
+		"below sma50 reset",
+		"volume 3x daily",
+	} {
+		a, err := Parse("AAPL", spec)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", spec, err)
+		}
+
+		if got := a.String(); got != spec {
+			t.Errorf("got %q, want %q", got, spec)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	day := func(i int) time.Time {
+		return time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i)
+	}
+
+	// series returns sessions on consecutive days with the given closes.
+	series := func(closes ...float32) *model.TradingSessionSeries {
+		ts := &model.TradingSessionSeries{}
+		for i, c := range closes {
+			ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{Date: day(i), Close: c})
+		}
+		return ts
+	}
+
+	// volumes returns sessions with an average volume of 100 followed by the last volume.
+	volumes := func(last int) *model.TradingSessionSeries {
+		ts := &model.TradingSessionSeries{}
+		for i := 0; i < averageVolumePeriods; i++ {
+			ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{Date: day(i), Volume: 100})
+		}
+		ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{Date: day(averageVolumePeriods), Volume: last})
+		return ts
+	}
+
+	for _, tt := range []struct {
+		desc   string
+		alert  *Alert
+		checks []*model.TradingSessionSeries
+		want   []bool
+	}{
+		{
+			desc:   "price crosses above",
+			alert:  &Alert{Condition: PriceAbove, Value: 10, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{series(9, 9), series(9, 9, 10)},
+			want:   []bool{false, true},
+		},
+		{
+			desc:   "price already above",
+			alert:  &Alert{Condition: PriceAbove, Value: 10, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{series(11, 12)},
+			want:   []bool{false},
+		},
+		{
+			desc:   "daily triggers once per session",
+			alert:  &Alert{Condition: PriceBelow, Value: 10, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{series(11, 9), series(11, 9), series(11, 9, 11, 9)},
+			want:   []bool{true, false, true},
+		},
+		{
+			desc:   "once never triggers again",
+			alert:  &Alert{Condition: PriceBelow, Value: 10, Rearm: RearmOnce},
+			checks: []*model.TradingSessionSeries{series(11, 9), series(11, 9, 11, 9)},
+			want:   []bool{true, false},
+		},
+		{
+			desc:   "reset triggers again after the condition clears",
+			alert:  &Alert{Condition: PriceBelow, Value: 10, Rearm: RearmReset},
+			checks: []*model.TradingSessionSeries{series(11, 9), series(11, 9), series(11, 9, 11), series(11, 9, 11, 9)},
+			want:   []bool{true, false, false, true},
+		},
+		{
+			desc:   "moving average cross above",
+			alert:  &Alert{Condition: AverageAbove, AverageType: model.Simple, Periods: 2, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{series(10, 8, 6, 12)},
+			want:   []bool{true},
+		},
+		{
+			desc:   "moving average without enough sessions",
+			alert:  &Alert{Condition: AverageAbove, AverageType: model.Simple, Periods: 50, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{series(10, 8, 6, 12)},
+			want:   []bool{false},
+		},
+		{
+			desc:   "volume above average",
+			alert:  &Alert{Condition: VolumeAbove, Value: 2, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{volumes(150), volumes(200)},
+			want:   []bool{false, true},
+		},
+		{
+			desc:   "percent up",
+			alert:  &Alert{Condition: PercentUp, Value: 5, Rearm: RearmDaily},
+			checks: []*model.TradingSessionSeries{{TradingSessions: []*model.TradingSession{{Close: 10}, {Close: 11, PercentChange: 10}}}},
+			want:   []bool{true},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []bool
+			for _, ts := range tt.checks {
+				got = append(got, tt.alert.Check(ts))
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
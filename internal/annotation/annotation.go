@@ -0,0 +1,189 @@
+// Package annotation stores the user's drawings on the charts of each symbol.
+//
+// Drawings are trendlines, rays, and horizontal price levels drawn with the mouse. They are anchored
+// to dates and prices instead of pixels, so they stay put when the chart's interval or size changes.
+//
+// The drawings of all the symbols are stored in a gob file.
+package annotation
+
+import (
+	"encoding/gob"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/logger"
+)
+
+// Kind is the kind of drawing.
+type Kind int
+
+// Kind values.
+const (
+	KindUnspecified Kind = iota
+
+	// Trendline is a line segment between two points.
+	Trendline
+
+	// Ray is a line that starts at one point and extends through another to the edge of the chart.
+	Ray
+
+	// Level is a horizontal line across the chart at a price.
+	Level
+)
+
+// String returns the lowercase name of the kind like "ray".
+func (k Kind) String() string {
+	switch k {
+	case Trendline:
+		return "line"
+	case Ray:
+		return "ray"
+	case Level:
+		return "level"
+	default:
+		return "?"
+	}
+}
+
+// ParseKind parses a case insensitive kind like "line", "ray", or "level".
+func ParseKind(s string) (Kind, error) {
+	for _, k := range []Kind{Trendline, Ray, Level} {
+		if strings.EqualFold(strings.TrimSpace(s), k.String()) {
+			return k, nil
+		}
+	}
+	return KindUnspecified, errs.Errorf("bad drawing: %q, want line, ray, or level", s)
+}
+
+// Point is a point on a chart anchored to a date and a price.
+type Point struct {
+	// Date is the time of the point, which can fall between the start times of sessions.
+	Date time.Time
+
+	// Price is the price of the point.
+	Price float32
+}
+
+// Drawing is a line drawn on a symbol's charts.
+// Fields are exported for gob encoding and decoding.
+type Drawing struct {
+	// Kind is the kind of drawing.
+	Kind Kind
+
+	// Start is where trendlines and rays start. Levels are only at the start's price.
+	Start Point
+
+	// End is where trendlines end and rays pass through. Unused by levels.
+	End Point
+}
+
+// validateDrawing returns an error if the drawing is missing its kind or has points without dates or prices.
+func validateDrawing(d *Drawing) error {
+	if d.Kind == KindUnspecified {
+		return errs.Errorf("unspecified kind")
+	}
+
+	points := []Point{d.Start}
+	if d.Kind != Level {
+		points = append(points, d.End)
+	}
+
+	for _, p := range points {
+		if p.Date.IsZero() {
+			return errs.Errorf("missing date")
+		}
+
+		if p.Price <= 0 {
+			return errs.Errorf("price must be positive: %v", p.Price)
+		}
+	}
+
+	return nil
+}
+
+// Store has the drawings of each symbol and saves them to a file whenever they change.
+type Store struct {
+	// path is the path of the file to save the drawings to.
+	path string
+
+	// symbol2Drawings maps symbol to the symbol's drawings in the order they were drawn.
+	symbol2Drawings map[string][]*Drawing
+}
+
+// Open loads the store from the file at the path. No drawings if the file does not exist.
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path:            path,
+		symbol2Drawings: map[string][]*Drawing{},
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing in open failed: %v", err)
+		}
+	}()
+
+	if err := gob.NewDecoder(file).Decode(&s.symbol2Drawings); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Drawings returns copies of the symbol's drawings in the order they were drawn.
+func (s *Store) Drawings(symbol string) []*Drawing {
+	var drawings []*Drawing
+	for _, d := range s.symbol2Drawings[symbol] {
+		d := *d
+		drawings = append(drawings, &d)
+	}
+	return drawings
+}
+
+// SetDrawings replaces the symbol's drawings and saves the store. No drawings removes the symbol.
+func (s *Store) SetDrawings(symbol string, drawings []*Drawing) error {
+	if err := model.ValidateSymbol(symbol); err != nil {
+		return err
+	}
+
+	var copies []*Drawing
+	for _, d := range drawings {
+		if err := validateDrawing(d); err != nil {
+			return err
+		}
+		d := *d
+		copies = append(copies, &d)
+	}
+
+	if len(copies) == 0 {
+		delete(s.symbol2Drawings, symbol)
+	} else {
+		s.symbol2Drawings[symbol] = copies
+	}
+
+	return s.save()
+}
+
+// save saves the drawings to the store's file.
+func (s *Store) save() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing in save failed: %v", err)
+		}
+	}()
+
+	return gob.NewEncoder(file).Encode(s.symbol2Drawings)
+}
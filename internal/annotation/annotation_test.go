@@ -0,0 +1,97 @@
+package annotation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseKind(t *testing.T) {
+	for _, tt := range []struct {
+		s       string
+		want    Kind
+		wantErr bool
+	}{
+		{"line", Trendline, false},
+		{"Ray", Ray, false},
+		{" level ", Level, false},
+		{"circle", KindUnspecified, true},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			got, gotErr := ParseKind(tt.s)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "annotation")
+	if err != nil {
+		t.Fatalf("TempDir returned error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "annotations.gob")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) of missing file returned error: %v", path, err)
+	}
+
+	if diff := cmp.Diff([]*Drawing(nil), s.Drawings("SPY")); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	jan := time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2020, time.February, 3, 0, 0, 0, 0, time.UTC)
+
+	want := []*Drawing{
+		{Kind: Trendline, Start: Point{jan, 300}, End: Point{feb, 330}},
+		{Kind: Level, Start: Point{jan, 320}},
+	}
+
+	if err := s.SetDrawings("SPY", want); err != nil {
+		t.Fatalf("SetDrawings returned error: %v", err)
+	}
+
+	if err := s.SetDrawings("SPY", []*Drawing{{Kind: Ray, Start: Point{jan, 300}}}); err == nil {
+		t.Error("SetDrawings should return an error for a ray without an end.")
+	}
+
+	if err := s.SetDrawings("", want); err == nil {
+		t.Error("SetDrawings should return an error for a missing symbol.")
+	}
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) returned error: %v", path, err)
+	}
+
+	if diff := cmp.Diff(want, s.Drawings("SPY")); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if err := s.SetDrawings("SPY", nil); err != nil {
+		t.Fatalf("SetDrawings returned error: %v", err)
+	}
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) returned error: %v", path, err)
+	}
+
+	if diff := cmp.Diff([]*Drawing(nil), s.Drawings("SPY")); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
@@ -8,16 +8,54 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/btmura/ponzi2/internal/alert"
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view/chart"
 	"github.com/btmura/ponzi2/internal/logger"
+	"github.com/btmura/ponzi2/internal/portfolio"
 )
 
 // Config configures the app.
 type Config struct {
 	CurrentStock *Stock
-	Stocks       []*Stock
-	Settings     Settings
+
+	// Stocks are the sidebar's stocks from before watchlists. Only loaded if there are no watchlists.
+	Stocks []*Stock
+
+	// Watchlists are the named lists of stocks that the sidebar can show.
+	Watchlists []*Watchlist
+
+	// Comparisons are the stocks compared against the current stock by percent change.
+	Comparisons []*Stock
+
+	// Alerts are the alerts checked whenever a stock updates.
+	Alerts []*alert.Alert
+
+	// AlertHistory are the alerts that triggered from oldest to newest.
+	AlertHistory []*alert.Trigger
+
+	// Lots are the lots of the user's positions.
+	Lots []*portfolio.Lot
+
+	Settings Settings
+}
+
+// Watchlist is a named list of stocks.
+type Watchlist struct {
+	Name string
+
+	// Stocks are the stocks above the titled sections.
+	Stocks []*Stock
+
+	// Sections are the titled groups of stocks below the Stocks.
+	Sections []*Section
+}
+
+// Section is a titled group of stocks in a watchlist.
+type Section struct {
+	Name      string
+	Collapsed bool
+	Stocks    []*Stock
 }
 
 // Stock identifies a single stock by symbol.
@@ -27,13 +65,85 @@ type Stock struct {
 
 // Settings has the user's settings.
 type Settings struct {
-	ChartSettings ChartSettings
+	ChartSettings   ChartSettings
+	SidebarSettings SidebarSettings
+	NotifySettings  NotifySettings
+}
+
+// NotifySettings has the user's settings for notifications of triggered alerts, new highs, and failed refreshes.
+type NotifySettings struct {
+	// Desktop is whether to show desktop notifications.
+	Desktop bool
+
+	// WebhookURL is a URL to post notifications to as JSON. Empty to not post them.
+	WebhookURL string
+
+	// RateLimit is how many notifications each notifier sends per minute. Zero to use the default.
+	RateLimit int
+}
+
+// SidebarSettings has the user's sidebar settings.
+type SidebarSettings struct {
+	// Filter is a screener expression that the sidebar's stocks must match to be shown. Empty to show all.
+	Filter string
+
+	// Watchlist is the name of the watchlist shown in the sidebar. Empty to show the first one.
+	Watchlist string
+
+	// SortMode is how the sidebar is sorted. Unspecified to sort manually.
+	SortMode model.SortMode
 }
 
 // ChartSettings has the user's chart settings.
 type ChartSettings struct {
 	PriceStyle chart.PriceStyle
 	Interval   model.Interval
+
+	// PriceScale is whether the main chart shows prices on a linear or log scale. Unspecified to use the log scale.
+	PriceScale chart.PriceScale
+
+	// ThumbPriceStyle is the price style of the sidebar thumbnails. Unspecified to use the PriceStyle.
+	ThumbPriceStyle chart.PriceStyle
+
+	// MovingAverages are the moving averages for each interval. Nil to use the defaults.
+	MovingAverages map[model.Interval][]*chart.MovingAverage
+
+	// RSIPeriods is the number of periods of the RSI pane. Zero to use the default.
+	RSIPeriods int
+
+	// ShowMACD is whether to show the MACD pane.
+	ShowMACD bool
+
+	// ShowVolumeProfile is whether to show the volume profile on the main chart.
+	ShowVolumeProfile bool
+
+	// Benchmark is the symbol that the relative strength line compares against. Empty to use the default.
+	Benchmark string
+
+	// RSUniverseFile is a file of symbols, one per line, to rank relative strength ratings against.
+	// Empty to rank the watchlist against itself.
+	RSUniverseFile string
+
+	// Layout is how many charts the main area shows. Unspecified to show one chart.
+	Layout model.ChartLayout
+
+	// Cells are the charts of the layout from the top left. Nil to show only the CurrentStock.
+	Cells []*ChartCell
+
+	// FocusedCell is the index of the chart that the CurrentStock is shown in and receives keyboard input.
+	FocusedCell int
+
+	// Linked is whether the two charts of the layout show the CurrentStock's daily and weekly charts together.
+	Linked bool
+}
+
+// ChartCell is one of the charts in the main area's layout.
+type ChartCell struct {
+	// Symbol is the symbol of the stock shown in the chart. Empty if the chart is empty.
+	Symbol string
+
+	// Interval is the interval of the chart. Unspecified to use the ChartSettings' Interval.
+	Interval model.Interval
 }
 
 // Load loads the user's config from disk.
@@ -88,6 +198,24 @@ func Save(cfg *Config) error {
 	return gob.NewEncoder(file).Encode(cfg)
 }
 
+// JournalPath returns the path of the user's trade journal file next to the config.
+func JournalPath() (string, error) {
+	dirPath, err := userConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(dirPath, "journal.gob"), nil
+}
+
+// AnnotationsPath returns the path of the user's chart drawings file next to the config.
+func AnnotationsPath() (string, error) {
+	dirPath, err := userConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(dirPath, "annotations.gob"), nil
+}
+
 func userConfigPath() (string, error) {
 	dirPath, err := userConfigDir()
 	if err != nil {
@@ -5,16 +5,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/btmura/ponzi2/internal/alert"
+	"github.com/btmura/ponzi2/internal/annotation"
 	"github.com/btmura/ponzi2/internal/app/config"
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view/chart"
 	"github.com/btmura/ponzi2/internal/app/view/ui"
 	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/indicator"
+	"github.com/btmura/ponzi2/internal/journal"
 	"github.com/btmura/ponzi2/internal/logger"
+	"github.com/btmura/ponzi2/internal/notify"
+	"github.com/btmura/ponzi2/internal/portfolio"
+	"github.com/btmura/ponzi2/internal/rating"
+	"github.com/btmura/ponzi2/internal/screener"
 	"github.com/btmura/ponzi2/internal/stock/iex"
 )
 
+// defaultBenchmark is the symbol that the relative strength line compares against by default.
+const defaultBenchmark = "SPY"
+
+// maxAlertHistory is how many triggered alerts to keep in the history.
+const maxAlertHistory = 100
+
+// newHighSessions is how many daily sessions a new high must be above like a 52-week high.
+const newHighSessions = 252
+
 // Controller runs the program in a "game loop".
 type Controller struct {
 	// model is the data that the Controller connects to the View.
@@ -26,19 +45,126 @@ type Controller struct {
 	// chartInterval is the current interval to use for charts and thumbnails.
 	chartInterval model.Interval
 
-	// chartPriceStyle is the current price style for charts and thumbnails.
+	// chartLayout is how many charts the main area shows.
+	chartLayout model.ChartLayout
+
+	// chartCells are the charts of the layout from the top left.
+	chartCells []*chartCell
+
+	// focusedCell is the index of the chart cell that shows the model's current symbol
+	// and that entered symbols and commands apply to.
+	focusedCell int
+
+	// chartLinked is whether the two charts show the current stock's daily and weekly charts together.
+	chartLinked bool
+
+	// chartPriceStyle is the current price style for charts.
 	chartPriceStyle chart.PriceStyle
 
+	// thumbPriceStyle is the current price style for thumbnails.
+	thumbPriceStyle chart.PriceStyle
+
+	// chartMovingAverages are the moving averages for each interval.
+	chartMovingAverages map[model.Interval][]*chart.MovingAverage
+
+	// chartRSIPeriods is the number of periods of the RSI pane.
+	chartRSIPeriods int
+
+	// chartPriceScale is whether the main chart shows prices on a linear or log scale.
+	chartPriceScale chart.PriceScale
+
+	// chartShowMACD is whether to show the MACD pane.
+	chartShowMACD bool
+
+	// chartShowVolumeProfile is whether to show the volume profile on the main chart.
+	chartShowVolumeProfile bool
+
+	// sidebarFilter shows only the sidebar stocks whose daily charts match. Nil to show all.
+	sidebarFilter *screener.Filter
+
+	// sidebarSortMode is how the sidebar is sorted. The model keeps the manual order for switching back.
+	sidebarSortMode model.SortMode
+
+	// alerts are checked against the daily charts of their symbols whenever a stock updates.
+	alerts []*alert.Alert
+
+	// alertHistory are the alerts that triggered from oldest to newest.
+	alertHistory []*alert.Trigger
+
+	// triggeredAlertSymbols are the symbols with triggered alerts that have not been acknowledged.
+	triggeredAlertSymbols map[string]bool
+
+	// lots are the lots of the user's positions.
+	lots []*portfolio.Lot
+
+	// lotPrices are the latest prices of the lots' symbols by symbol,
+	// since the symbols that aren't shown are not in the model.
+	lotPrices map[string]float32
+
+	// trades are the user's trades recorded in the journal.
+	trades []*journal.Trade
+
+	// journalPath is the path of the journal file to save the trades to. Empty if the journal is unavailable.
+	journalPath string
+
+	// annotations stores the user's drawings on the charts of each symbol. Nil if the store is unavailable.
+	annotations *annotation.Store
+
+	// newHighDates are the dates of the last sessions that notified about new highs by symbol.
+	newHighDates map[string]time.Time
+
+	// pendingRefreshSymbols are the symbols of the last refresh of all stocks that have not updated or failed yet.
+	pendingRefreshSymbols map[string]bool
+
+	// refreshSucceeded is true if any symbol of the last refresh of all stocks updated.
+	refreshSucceeded bool
+
+	// chartBenchmark is the symbol that the relative strength line compares against.
+	chartBenchmark string
+
+	// rsUniverseFile is the file with the symbols to rank relative strength ratings against.
+	// Empty to rank the symbols of the current chart and the sidebar against each other.
+	rsUniverseFile string
+
+	// rsUniverse are the symbols loaded from the rsUniverseFile.
+	rsUniverse []string
+
+	// rsRatings are the latest relative strength ratings by symbol.
+	rsRatings map[string]int
+
+	// rsRatingsDay is the local date of the last ranking, since the ratings use daily closes
+	// that change at most once a day.
+	rsRatingsDay time.Time
+
+	// rsRatingsSymbols are the symbols of the last ranking, so new symbols are ranked the same day.
+	rsRatingsSymbols map[string]bool
+
+	// pendingRSRatingsSymbols are the symbols being ranked. They become the rsRatingsSymbols
+	// once the ratings arrive, so a failed ranking is retried on the next refresh.
+	pendingRSRatingsSymbols map[string]bool
+
 	// stockRefresher offers methods to refresh one or many stocks.
 	stockRefresher *stockRefresher
 
 	// configSaver offers methods to save configs in the background.
 	configSaver *configSaver
 
+	// notifier offers methods to send notifications in the background.
+	notifier *notifier
+
 	// eventController offers methods to queue and process events in the main loop.
 	eventController *eventController
 }
 
+// chartCell is one of the charts in the main area's layout.
+type chartCell struct {
+	// symbol is the symbol of the stock shown in the chart. Empty if the chart is empty.
+	symbol string
+
+	// interval is the interval of the chart. Unspecified to use the chartInterval.
+	interval model.Interval
+}
+
 // iexClientInterface is implemented by clients in the iex package to get stock data.
 type iexClientInterface interface {
 	GetQuotes(ctx context.Context, req *iex.GetQuotesRequest) ([]*iex.Quote, error)
@@ -48,9 +174,15 @@ type iexClientInterface interface {
 // New creates a new Controller.
 func New(iexClient iexClientInterface, token string) *Controller {
 	c := &Controller{
-		model:       model.New(),
-		ui:          ui.New(),
-		configSaver: newConfigSaver(),
+		model:                 model.New(),
+		ui:                    ui.New(),
+		chartLayout:           model.SingleChart,
+		chartCells:            []*chartCell{{}},
+		configSaver:           newConfigSaver(),
+		notifier:              newNotifier(),
+		triggeredAlertSymbols: map[string]bool{},
+		newHighDates:          map[string]time.Time{},
+		lotPrices:             map[string]float32{},
 	}
 	c.eventController = newEventController(c)
 	c.stockRefresher = newStockRefresher(iexClient, token, c.eventController)
@@ -82,32 +214,160 @@ func (c *Controller) RunLoop() error {
 	}
 	c.setChartPriceStyle(priceStyle)
 
+	thumbPriceStyle := priceStyle
+	if p := settings.ThumbPriceStyle; p != chart.PriceStyleUnspecified {
+		thumbPriceStyle = p
+	}
+	c.setThumbPriceStyle(thumbPriceStyle)
+
 	interval := model.Daily
 	if i := settings.Interval; i != model.IntervalUnspecified {
 		interval = i
 	}
 	c.setChartInterval(interval)
 
-	// Add the user's stocks to the UI.
-	if cfg.CurrentStock != nil {
-		if s := cfg.CurrentStock.Symbol; s != "" {
-			if err := c.setChart(ctx, s); err != nil {
-				return err
-			}
+	c.chartMovingAverages = map[model.Interval][]*chart.MovingAverage{}
+	for _, i := range []model.Interval{model.Daily, model.Weekly} {
+		mas := settings.MovingAverages[i]
+		if err := validateMovingAverages(mas); err != nil {
+			logger.Errorf("bad %v moving averages, using the defaults: %v", i, err)
+			mas = nil
+		}
+		if mas == nil {
+			mas = chart.DefaultMovingAverages(i)
+		}
+		c.chartMovingAverages[i] = mas
+	}
+
+	c.chartRSIPeriods = indicator.DefaultRSIPeriods
+	if p := settings.RSIPeriods; p > 0 {
+		c.chartRSIPeriods = p
+	}
+
+	c.chartShowMACD = settings.ShowMACD
+	c.chartShowVolumeProfile = settings.ShowVolumeProfile
+
+	c.chartPriceScale = chart.LogScale
+	if s := settings.PriceScale; s != chart.PriceScaleUnspecified {
+		c.chartPriceScale = s
+	}
+
+	c.chartBenchmark = defaultBenchmark
+	if b := settings.Benchmark; b != "" {
+		if err := model.ValidateSymbol(b); err != nil {
+			logger.Errorf("bad benchmark, using %s: %v", defaultBenchmark, err)
+		} else {
+			c.chartBenchmark = b
+		}
+	}
+	c.stockRefresher.setBenchmark(c.chartBenchmark)
+
+	c.rsUniverseFile = settings.RSUniverseFile
+	if f := c.rsUniverseFile; f != "" {
+		symbols, err := rating.LoadUniverse(f)
+		if err != nil {
+			logger.Errorf("bad universe file, ranking the watchlist: %v", err)
 		}
+		c.rsUniverse = symbols
+	}
+
+	c.stockRefresher.setIndicators(chartIndicators(c.chartMovingAverages, c.chartRSIPeriods))
+
+	// Add the user's stocks to the UI.
+	if err := c.loadChartLayout(ctx, cfg); err != nil {
+		return err
+	}
+
+	if err := c.loadWatchlists(ctx, cfg); err != nil {
+		return err
 	}
 
-	for _, cs := range cfg.Stocks {
+	for _, cs := range cfg.Comparisons {
 		if s := cs.Symbol; s != "" {
-			if err := c.addChartThumb(ctx, s); err != nil {
+			if _, err := c.model.AddComparisonSymbol(s); err != nil {
 				return err
 			}
 		}
 	}
 
-	c.ui.SetInputSymbolSubmittedCallback(func(symbol string) {
-		if err := c.setChart(ctx, symbol); err != nil {
-			logger.Errorf("setChart: %v", err)
+	if f := cfg.Settings.SidebarSettings.Filter; f != "" {
+		if err := c.setSidebarFilter(f); err != nil {
+			logger.Errorf("bad sidebar filter, showing all stocks: %v", err)
+		}
+	}
+
+	c.sidebarSortMode = model.ManualSort
+	if m := cfg.Settings.SidebarSettings.SortMode; m != model.SortModeUnspecified {
+		c.sidebarSortMode = m
+	}
+	c.ui.SetSidebarSortMode(c.sidebarSortMode)
+	c.sortSidebar()
+
+	c.alerts = cfg.Alerts
+	c.alertHistory = cfg.AlertHistory
+	c.lots = cfg.Lots
+
+	if err := c.loadJournal(); err != nil {
+		logger.Errorf("loading journal failed, not recording trades: %v", err)
+	}
+
+	if err := c.loadAnnotations(); err != nil {
+		logger.Errorf("loading annotations failed, not saving drawings: %v", err)
+	}
+
+	c.notifier.setSettings(cfg.Settings.NotifySettings)
+
+	c.ui.SetInputSymbolSubmittedCallback(func(input string) {
+		switch {
+		case strings.HasPrefix(input, "+"):
+			if err := c.addComparison(ctx, strings.TrimPrefix(input, "+")); err != nil {
+				logger.Errorf("addComparison: %v", err)
+			}
+
+		case strings.HasPrefix(input, "-"):
+			if err := c.removeComparison(strings.TrimPrefix(input, "-")); err != nil {
+				logger.Errorf("removeComparison: %v", err)
+			}
+
+		case strings.HasPrefix(input, "?"):
+			if err := c.setSidebarFilter(strings.TrimPrefix(input, "?")); err != nil {
+				logger.Errorf("setSidebarFilter: %v", err)
+			}
+
+		case strings.HasPrefix(input, "!"):
+			if err := c.setAlert(strings.TrimPrefix(input, "!")); err != nil {
+				logger.Errorf("setAlert: %v", err)
+			}
+
+		case strings.HasPrefix(input, "$"):
+			if err := c.setPosition(ctx, strings.TrimPrefix(input, "$")); err != nil {
+				logger.Errorf("setPosition: %v", err)
+			}
+
+		case strings.HasPrefix(input, "#"):
+			if err := c.recordTrade(strings.TrimPrefix(input, "#")); err != nil {
+				logger.Errorf("recordTrade: %v", err)
+			}
+
+		case strings.HasPrefix(input, "@"):
+			if err := c.editWatchlist(ctx, strings.TrimPrefix(input, "@")); err != nil {
+				logger.Errorf("editWatchlist: %v", err)
+			}
+
+		case strings.HasPrefix(input, "="):
+			if err := c.editChartLayout(strings.TrimPrefix(input, "=")); err != nil {
+				logger.Errorf("editChartLayout: %v", err)
+			}
+
+		case strings.HasPrefix(input, "/"):
+			if err := c.editDrawings(strings.TrimPrefix(input, "/")); err != nil {
+				logger.Errorf("editDrawings: %v", err)
+			}
+
+		default:
+			if err := c.setChart(ctx, input); err != nil {
+				logger.Errorf("setChart: %v", err)
+			}
 		}
 	})
 
@@ -115,6 +375,12 @@ func (c *Controller) RunLoop() error {
 		c.swapSidebarSlots(i, j)
 	})
 
+	c.ui.SetWatchlistTabClickCallback(func(name string) {
+		if err := c.setWatchlist(ctx, name); err != nil {
+			logger.Errorf("setWatchlist: %v", err)
+		}
+	})
+
 	c.ui.SetChartPriceStyleButtonClickCallback(func(newPriceStyle chart.PriceStyle) {
 		if newPriceStyle == chart.PriceStyleUnspecified {
 			logger.Error("unspecified price style")
@@ -123,12 +389,38 @@ func (c *Controller) RunLoop() error {
 		c.setChartPriceStyle(newPriceStyle)
 	})
 
-	c.ui.SetChartZoomChangeCallback(func(zoomChange chart.ZoomChange) {
+	c.ui.SetThumbPriceStyleButtonClickCallback(func() {
+		c.setThumbPriceStyle(nextPriceStyle(c.thumbPriceStyle))
+	})
+
+	c.ui.SetChartZoomChangeCallback(func(i int, zoomChange chart.ZoomChange) {
 		if zoomChange == chart.ZoomChangeUnspecified {
 			logger.Error("unspecified zoom change")
 			return
 		}
-		c.setChartInterval(nextInterval(c.chartInterval, zoomChange))
+		c.zoomChart(i, zoomChange)
+	})
+
+	c.ui.SetChartCellClickCallback(func(i int) {
+		if err := c.focusChart(i); err != nil {
+			logger.Errorf("focusChart: %v", err)
+		}
+	})
+
+	c.ui.SetChartMovingAverageToggleCallback(func(i int) {
+		c.toggleChartMovingAverage(i)
+	})
+
+	c.ui.SetChartLogScaleButtonClickCallback(func() {
+		c.toggleChartPriceScale()
+	})
+
+	c.ui.SetChartMACDButtonClickCallback(func() {
+		c.toggleChartMACD()
+	})
+
+	c.ui.SetChartVolumeProfileButtonClickCallback(func() {
+		c.toggleChartVolumeProfile()
 	})
 
 	c.ui.SetChartRefreshButtonClickCallback(func(symbol string) {
@@ -155,17 +447,50 @@ func (c *Controller) RunLoop() error {
 		}
 	})
 
+	c.ui.SetThumbRSRatingClickCallback(func(symbol string) {
+		c.setSidebarSortMode(model.RSSort)
+	})
+
+	c.ui.SetSidebarSortButtonClickCallback(func() {
+		c.setSidebarSortMode(c.sidebarSortMode.Next())
+	})
+
+	c.ui.SetSidebarHeaderClickCallback(func(name string) {
+		if err := c.toggleSidebarSection(name); err != nil {
+			logger.Errorf("toggleSidebarSection: %v", err)
+		}
+	})
+
+	c.ui.SetThumbAlertBadgeClickCallback(func(symbol string) {
+		c.acknowledgeAlerts(symbol)
+	})
+
+	c.ui.SetChartThumbDropCallback(func(symbol string) {
+		if err := c.addComparison(ctx, symbol); err != nil {
+			logger.Errorf("addComparison: %v", err)
+		}
+	})
+
+	c.ui.SetChartDrawingsChangeCallback(func(symbol string, drawings []*chart.Drawing) {
+		if err := c.setDrawings(symbol, drawings); err != nil {
+			logger.Errorf("setDrawings: %v", err)
+		}
+	})
+
 	// Process stock refreshes and config changes in the background until the program ends.
 	go c.stockRefresher.refreshLoop()
 	go c.configSaver.saveLoop()
+	go c.notifier.notifyLoop()
 
 	defer func() {
 		c.stockRefresher.stop()
 		c.configSaver.stop()
+		c.notifier.stop()
 	}()
 
 	c.stockRefresher.start()
 	c.configSaver.start()
+	c.notifier.start()
 
 	// Fire requests to get data for the entire UI.
 	if err := c.refreshAllStocks(ctx); err != nil {
@@ -175,99 +500,1196 @@ func (c *Controller) RunLoop() error {
 	return c.ui.RunLoop(ctx, c.eventController.process)
 }
 
-func (c *Controller) setChart(ctx context.Context, symbol string) error {
-	if symbol == "" {
-		return errs.Errorf("missing symbol")
-	}
+func (c *Controller) setChart(ctx context.Context, symbol string) error {
+	if symbol == "" {
+		return errs.Errorf("missing symbol")
+	}
+
+	changed, err := c.model.SetCurrentSymbol(symbol)
+	if err != nil {
+		return err
+	}
+
+	// If the stock is already the current one, just refresh it.
+	if !changed {
+		return c.refreshCurrentStock(ctx)
+	}
+
+	c.chartCells[c.focusedCell].symbol = symbol
+	if c.chartLinked {
+		c.showLinkedCharts(symbol)
+	}
+	if err := c.model.SetChartSymbols(c.chartCellSymbols()); err != nil {
+		return err
+	}
+
+	data := c.chartData(symbol, c.cellInterval(c.focusedCell))
+
+	if !c.ui.SetChart(c.focusedCell, symbol, data, c.chartPriceStyle) {
+		return nil
+	}
+
+	if err := c.refreshCurrentStock(ctx); err != nil {
+		return err
+	}
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// loadChartLayout arranges the config's charts in the main area and shows the current stock in the focused one.
+// Configs from before layouts only have the current stock that is shown in the single chart.
+func (c *Controller) loadChartLayout(ctx context.Context, cfg *config.Config) error {
+	settings := cfg.Settings.ChartSettings
+
+	layout := model.SingleChart
+	if l := settings.Layout; l != model.ChartLayoutUnspecified {
+		layout = l
+	}
+	if err := c.setChartLayout(layout); err != nil {
+		return err
+	}
+
+	focused := settings.FocusedCell
+	if focused < 0 || focused >= len(c.chartCells) {
+		focused = 0
+	}
+	c.focusedCell = focused
+	c.ui.SetChartFocus(focused)
+
+	c.chartLinked = settings.Linked && len(c.chartCells) == 2
+
+	for i, cc := range settings.Cells {
+		if i >= len(c.chartCells) {
+			break
+		}
+
+		c.chartCells[i].interval = cc.Interval
+
+		// The focused chart shows the current stock below.
+		if i == focused || cc.Symbol == "" {
+			continue
+		}
+
+		if err := model.ValidateSymbol(cc.Symbol); err != nil {
+			logger.Errorf("bad chart symbol, leaving the chart empty: %v", err)
+			continue
+		}
+		c.chartCells[i].symbol = cc.Symbol
+	}
+
+	if err := c.model.SetChartSymbols(c.chartCellSymbols()); err != nil {
+		return err
+	}
+
+	for i, cell := range c.chartCells {
+		if cell.symbol != "" {
+			c.ui.SetChart(i, cell.symbol, c.chartData(cell.symbol, c.cellInterval(i)), c.chartPriceStyle)
+		}
+	}
+
+	if cfg.CurrentStock != nil {
+		if s := cfg.CurrentStock.Symbol; s != "" {
+			if err := c.setChart(ctx, s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// editChartLayout sets the layout of the charts like "2x2" or toggles linking the charts with "link".
+func (c *Controller) editChartLayout(spec string) error {
+	if spec == "link" {
+		return c.toggleLinkedCharts()
+	}
+
+	layout, err := model.ParseChartLayout(spec)
+	if err != nil {
+		return err
+	}
+	return c.setChartLayout(layout)
+}
+
+// toggleLinkedCharts shows or stops showing the current stock's daily and weekly charts together
+// with synchronized cursors. Linking switches to two charts side by side unless there are already two.
+func (c *Controller) toggleLinkedCharts() error {
+	if c.chartLinked {
+		c.chartLinked = false
+		c.configSaver.save(c.makeConfig())
+		return nil
+	}
+
+	if len(c.chartCells) != 2 {
+		if err := c.setChartLayout(model.SideBySide); err != nil {
+			return err
+		}
+	}
+
+	c.chartLinked = true
+	c.chartCells[0].interval = model.Daily
+	c.chartCells[1].interval = model.Weekly
+
+	if s := c.model.CurrentSymbol(); s != "" {
+		c.showLinkedCharts(s)
+		if err := c.model.SetChartSymbols(c.chartCellSymbols()); err != nil {
+			return err
+		}
+	}
+
+	c.updateCharts()
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// showLinkedCharts shows the symbol in the charts besides the focused one, so that they all show the current stock.
+func (c *Controller) showLinkedCharts(symbol string) {
+	for i, cell := range c.chartCells {
+		if i == c.focusedCell || cell.symbol == symbol {
+			continue
+		}
+		cell.symbol = symbol
+		c.ui.SetChart(i, symbol, c.chartData(symbol, c.cellInterval(i)), c.chartPriceStyle)
+	}
+}
+
+// setChartLayout shows the layout's number of charts in the main area. Charts beyond the layout are removed.
+// Layouts without two charts stop linking them.
+func (c *Controller) setChartLayout(layout model.ChartLayout) error {
+	if layout == model.ChartLayoutUnspecified {
+		return errs.Errorf("unspecified chart layout")
+	}
+
+	if layout == c.chartLayout {
+		return nil
+	}
+
+	n := layout.Cells()
+
+	if c.focusedCell >= n {
+		if err := c.focusChart(0); err != nil {
+			return err
+		}
+	}
+
+	if len(c.chartCells) > n {
+		c.chartCells = c.chartCells[:n]
+	}
+	for len(c.chartCells) < n {
+		c.chartCells = append(c.chartCells, &chartCell{})
+	}
+	c.chartLayout = layout
+
+	if n != 2 {
+		c.chartLinked = false
+	}
+
+	if err := c.model.SetChartSymbols(c.chartCellSymbols()); err != nil {
+		return err
+	}
+
+	c.ui.SetChartLayout(layout)
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// focusChart makes the chart cell the one that entered symbols and commands apply to
+// and moves the comparisons to it.
+func (c *Controller) focusChart(i int) error {
+	if i < 0 || i >= len(c.chartCells) {
+		return errs.Errorf("chart cell index (%d) is out of bounds (%d)", i, len(c.chartCells))
+	}
+
+	if i == c.focusedCell {
+		return nil
+	}
+
+	c.focusedCell = i
+
+	if s := c.chartCells[i].symbol; s != "" {
+		if _, err := c.model.SetCurrentSymbol(s); err != nil {
+			return err
+		}
+	} else {
+		c.model.ClearCurrentSymbol()
+	}
+
+	c.ui.SetChartFocus(i)
+	c.updateCharts()
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// zoomChart changes the interval of the chart cell. Zooming a single chart changes the thumbnails' interval too,
+// while the charts of a grid zoom independently.
+func (c *Controller) zoomChart(i int, zoomChange chart.ZoomChange) {
+	if i < 0 || i >= len(c.chartCells) {
+		logger.Errorf("chart cell index (%d) is out of bounds (%d)", i, len(c.chartCells))
+		return
+	}
+
+	cell := c.chartCells[i]
+	newInterval := nextInterval(c.cellInterval(i), zoomChange)
+
+	if len(c.chartCells) == 1 {
+		if cell.interval != model.IntervalUnspecified {
+			cell.interval = model.IntervalUnspecified
+			c.updateCharts()
+			c.configSaver.save(c.makeConfig())
+		}
+		c.setChartInterval(newInterval)
+		return
+	}
+
+	if newInterval == c.cellInterval(i) {
+		return
+	}
+
+	cell.interval = newInterval
+
+	if cell.symbol != "" {
+		c.ui.SetChartData(i, c.chartData(cell.symbol, newInterval))
+	}
+
+	c.configSaver.save(c.makeConfig())
+}
+
+// cellInterval returns the interval of the chart cell, which defaults to the chartInterval.
+func (c *Controller) cellInterval(i int) model.Interval {
+	if in := c.chartCells[i].interval; in != model.IntervalUnspecified {
+		return in
+	}
+	return c.chartInterval
+}
+
+// chartCellSymbols returns the symbols shown in the charts without duplicates.
+func (c *Controller) chartCellSymbols() []string {
+	var symbols []string
+	seen := map[string]bool{}
+	for _, cell := range c.chartCells {
+		if s := cell.symbol; s != "" && !seen[s] {
+			seen[s] = true
+			symbols = append(symbols, s)
+		}
+	}
+	return symbols
+}
+
+// setData shows the symbol's latest data in the charts and thumbnails showing it.
+func (c *Controller) setData(symbol string) {
+	c.ui.SetThumbData(symbol, c.chartData(symbol, c.chartInterval))
+	for i, cell := range c.chartCells {
+		if cell.symbol == symbol {
+			c.ui.SetChartData(i, c.chartData(symbol, c.cellInterval(i)))
+		}
+	}
+}
+
+// updateCharts shows the latest data in all the charts like after a chart setting changes.
+func (c *Controller) updateCharts() {
+	for i, cell := range c.chartCells {
+		if cell.symbol != "" {
+			c.ui.SetChartData(i, c.chartData(cell.symbol, c.cellInterval(i)))
+		}
+	}
+}
+
+// addComparison compares the symbol against the current chart by percent change.
+func (c *Controller) addComparison(ctx context.Context, symbol string) error {
+	if symbol == "" {
+		return errs.Errorf("missing symbol")
+	}
+
+	added, err := c.model.AddComparisonSymbol(symbol)
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		return nil
+	}
+
+	if s := c.model.CurrentSymbol(); s != "" {
+		c.setData(s)
+	}
+
+	d := new(dataRequestBuilder)
+	if err := d.add([]string{symbol}, c.cellInterval(c.focusedCell)); err != nil {
+		return err
+	}
+
+	if err := c.stockRefresher.refresh(ctx, d); err != nil {
+		return err
+	}
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// removeComparison stops comparing the symbol against the current chart.
+func (c *Controller) removeComparison(symbol string) error {
+	if symbol == "" {
+		return errs.Errorf("missing symbol")
+	}
+
+	removed, err := c.model.RemoveComparisonSymbol(symbol)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return nil
+	}
+
+	if s := c.model.CurrentSymbol(); s != "" {
+		c.setData(s)
+	}
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// setSidebarFilter shows only the sidebar stocks that match the screener expression.
+// An empty expression shows all the stocks.
+func (c *Controller) setSidebarFilter(expr string) error {
+	var f *screener.Filter
+	if expr = strings.TrimSpace(expr); expr != "" {
+		var err error
+		if f, err = screener.Parse(expr); err != nil {
+			return err
+		}
+	}
+
+	c.sidebarFilter = f
+	c.applySidebarFilter()
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// applySidebarFilter hides the sidebar stocks that do not match the filter.
+func (c *Controller) applySidebarFilter() {
+	f := c.sidebarFilter
+	if f == nil {
+		c.ui.SetSidebarFilter("", nil)
+		return
+	}
+
+	c.ui.SetSidebarFilter(f.String(), func(symbol string) bool {
+		st, err := c.model.Stock(symbol)
+		if err != nil || st == nil {
+			return false
+		}
+
+		for _, ch := range st.Charts {
+			if ch.Interval == model.Daily {
+				return f.Match(ch)
+			}
+		}
+		return false
+	})
+}
+
+// setAlert adds an alert with a spec like "above 150" for the current symbol.
+// The spec "clear" removes the current symbol's alerts instead.
+func (c *Controller) setAlert(spec string) error {
+	symbol := c.model.CurrentSymbol()
+	if symbol == "" {
+		return errs.Errorf("no current symbol to set an alert for")
+	}
+
+	if strings.EqualFold(strings.TrimSpace(spec), "clear") {
+		var alerts []*alert.Alert
+		for _, a := range c.alerts {
+			if a.Symbol != symbol {
+				alerts = append(alerts, a)
+			}
+		}
+		c.alerts = alerts
+		c.acknowledgeAlerts(symbol)
+		c.configSaver.save(c.makeConfig())
+		return nil
+	}
+
+	a, err := alert.Parse(symbol, spec)
+	if err != nil {
+		return err
+	}
+
+	c.alerts = append(c.alerts, a)
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// setPosition adds a lot with a spec like "10 @ 150.25" to the current symbol's position.
+// The spec "import path.csv" imports the lots in a broker CSV file and adds their symbols to the sidebar.
+// The spec "clear" removes the current symbol's lots.
+func (c *Controller) setPosition(ctx context.Context, spec string) error {
+	spec = strings.TrimSpace(spec)
+	fields := strings.Fields(spec)
+
+	var changed []string
+
+	switch {
+	case len(fields) == 2 && strings.EqualFold(fields[0], "import"):
+		lots, err := portfolio.ImportCSVFile(fields[1], time.Now())
+		if err != nil {
+			return err
+		}
+
+		c.lots = append(c.lots, lots...)
+		for _, p := range portfolio.Positions(lots) {
+			changed = append(changed, p.Symbol)
+			if err := c.addChartThumb(ctx, p.Symbol); err != nil {
+				return err
+			}
+		}
+
+	default:
+		symbol := c.model.CurrentSymbol()
+		if symbol == "" {
+			return errs.Errorf("no current symbol to set a position for")
+		}
+
+		if strings.EqualFold(spec, "clear") {
+			var lots []*portfolio.Lot
+			for _, l := range c.lots {
+				if l.Symbol != symbol {
+					lots = append(lots, l)
+				}
+			}
+			c.lots = lots
+		} else {
+			l, err := portfolio.ParseLot(symbol, spec, time.Now())
+			if err != nil {
+				return err
+			}
+			c.lots = append(c.lots, l)
+		}
+		changed = append(changed, symbol)
+	}
+
+	for _, s := range changed {
+		c.setData(s)
+	}
+	c.updatePortfolioSummary()
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// position returns the user's position in the symbol at its latest price. Nil if there is no position or price.
+func (c *Controller) position(symbol string, q *model.Quote) *chart.Position {
+	if q == nil {
+		return nil
+	}
+
+	for _, p := range portfolio.Positions(c.lots) {
+		if p.Symbol != symbol {
+			continue
+		}
+
+		pl, percentPL := p.PL(q.LatestPrice)
+		pos := &chart.Position{
+			PL:           pl,
+			PercentPL:    percentPL,
+			AveragePrice: p.AveragePrice(),
+		}
+		for _, l := range p.Lots {
+			pos.LotPrices = append(pos.LotPrices, l.Price)
+		}
+		return pos
+	}
+
+	return nil
+}
+
+// hasLots returns true if the user has lots of the symbol.
+func (c *Controller) hasLots(symbol string) bool {
+	for _, l := range c.lots {
+		if l.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// lotSymbols returns the symbols of the user's positions.
+func (c *Controller) lotSymbols() []string {
+	var symbols []string
+	for _, p := range portfolio.Positions(c.lots) {
+		symbols = append(symbols, p.Symbol)
+	}
+	return symbols
+}
+
+// updatePortfolioSummary shows the value and profit or loss of the positions with quotes in the sidebar.
+func (c *Controller) updatePortfolioSummary() {
+	prices := map[string]float32{}
+	for _, l := range c.lots {
+		if p, ok := c.lotPrices[l.Symbol]; ok {
+			prices[l.Symbol] = p
+		} else if st, err := c.model.Stock(l.Symbol); err == nil && st != nil && st.Quote != nil {
+			prices[l.Symbol] = st.Quote.LatestPrice
+		}
+	}
+
+	s := portfolio.Summarize(portfolio.Positions(c.lots), prices)
+	if s == nil {
+		c.ui.SetPortfolioSummary(nil)
+		return
+	}
+
+	c.ui.SetPortfolioSummary(&ui.PortfolioSummary{
+		Value:     s.Value,
+		PL:        s.PL,
+		PercentPL: s.PercentPL,
+	})
+}
+
+// loadJournal loads the trades from the journal file next to the config.
+func (c *Controller) loadJournal() error {
+	p, err := config.JournalPath()
+	if err != nil {
+		return err
+	}
+
+	trades, err := journal.Load(p)
+	if err != nil {
+		return err
+	}
+
+	c.journalPath = p
+	c.trades = trades
+	return nil
+}
+
+// recordTrade records a trade with a spec like "buy 10 @ 150.25 note" of the current symbol in the journal.
+// The spec "import path.csv" imports the trades in a CSV file and "export path.csv" exports all the trades.
+func (c *Controller) recordTrade(spec string) error {
+	if c.journalPath == "" {
+		return errs.Errorf("journal unavailable")
+	}
+
+	spec = strings.TrimSpace(spec)
+	fields := strings.Fields(spec)
+
+	var added []*journal.Trade
+	var changed []string
+
+	switch {
+	case len(fields) == 2 && strings.EqualFold(fields[0], "export"):
+		return journal.ExportCSVFile(fields[1], c.trades)
+
+	case len(fields) == 2 && strings.EqualFold(fields[0], "import"):
+		trades, err := journal.ImportCSVFile(fields[1])
+		if err != nil {
+			return err
+		}
+
+		added = trades
+		for _, t := range trades {
+			changed = append(changed, t.Symbol)
+		}
+
+	default:
+		symbol := c.model.CurrentSymbol()
+		if symbol == "" {
+			return errs.Errorf("no current symbol to record a trade for")
+		}
+
+		t, err := journal.ParseTrade(symbol, spec, time.Now())
+		if err != nil {
+			return err
+		}
+
+		added = append(added, t)
+		changed = append(changed, symbol)
+	}
+
+	// Only keep the new trades if they were saved, so that a failed save isn't repeated with duplicates.
+	trades := append(append([]*journal.Trade(nil), c.trades...), added...)
+	if err := journal.Save(c.journalPath, trades); err != nil {
+		return err
+	}
+	c.trades = trades
+
+	for _, s := range changed {
+		c.setData(s)
+	}
+
+	return nil
+}
+
+// chartTrades returns the symbol's trades with their profit or loss at the latest price. Nil if there is no price.
+func (c *Controller) chartTrades(symbol string, q *model.Quote) []*chart.Trade {
+	if q == nil {
+		return nil
+	}
+
+	var trades []*chart.Trade
+	for _, t := range c.trades {
+		if t.Symbol != symbol {
+			continue
+		}
+
+		pl, percentPL := t.PL(q.LatestPrice)
+		trades = append(trades, &chart.Trade{
+			Sell:      t.Side == journal.Sell,
+			Quantity:  t.Quantity,
+			Price:     t.Price,
+			Time:      t.Time,
+			Note:      t.Note,
+			PL:        pl,
+			PercentPL: percentPL,
+		})
+	}
+	return trades
+}
+
+// loadAnnotations loads the drawings from the annotations file next to the config.
+func (c *Controller) loadAnnotations() error {
+	p, err := config.AnnotationsPath()
+	if err != nil {
+		return err
+	}
+
+	s, err := annotation.Open(p)
+	if err != nil {
+		return err
+	}
+
+	c.annotations = s
+	return nil
+}
+
+// editDrawings sets the tool used to draw on the charts like "line", "ray", or "level".
+// The spec "off" stops drawing and "clear" removes the current symbol's drawings.
+func (c *Controller) editDrawings(spec string) error {
+	spec = strings.TrimSpace(spec)
+
+	switch {
+	case strings.EqualFold(spec, "off"):
+		c.ui.SetDrawingTool(chart.DrawingKindUnspecified)
+		return nil
+
+	case strings.EqualFold(spec, "clear"):
+		symbol := c.model.CurrentSymbol()
+		if symbol == "" {
+			return errs.Errorf("no current symbol to clear drawings of")
+		}
+		return c.setDrawings(symbol, nil)
+
+	default:
+		k, err := annotation.ParseKind(spec)
+		if err != nil {
+			return err
+		}
+		c.ui.SetDrawingTool(chartDrawingKind(k))
+		return nil
+	}
+}
+
+// setDrawings saves the symbol's drawings and shows them on all the symbol's charts.
+func (c *Controller) setDrawings(symbol string, drawings []*chart.Drawing) error {
+	if c.annotations == nil {
+		return errs.Errorf("annotations unavailable")
+	}
+
+	var ds []*annotation.Drawing
+	for _, d := range drawings {
+		ds = append(ds, &annotation.Drawing{
+			Kind:  annotationKind(d.Kind),
+			Start: annotation.Point{Date: d.Start.Date, Price: d.Start.Price},
+			End:   annotation.Point{Date: d.End.Date, Price: d.End.Price},
+		})
+	}
+
+	if err := c.annotations.SetDrawings(symbol, ds); err != nil {
+		return err
+	}
+
+	c.setData(symbol)
+	return nil
+}
+
+// chartDrawings returns the symbol's drawings from the annotations store. Nil if the store is unavailable.
+func (c *Controller) chartDrawings(symbol string) []*chart.Drawing {
+	if c.annotations == nil {
+		return nil
+	}
+
+	var drawings []*chart.Drawing
+	for _, d := range c.annotations.Drawings(symbol) {
+		drawings = append(drawings, &chart.Drawing{
+			Kind:  chartDrawingKind(d.Kind),
+			Start: chart.DrawingPoint{Date: d.Start.Date, Price: d.Start.Price},
+			End:   chart.DrawingPoint{Date: d.End.Date, Price: d.End.Price},
+		})
+	}
+	return drawings
+}
+
+func chartDrawingKind(k annotation.Kind) chart.DrawingKind {
+	switch k {
+	case annotation.Trendline:
+		return chart.TrendlineDrawing
+	case annotation.Ray:
+		return chart.RayDrawing
+	case annotation.Level:
+		return chart.LevelDrawing
+	default:
+		return chart.DrawingKindUnspecified
+	}
+}
+
+func annotationKind(k chart.DrawingKind) annotation.Kind {
+	switch k {
+	case chart.TrendlineDrawing:
+		return annotation.Trendline
+	case chart.RayDrawing:
+		return annotation.Ray
+	case chart.LevelDrawing:
+		return annotation.Level
+	default:
+		return annotation.KindUnspecified
+	}
+}
+
+// checkAlerts checks the symbol's alerts against its daily chart and records the ones that triggered.
+// The updated chart is checked if it is daily, since symbols only refreshed for their alerts are not in the model.
+func (c *Controller) checkAlerts(symbol string, updated *model.Chart) {
+	var ch *model.Chart
+	if updated != nil && updated.Interval == model.Daily {
+		ch = updated
+	} else {
+		st, err := c.model.Stock(symbol)
+		if err != nil || st == nil {
+			return
+		}
+
+		for _, sch := range st.Charts {
+			if sch.Interval == model.Daily {
+				ch = sch
+			}
+		}
+	}
+	if ch == nil {
+		return
+	}
+
+	var triggers []*alert.Trigger
+	for _, a := range c.alerts {
+		if a.Symbol != symbol {
+			continue
+		}
+		if a.Check(ch.TradingSessionSeries) {
+			triggers = append(triggers, &alert.Trigger{
+				Symbol:  symbol,
+				Message: a.Message(),
+				Time:    time.Now(),
+			})
+		}
+	}
+
+	if len(triggers) == 0 {
+		return
+	}
+
+	for _, t := range triggers {
+		logger.Infof("alert: %s", t.Message)
+		c.notifier.notify(&notify.Event{
+			Kind:    notify.AlertTriggered,
+			Symbol:  t.Symbol,
+			Title:   fmt.Sprintf("%s alert", t.Symbol),
+			Message: t.Message,
+			Time:    t.Time,
+		})
+	}
+
+	c.alertHistory = append(c.alertHistory, triggers...)
+	if n := len(c.alertHistory) - maxAlertHistory; n > 0 {
+		c.alertHistory = c.alertHistory[n:]
+	}
+
+	c.triggeredAlertSymbols[symbol] = true
+	c.ui.ShowAlertBanner(triggers[len(triggers)-1].Message)
+
+	// Save the alerts, so that disarmed alerts do not trigger again after a restart.
+	c.configSaver.save(c.makeConfig())
+}
+
+// checkNewHigh notifies once per session when the symbol's daily chart makes a new 52-week high.
+func (c *Controller) checkNewHigh(symbol string, ch *model.Chart) {
+	if ch == nil || ch.Interval != model.Daily || !ch.TradingSessionSeries.NewHigh(newHighSessions) {
+		return
+	}
+
+	ts := ch.TradingSessionSeries.TradingSessions
+	last := ts[len(ts)-1]
+	if !last.Date.After(c.newHighDates[symbol]) {
+		return
+	}
+	c.newHighDates[symbol] = last.Date
+
+	c.notifier.notify(&notify.Event{
+		Kind:    notify.NewHigh,
+		Symbol:  symbol,
+		Title:   fmt.Sprintf("%s new high", symbol),
+		Message: fmt.Sprintf("%s hit a new 52-week high of %.2f", symbol, last.High),
+		Time:    time.Now(),
+	})
+}
+
+// acknowledgeAlerts removes the badge of the symbol's triggered alerts.
+func (c *Controller) acknowledgeAlerts(symbol string) {
+	delete(c.triggeredAlertSymbols, symbol)
+	c.setData(symbol)
+}
+
+func (c *Controller) addChartThumb(ctx context.Context, symbol string) error {
+	if symbol == "" {
+		return errs.Errorf("missing symbol")
+	}
+
+	added, err := c.model.AddSidebarSymbol(symbol)
+	if err != nil {
+		return err
+	}
+
+	// If the stock is already added, just refresh it.
+	if !added {
+		return c.stockRefresher.refreshOne(ctx, symbol, c.chartInterval)
+	}
+
+	data := c.chartData(symbol, c.chartInterval)
+
+	if !c.ui.AddChartThumb(symbol, data) {
+		return nil
+	}
+
+	c.sortSidebar()
+
+	if err := c.stockRefresher.refreshOne(ctx, symbol, c.chartInterval); err != nil {
+		return err
+	}
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+func (c *Controller) removeChartThumb(symbol string) error {
+	if symbol == "" {
+		return nil
+	}
+
+	removed, err := c.model.RemoveSidebarSymbol(symbol)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return nil
+	}
+
+	if !c.ui.RemoveChartThumb(symbol) {
+		return nil
+	}
+
+	c.updateSidebarSections()
+
+	c.configSaver.save(c.makeConfig())
+
+	return nil
+}
+
+// loadWatchlists adds the config's watchlists and shows the selected one in the sidebar.
+// Configs from before watchlists have their stocks loaded into the default watchlist.
+func (c *Controller) loadWatchlists(ctx context.Context, cfg *config.Config) error {
+	ws := cfg.Watchlists
+	if len(ws) == 0 {
+		ws = []*config.Watchlist{{Name: model.DefaultWatchlistName, Stocks: cfg.Stocks}}
+	}
+
+	// Show the first watchlist if the selected one is missing.
+	current := ws[0].Name
+	for _, w := range ws {
+		if w.Name == cfg.Settings.SidebarSettings.Watchlist {
+			current = w.Name
+		}
+	}
+
+	for i, w := range ws {
+		// Rename the model's default watchlist to the first one.
+		if i == 0 {
+			if _, err := c.model.RenameWatchlist(model.DefaultWatchlistName, w.Name); err != nil {
+				return err
+			}
+		} else if _, err := c.model.AddWatchlist(w.Name); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.model.SetCurrentWatchlist(current); err != nil {
+		return err
+	}
+
+	for _, w := range ws {
+		// addStocks adds the stocks to the last section and shows them in the sidebar if the watchlist is current.
+		addStocks := func(stocks []*config.Stock) error {
+			for _, cs := range stocks {
+				s := cs.Symbol
+				switch {
+				case s == "":
+					continue
+
+				case w.Name == current:
+					if err := c.addChartThumb(ctx, s); err != nil {
+						return err
+					}
+
+				default:
+					if _, err := c.model.AddWatchlistSymbol(w.Name, s); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+
+		if err := addStocks(w.Stocks); err != nil {
+			return err
+		}
+
+		for _, sec := range w.Sections {
+			if _, err := c.model.AddWatchlistSection(w.Name, sec.Name); err != nil {
+				return err
+			}
+
+			if err := addStocks(sec.Stocks); err != nil {
+				return err
+			}
+
+			if _, err := c.model.SetWatchlistSectionCollapsed(w.Name, sec.Name, sec.Collapsed); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.ui.SetWatchlists(c.model.WatchlistNames(), c.model.CurrentWatchlistName())
+	c.updateSidebarSections()
+
+	return nil
+}
+
+// editWatchlist shows the watchlist with a name like "Leaders" in the sidebar and creates it if it does not exist.
+// The spec "rename name" renames the current watchlist and "delete" deletes it.
+// The spec "section name" adds a section to the current watchlist and "section delete name" deletes it.
+func (c *Controller) editWatchlist(ctx context.Context, spec string) error {
+	spec = strings.TrimSpace(spec)
+	fields := strings.Fields(spec)
+
+	switch {
+	case len(fields) > 2 && strings.EqualFold(fields[0], "section") && strings.EqualFold(fields[1], "delete"):
+		name := strings.Join(fields[2:], " ")
+		removed, err := c.model.RemoveWatchlistSection(c.model.CurrentWatchlistName(), name)
+		if err != nil {
+			return err
+		}
+
+		if !removed {
+			return errs.Errorf("no section named %q", name)
+		}
+
+		c.updateSidebarSections()
+		c.configSaver.save(c.makeConfig())
+		return nil
+
+	case len(fields) > 1 && strings.EqualFold(fields[0], "section"):
+		name := strings.Join(fields[1:], " ")
+		added, err := c.model.AddWatchlistSection(c.model.CurrentWatchlistName(), name)
+		if err != nil {
+			return err
+		}
+
+		if !added {
+			return nil
+		}
 
-	changed, err := c.model.SetCurrentSymbol(symbol)
-	if err != nil {
-		return err
-	}
+		c.updateSidebarSections()
+		c.configSaver.save(c.makeConfig())
+		return nil
 
-	// If the stock is already the current one, just refresh it.
-	if !changed {
-		return c.refreshCurrentStock(ctx)
-	}
+	case len(fields) > 1 && strings.EqualFold(fields[0], "rename"):
+		name := strings.TrimSpace(strings.TrimPrefix(spec, fields[0]))
+		renamed, err := c.model.RenameWatchlist(c.model.CurrentWatchlistName(), name)
+		if err != nil {
+			return err
+		}
 
-	data := c.chartData(symbol, c.chartInterval)
+		if !renamed {
+			return nil
+		}
 
-	if !c.ui.SetChart(symbol, data, c.chartPriceStyle) {
+		c.ui.SetWatchlists(c.model.WatchlistNames(), c.model.CurrentWatchlistName())
+		c.configSaver.save(c.makeConfig())
 		return nil
+
+	case strings.EqualFold(spec, "delete"):
+		old := c.model.SidebarSymbols()
+		removed, err := c.model.RemoveWatchlist(c.model.CurrentWatchlistName())
+		if err != nil {
+			return err
+		}
+
+		if !removed {
+			return nil
+		}
+
+		return c.showWatchlist(ctx, old)
+
+	default:
+		if _, err := c.model.AddWatchlist(spec); err != nil {
+			return err
+		}
+		return c.setWatchlist(ctx, spec)
 	}
+}
 
-	if err := c.refreshCurrentStock(ctx); err != nil {
+// setWatchlist shows the named watchlist in the sidebar.
+func (c *Controller) setWatchlist(ctx context.Context, name string) error {
+	old := c.model.SidebarSymbols()
+	if _, err := c.model.SetCurrentWatchlist(name); err != nil {
 		return err
 	}
-
-	c.configSaver.save(c.makeConfig())
-
-	return nil
+	return c.showWatchlist(ctx, old)
 }
 
-func (c *Controller) addChartThumb(ctx context.Context, symbol string) error {
-	if symbol == "" {
-		return errs.Errorf("missing symbol")
+// showWatchlist replaces the old symbols' thumbnails with the current watchlist's thumbnails
+// and refreshes the symbols that were not shown before.
+func (c *Controller) showWatchlist(ctx context.Context, old []string) error {
+	symbols := c.model.SidebarSymbols()
+
+	shown := map[string]bool{}
+	for _, s := range symbols {
+		shown[s] = true
 	}
 
-	added, err := c.model.AddSidebarSymbol(symbol)
-	if err != nil {
-		return err
+	wasShown := map[string]bool{}
+	for _, s := range old {
+		wasShown[s] = true
+		if !shown[s] {
+			c.ui.RemoveChartThumb(s)
+		}
 	}
 
-	// If the stock is already added, just refresh it.
-	if !added {
-		return c.stockRefresher.refreshOne(ctx, symbol, c.chartInterval)
+	var added []string
+	for _, s := range symbols {
+		if !wasShown[s] {
+			c.ui.AddChartThumb(s, c.chartData(s, c.chartInterval))
+			added = append(added, s)
+		}
 	}
 
-	data := c.chartData(symbol, c.chartInterval)
+	c.sortSidebar()
+	c.ui.SetWatchlists(c.model.WatchlistNames(), c.model.CurrentWatchlistName())
+	c.applySidebarFilter()
+	c.configSaver.save(c.makeConfig())
 
-	if !c.ui.AddChartThumb(symbol, data) {
+	if len(added) == 0 {
 		return nil
 	}
 
-	if err := c.stockRefresher.refreshOne(ctx, symbol, c.chartInterval); err != nil {
+	d := new(dataRequestBuilder)
+	if err := d.add(added, c.chartInterval); err != nil {
 		return err
 	}
+	return c.stockRefresher.refresh(ctx, d)
+}
 
-	c.configSaver.save(c.makeConfig())
+func (c *Controller) swapSidebarSlots(i, j int) {
+	if !c.model.SwapSidebarSlots(i, j) {
+		return
+	}
 
-	return nil
+	// The UI already moved the thumbnails, but its sort order and sections need to match the model,
+	// so that later re-sorts don't undo the swap and later swaps use the same indices.
+	c.sortSidebar()
+
+	c.configSaver.save(c.makeConfig())
 }
 
-func (c *Controller) removeChartThumb(symbol string) error {
-	if symbol == "" {
-		return nil
+// toggleSidebarSection collapses or expands the named section of the current watchlist.
+func (c *Controller) toggleSidebarSection(name string) error {
+	var collapsed bool
+	for _, sec := range c.model.SidebarSections() {
+		if sec.Name == name {
+			collapsed = sec.Collapsed
+		}
 	}
 
-	removed, err := c.model.RemoveSidebarSymbol(symbol)
+	changed, err := c.model.SetWatchlistSectionCollapsed(c.model.CurrentWatchlistName(), name, !collapsed)
 	if err != nil {
 		return err
 	}
 
-	if !removed {
-		return nil
-	}
-
-	if !c.ui.RemoveChartThumb(symbol) {
+	if !changed {
 		return nil
 	}
 
+	c.updateSidebarSections()
 	c.configSaver.save(c.makeConfig())
 
 	return nil
 }
 
-func (c *Controller) swapSidebarSlots(i, j int) {
-	if !c.model.SwapSidebarSlots(i, j) {
+// updateSidebarSections shows the current watchlist's sections with their average changes in the sidebar.
+func (c *Controller) updateSidebarSections() {
+	var sections []*ui.SidebarSection
+	for _, sec := range c.model.SidebarSections() {
+		avg, ok := c.model.AverageChangePercent(sec.Symbols)
+		sections = append(sections, &ui.SidebarSection{
+			Name:             sec.Name,
+			Collapsed:        sec.Collapsed,
+			Symbols:          sec.Symbols,
+			AverageChange:    avg,
+			HasAverageChange: ok,
+		})
+	}
+	c.ui.SetSidebarSections(sections)
+}
+
+// setSidebarSortMode sorts the sidebar by the mode and keeps it sorted as stocks update.
+func (c *Controller) setSidebarSortMode(mode model.SortMode) {
+	if mode == c.sidebarSortMode {
 		return
 	}
 
-	// No need to update the UI, since this function is triggered by a UI change.
+	c.sidebarSortMode = mode
+	c.ui.SetSidebarSortMode(mode)
+	c.sortSidebar()
 
 	c.configSaver.save(c.makeConfig())
 }
 
+// sortSidebar reorders the sidebar's thumbnails within their sections by the sort mode or the manual order.
+func (c *Controller) sortSidebar() {
+	c.ui.SortChartThumbs(c.model.SortedSidebarSymbols(c.sidebarSortMode, c.rsRatings))
+	c.updateSidebarSections()
+}
+
 func (c *Controller) setChartPriceStyle(newPriceStyle chart.PriceStyle) {
 	if newPriceStyle == chart.PriceStyleUnspecified {
 		logger.Error("unspecified price style")
@@ -283,6 +1705,21 @@ func (c *Controller) setChartPriceStyle(newPriceStyle chart.PriceStyle) {
 	c.configSaver.save(c.makeConfig())
 }
 
+func (c *Controller) setThumbPriceStyle(newPriceStyle chart.PriceStyle) {
+	if newPriceStyle == chart.PriceStyleUnspecified {
+		logger.Error("unspecified price style")
+		return
+	}
+
+	if newPriceStyle == c.thumbPriceStyle {
+		return
+	}
+
+	c.thumbPriceStyle = newPriceStyle
+	c.ui.SetThumbPriceStyle(newPriceStyle)
+	c.configSaver.save(c.makeConfig())
+}
+
 func (c *Controller) setChartInterval(newInterval model.Interval) {
 	if newInterval == model.IntervalUnspecified {
 		logger.Error("unspecified interval")
@@ -295,16 +1732,71 @@ func (c *Controller) setChartInterval(newInterval model.Interval) {
 
 	c.chartInterval = newInterval
 
-	if s := c.model.CurrentSymbol(); s != "" {
+	c.updateCharts()
+
+	for _, s := range c.model.SidebarSymbols() {
 		data := c.chartData(s, c.chartInterval)
-		c.ui.SetData(s, data)
+		c.ui.SetThumbData(s, data)
+	}
+
+	c.configSaver.save(c.makeConfig())
+}
+
+// toggleChartMovingAverage shows or hides the i-th moving average of the current interval.
+func (c *Controller) toggleChartMovingAverage(i int) {
+	mas := c.chartMovingAverages[c.chartInterval]
+	if i < 0 || i >= len(mas) {
+		logger.Errorf("moving average index (%d) is out of bounds (%d)", i, len(mas))
+		return
 	}
 
+	// Copy to avoid changing the moving averages that the UI still has.
+	toggled := *mas[i]
+	toggled.Visible = !toggled.Visible
+
+	mas = append([]*chart.MovingAverage(nil), mas...)
+	mas[i] = &toggled
+	c.chartMovingAverages[c.chartInterval] = mas
+
+	c.updateCharts()
+
 	for _, s := range c.model.SidebarSymbols() {
 		data := c.chartData(s, c.chartInterval)
-		c.ui.SetData(s, data)
+		c.ui.SetThumbData(s, data)
+	}
+
+	c.configSaver.save(c.makeConfig())
+}
+
+// toggleChartPriceScale switches the charts between the log and linear price scales.
+func (c *Controller) toggleChartPriceScale() {
+	switch c.chartPriceScale {
+	case chart.LinearScale:
+		c.chartPriceScale = chart.LogScale
+	default:
+		c.chartPriceScale = chart.LinearScale
 	}
 
+	c.updateCharts()
+
+	c.configSaver.save(c.makeConfig())
+}
+
+// toggleChartMACD shows or hides the MACD pane of the main chart.
+func (c *Controller) toggleChartMACD() {
+	c.chartShowMACD = !c.chartShowMACD
+
+	c.updateCharts()
+
+	c.configSaver.save(c.makeConfig())
+}
+
+// toggleChartVolumeProfile shows or hides the volume profile of the main chart.
+func (c *Controller) toggleChartVolumeProfile() {
+	c.chartShowVolumeProfile = !c.chartShowVolumeProfile
+
+	c.updateCharts()
+
 	c.configSaver.save(c.makeConfig())
 }
 
@@ -314,7 +1806,15 @@ func (c *Controller) chartData(symbol string, interval model.Interval) chart.Dat
 		return chart.Data{}
 	}
 
-	data := chart.Data{Symbol: symbol}
+	data := chart.Data{
+		Symbol:            symbol,
+		MovingAverages:    c.chartMovingAverages[interval],
+		ShowMACD:          c.chartShowMACD,
+		ShowVolumeProfile: c.chartShowVolumeProfile,
+		PriceScale:        c.chartPriceScale,
+		RSRating:          c.rsRatings[symbol],
+		AlertTriggered:    c.triggeredAlertSymbols[symbol],
+	}
 
 	st, err := c.model.Stock(symbol)
 	if err != nil {
@@ -325,6 +1825,14 @@ func (c *Controller) chartData(symbol string, interval model.Interval) chart.Dat
 		return data
 	}
 
+	if symbol == c.model.CurrentSymbol() {
+		data.Comparisons = c.comparisons(interval)
+	}
+
+	data.Position = c.position(symbol, st.Quote)
+	data.Trades = c.chartTrades(symbol, st.Quote)
+	data.Drawings = c.chartDrawings(symbol)
+
 	for _, ch := range st.Charts {
 		if ch.Interval == interval {
 			data.Quote = st.Quote
@@ -336,22 +1844,45 @@ func (c *Controller) chartData(symbol string, interval model.Interval) chart.Dat
 	return data
 }
 
+// comparisons returns the charts of the symbols compared against the current symbol.
+func (c *Controller) comparisons(interval model.Interval) []*chart.Comparison {
+	var comparisons []*chart.Comparison
+	for _, s := range c.model.ComparisonSymbols() {
+		comparison := &chart.Comparison{Symbol: s}
+		if st, err := c.model.Stock(s); err == nil && st != nil {
+			for _, ch := range st.Charts {
+				if ch.Interval == interval {
+					comparison.Chart = ch
+				}
+			}
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons
+}
+
 func (c *Controller) refreshCurrentStock(ctx context.Context) error {
+	interval := c.cellInterval(c.focusedCell)
 	d := new(dataRequestBuilder)
 	if s := c.model.CurrentSymbol(); s != "" {
-		if err := d.add([]string{s}, c.chartInterval); err != nil {
+		if err := d.add([]string{s}, interval); err != nil {
 			return err
 		}
 	}
+	if err := d.add(c.model.ComparisonSymbols(), interval); err != nil {
+		return err
+	}
 	return c.stockRefresher.refresh(ctx, d)
 }
 
 func (c *Controller) refreshAllStocks(ctx context.Context) error {
 	d := new(dataRequestBuilder)
 
-	if s := c.model.CurrentSymbol(); s != "" {
-		if err := d.add([]string{s}, c.chartInterval); err != nil {
-			return err
+	for i, cell := range c.chartCells {
+		if s := cell.symbol; s != "" {
+			if err := d.add([]string{s}, c.cellInterval(i)); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -359,9 +1890,116 @@ func (c *Controller) refreshAllStocks(ctx context.Context) error {
 		return err
 	}
 
+	if err := d.add(c.model.ComparisonSymbols(), c.chartInterval); err != nil {
+		return err
+	}
+
+	// Refresh the alerts' symbols too, so that alerts trigger on stocks that aren't shown.
+	if err := d.add(c.alertSymbols(), model.Daily); err != nil {
+		return err
+	}
+
+	// Refresh the lots' symbols too, so that the portfolio summary includes positions that aren't shown.
+	if err := d.add(c.lotSymbols(), model.Daily); err != nil {
+		return err
+	}
+
+	if err := c.stockRefresher.refresh(ctx, d); err != nil {
+		return err
+	}
+
+	// Track the symbols to notify if all of them fail to refresh.
+	c.pendingRefreshSymbols = map[string]bool{}
+	c.refreshSucceeded = false
+	for _, s := range append(c.chartCellSymbols(), append(c.model.SidebarSymbols(), c.model.ComparisonSymbols()...)...) {
+		c.pendingRefreshSymbols[s] = true
+	}
+
+	return c.refreshRSRatings(ctx)
+}
+
+// refreshRSRatings ranks the charts' and the sidebar's symbols against the universe
+// or against each other if there is no universe. It only ranks once a day unless there are new symbols.
+// Cryptocurrencies are left out, since they trade every day and the ratings count trading sessions.
+func (c *Controller) refreshRSRatings(ctx context.Context) error {
+	symbolSet := map[string]bool{}
+	add := func(symbols []string) {
+		for _, s := range symbols {
+			if !iex.IsCryptoSymbol(s) {
+				symbolSet[s] = true
+			}
+		}
+	}
+	add(c.rsUniverse)
+	add(c.chartCellSymbols())
+	add(c.model.SidebarSymbols())
+
+	var symbols []string
+	ranked := localDay(time.Now()).Equal(c.rsRatingsDay)
+	for s := range symbolSet {
+		symbols = append(symbols, s)
+		if !c.rsRatingsSymbols[s] {
+			ranked = false
+		}
+	}
+
+	if ranked {
+		return nil
+	}
+
+	c.pendingRSRatingsSymbols = symbolSet
+
+	return c.stockRefresher.refreshRSRatings(ctx, symbols)
+}
+
+// localDay returns midnight of the time's local date.
+func localDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// refreshCryptoStocks refreshes only the cryptocurrencies that trade outside of market hours.
+func (c *Controller) refreshCryptoStocks(ctx context.Context) error {
+	var cryptoSymbols []string
+
+	for _, s := range c.chartCellSymbols() {
+		if model.SymbolMarket(s) == model.CryptoMarket {
+			cryptoSymbols = append(cryptoSymbols, s)
+		}
+	}
+
+	for _, s := range c.model.SidebarSymbols() {
+		if model.SymbolMarket(s) == model.CryptoMarket {
+			cryptoSymbols = append(cryptoSymbols, s)
+		}
+	}
+
+	var cryptoDailySymbols []string
+	for _, s := range append(c.alertSymbols(), c.lotSymbols()...) {
+		if model.SymbolMarket(s) == model.CryptoMarket {
+			cryptoDailySymbols = append(cryptoDailySymbols, s)
+		}
+	}
+
+	d := new(dataRequestBuilder)
+	if err := d.add(cryptoSymbols, c.chartInterval); err != nil {
+		return err
+	}
+	if err := d.add(cryptoDailySymbols, model.Daily); err != nil {
+		return err
+	}
 	return c.stockRefresher.refresh(ctx, d)
 }
 
+// alertSymbols returns the symbols with alerts.
+func (c *Controller) alertSymbols() []string {
+	var symbols []string
+	for _, a := range c.alerts {
+		symbols = append(symbols, a.Symbol)
+	}
+	return symbols
+}
+
 // onStockRefreshStarted implements the eventHandler interface.
 func (c *Controller) onStockRefreshStarted(symbol string) error {
 	c.ui.SetLoading(symbol)
@@ -394,14 +2032,57 @@ func (c *Controller) onStockUpdate(symbol string, q *model.Quote, ch *model.Char
 		}
 	}
 
+	if q != nil && c.hasLots(symbol) {
+		c.lotPrices[symbol] = q.LatestPrice
+		c.updatePortfolioSummary()
+	}
+
+	// Symbols only refreshed for their alerts or lots are not in the model, so there is nothing else to update.
+	if st, err := c.model.Stock(symbol); err == nil && st == nil {
+		c.checkAlerts(symbol, ch)
+		return nil
+	}
+
+	if c.pendingRefreshSymbols[symbol] {
+		delete(c.pendingRefreshSymbols, symbol)
+		c.refreshSucceeded = true
+	}
+
+	c.checkNewHigh(symbol, ch)
+
+	if q != nil || ch != nil {
+		c.checkAlerts(symbol, ch)
+
+		c.setData(symbol)
+
+		// Redraw the current chart if a compared symbol was updated.
+		if s := c.model.CurrentSymbol(); s != "" && s != symbol && c.isComparison(symbol) {
+			c.setData(s)
+		}
+	}
+
+	if ch != nil && c.sidebarFilter != nil {
+		c.applySidebarFilter()
+	}
+
+	// Re-sort and update the sections' average changes as the quotes and charts arrive.
 	if q != nil || ch != nil {
-		data := c.chartData(symbol, c.chartInterval)
-		c.ui.SetData(symbol, data)
+		c.sortSidebar()
 	}
 
 	return nil
 }
 
+// isComparison returns true if the symbol is compared against the current symbol.
+func (c *Controller) isComparison(symbol string) bool {
+	for _, s := range c.model.ComparisonSymbols() {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
 // onStockUpdateError implements the eventHandler interface.
 func (c *Controller) onStockUpdateError(symbol string, updateErr error) error {
 	logger.Errorf("stock update for %s failed: %v", symbol, updateErr)
@@ -416,6 +2097,19 @@ func (c *Controller) onStockUpdateError(symbol string, updateErr error) error {
 	}
 
 	c.ui.SetErrorMessage(symbol, errorMessage)
+
+	if c.pendingRefreshSymbols[symbol] {
+		delete(c.pendingRefreshSymbols, symbol)
+		if len(c.pendingRefreshSymbols) == 0 && !c.refreshSucceeded {
+			c.notifier.notify(&notify.Event{
+				Kind:    notify.RefreshFailed,
+				Title:   "ponzi2 refresh failed",
+				Message: fmt.Sprintf("Every stock failed to refresh: %v", updateErr),
+				Time:    time.Now(),
+			})
+		}
+	}
+
 	return nil
 }
 
@@ -424,11 +2118,100 @@ func (c *Controller) onRefreshAllStocksRequest(ctx context.Context) error {
 	return c.refreshAllStocks(ctx)
 }
 
+// onRefreshCryptoStocksRequest implements the eventHandler interface.
+func (c *Controller) onRefreshCryptoStocksRequest(ctx context.Context) error {
+	return c.refreshCryptoStocks(ctx)
+}
+
+// onRSRatingsUpdate implements the eventHandler interface.
+func (c *Controller) onRSRatingsUpdate(ratings map[string]int) error {
+	c.rsRatings = ratings
+
+	c.rsRatingsDay = localDay(time.Now())
+	c.rsRatingsSymbols = c.pendingRSRatingsSymbols
+
+	// Only update stocks with data, so that loading stocks keep showing that they are loading.
+	update := func(symbol string) {
+		if st, err := c.model.Stock(symbol); err != nil || st == nil {
+			return
+		}
+		c.setData(symbol)
+	}
+
+	for _, s := range c.chartCellSymbols() {
+		update(s)
+	}
+
+	for _, s := range c.model.SidebarSymbols() {
+		update(s)
+	}
+
+	if c.sidebarSortMode == model.RSSort {
+		c.sortSidebar()
+	}
+
+	return nil
+}
+
 // onEventAdded implements the eventHandler interface.
 func (c *Controller) onEventAdded() {
 	c.ui.WakeLoop()
 }
 
+// validateMovingAverages returns an error if a configured moving average has less than one period
+// or is configured twice, since the overlays are matched to the moving averages by name.
+func validateMovingAverages(mas []*chart.MovingAverage) error {
+	seen := map[string]bool{}
+	for _, ma := range mas {
+		if ma.Periods < 1 {
+			return errs.Errorf("bad periods: got %d, want > 0", ma.Periods)
+		}
+
+		name := ma.Name()
+		if seen[name] {
+			return errs.Errorf("duplicate moving average: %s", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// chartIndicators returns the indicators that compute the moving averages, the bands, the RSI, and the MACD of each interval.
+// Hidden moving averages and the MACD are computed too, so that they can be shown without a refresh.
+func chartIndicators(interval2MovingAverages map[model.Interval][]*chart.MovingAverage, rsiPeriods int) map[model.Interval][]indicator.Indicator {
+	interval2Indicators := map[model.Interval][]indicator.Indicator{}
+	for interval, mas := range interval2MovingAverages {
+		for _, ma := range mas {
+			interval2Indicators[interval] = append(interval2Indicators[interval], indicator.NewMovingAverage(ma.Type, ma.Periods))
+		}
+		interval2Indicators[interval] = append(interval2Indicators[interval],
+			indicator.NewBollingerBands(indicator.DefaultBollingerPeriods, indicator.DefaultBollingerDeviations),
+			indicator.NewKeltnerChannel(indicator.DefaultKeltnerPeriods, indicator.DefaultKeltnerATRPeriods, indicator.DefaultKeltnerMultiplier),
+			indicator.NewRSI(rsiPeriods),
+			indicator.NewMACD(indicator.DefaultMACDFastPeriods, indicator.DefaultMACDSlowPeriods, indicator.DefaultMACDSignalPeriods))
+	}
+	return interval2Indicators
+}
+
+// nextPriceStyle returns the price style after the given one, wrapping around to the first one.
+func nextPriceStyle(priceStyle chart.PriceStyle) chart.PriceStyle {
+	priceStyles := []chart.PriceStyle{
+		chart.Bar,
+		chart.Candlestick,
+		chart.HeikinAshi,
+		chart.Line,
+		chart.Area,
+	}
+
+	for i, p := range priceStyles {
+		if p == priceStyle {
+			return priceStyles[(i+1)%len(priceStyles)]
+		}
+	}
+
+	return priceStyles[0]
+}
+
 func nextInterval(interval model.Interval, zoomChange chart.ZoomChange) model.Interval {
 	// zoomIntervals are the ranges from most zoomed out to most zoomed in.
 	var zoomIntervals = []model.Interval{
@@ -464,10 +2247,71 @@ func (c *Controller) makeConfig() *config.Config {
 	if s := c.model.CurrentSymbol(); s != "" {
 		cfg.CurrentStock = &config.Stock{Symbol: s}
 	}
-	for _, s := range c.model.SidebarSymbols() {
-		cfg.Stocks = append(cfg.Stocks, &config.Stock{Symbol: s})
+	for _, name := range c.model.WatchlistNames() {
+		sections, err := c.model.WatchlistSections(name)
+		if err != nil {
+			logger.Errorf("WatchlistSections: %v", err)
+			continue
+		}
+
+		w := &config.Watchlist{Name: name}
+		for i, sec := range sections {
+			var stocks []*config.Stock
+			for _, s := range sec.Symbols {
+				stocks = append(stocks, &config.Stock{Symbol: s})
+			}
+
+			// The first section is untitled and holds the stocks above the titled sections.
+			if i == 0 {
+				w.Stocks = stocks
+				continue
+			}
+
+			w.Sections = append(w.Sections, &config.Section{
+				Name:      sec.Name,
+				Collapsed: sec.Collapsed,
+				Stocks:    stocks,
+			})
+		}
+		cfg.Watchlists = append(cfg.Watchlists, w)
+	}
+	for _, s := range c.model.ComparisonSymbols() {
+		cfg.Comparisons = append(cfg.Comparisons, &config.Stock{Symbol: s})
 	}
 	cfg.Settings.ChartSettings.PriceStyle = c.chartPriceStyle
+	cfg.Settings.ChartSettings.ThumbPriceStyle = c.thumbPriceStyle
 	cfg.Settings.ChartSettings.Interval = c.chartInterval
+	cfg.Settings.ChartSettings.MovingAverages = map[model.Interval][]*chart.MovingAverage{}
+	for i, mas := range c.chartMovingAverages {
+		cfg.Settings.ChartSettings.MovingAverages[i] = mas
+	}
+	cfg.Settings.ChartSettings.RSIPeriods = c.chartRSIPeriods
+	cfg.Settings.ChartSettings.ShowMACD = c.chartShowMACD
+	cfg.Settings.ChartSettings.ShowVolumeProfile = c.chartShowVolumeProfile
+	cfg.Settings.ChartSettings.PriceScale = c.chartPriceScale
+	cfg.Settings.ChartSettings.Benchmark = c.chartBenchmark
+	cfg.Settings.ChartSettings.RSUniverseFile = c.rsUniverseFile
+	cfg.Settings.ChartSettings.Layout = c.chartLayout
+	for _, cell := range c.chartCells {
+		cfg.Settings.ChartSettings.Cells = append(cfg.Settings.ChartSettings.Cells, &config.ChartCell{
+			Symbol:   cell.symbol,
+			Interval: cell.interval,
+		})
+	}
+	cfg.Settings.ChartSettings.FocusedCell = c.focusedCell
+	cfg.Settings.ChartSettings.Linked = c.chartLinked
+	if f := c.sidebarFilter; f != nil {
+		cfg.Settings.SidebarSettings.Filter = f.String()
+	}
+	cfg.Settings.SidebarSettings.Watchlist = c.model.CurrentWatchlistName()
+	cfg.Settings.SidebarSettings.SortMode = c.sidebarSortMode
+	// Copy the alerts, since checking them changes whether they are armed while the config saves in the background.
+	for _, a := range c.alerts {
+		a := *a
+		cfg.Alerts = append(cfg.Alerts, &a)
+	}
+	cfg.AlertHistory = append(cfg.AlertHistory, c.alertHistory...)
+	cfg.Lots = append(cfg.Lots, c.lots...)
+	cfg.Settings.NotifySettings = c.notifier.settings
 	return cfg
 }
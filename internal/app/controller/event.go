@@ -10,13 +10,15 @@ import (
 
 // event is a single event that the Controller should process on the main thread.
 type event struct {
-	symbol           string
-	interval         model.Interval
-	quote            *model.Quote
-	chart            *model.Chart
-	updateErr        error
-	refreshAllStocks bool
-	refreshStarted   bool
+	symbol              string
+	interval            model.Interval
+	quote               *model.Quote
+	chart               *model.Chart
+	updateErr           error
+	refreshAllStocks    bool
+	refreshCryptoStocks bool
+	refreshStarted      bool
+	rsRatings           map[string]int
 }
 
 // eventController collects events in a queue. It is thread-safe.
@@ -37,6 +39,8 @@ type eventHandler interface {
 	onStockUpdate(symbol string, q *model.Quote, ch *model.Chart) error
 	onStockUpdateError(symbol string, updateErr error) error
 	onRefreshAllStocksRequest(ctx context.Context) error
+	onRefreshCryptoStocksRequest(ctx context.Context) error
+	onRSRatingsUpdate(ratings map[string]int) error
 	onEventAdded()
 }
 
@@ -95,6 +99,16 @@ func (c *eventController) process(ctx context.Context) error {
 				return err
 			}
 
+		case e.refreshCryptoStocks:
+			if err := c.handler.onRefreshCryptoStocksRequest(ctx); err != nil {
+				return err
+			}
+
+		case e.rsRatings != nil:
+			if err := c.handler.onRSRatingsUpdate(e.rsRatings); err != nil {
+				return err
+			}
+
 		case e.refreshStarted:
 			if err := c.handler.onStockRefreshStarted(e.symbol); err != nil {
 				return err
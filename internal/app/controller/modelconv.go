@@ -6,6 +6,7 @@ import (
 
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/indicator"
 	"github.com/btmura/ponzi2/internal/logger"
 	"github.com/btmura/ponzi2/internal/stock/iex"
 )
@@ -13,6 +14,12 @@ import (
 // maxDataWeeks is maximum number of weeks of data to retain.
 const maxDataWeeks = 12 /* months */ * 4 /* weeks = 1 year */
 
+// Number of sessions in about a year to look back for new relative strength highs.
+const (
+	dailyRelativeStrengthLookback  = 252
+	weeklyRelativeStrengthLookback = 52
+)
+
 func modelIntradayChart(chart *iex.Chart) *model.Chart {
 	var ts []*model.TradingSession
 	for _, p := range chart.ChartPoints {
@@ -39,55 +46,49 @@ func modelIntradayChart(chart *iex.Chart) *model.Chart {
 	}
 }
 
-func modelDailyChart(quote *iex.Quote, chart *iex.Chart) *model.Chart {
+func modelDailyChart(quote *iex.Quote, chart *iex.Chart, benchmark *stockData, indicators []indicator.Indicator) *model.Chart {
 	ds := modelTradingSessions(quote, chart)
 	ws := weeklyModelTradingSessions(ds)
-	m8 := modelExponentialMovingAverages(ds, 8)
-	m21 := modelExponentialMovingAverages(ds, 21)
-	m50 := modelSimpleMovingAverages(ds, 50)
-	m200 := modelSimpleMovingAverages(ds, 200)
+	overlays, panes := indicator.ComputeAll(&model.TradingSessionSeries{TradingSessions: ds}, indicators)
+	rs := modelRelativeStrengthSeries(model.Daily, benchmark, ds)
 	v50 := modelAverageVolumes(ds, 50)
 
 	if len(ws) > maxDataWeeks {
 		start := ws[len(ws)-maxDataWeeks:][0].Date
 		ds = trimmedTradingSessions(ds, start)
-		m8 = trimmedMovingAverages(m8, start)
-		m21 = trimmedMovingAverages(m21, start)
-		m50 = trimmedMovingAverages(m50, start)
-		m200 = trimmedMovingAverages(m200, start)
+		overlays = trimmedIndicatorSeriesSet(overlays, start)
+		panes = trimmedIndicatorSeriesSet(panes, start)
+		rs = trimmedRelativeStrengthSeries(rs, start)
 		v50 = trimmedAverageVolumes(v50, start)
 	}
 
 	return &model.Chart{
-		Interval:             model.Daily,
-		TradingSessionSeries: &model.TradingSessionSeries{TradingSessions: ds},
-		MovingAverageSeriesSet: []*model.AverageSeries{
-			{Type: model.Exponential, Intervals: 8, Values: m8},
-			{Type: model.Exponential, Intervals: 21, Values: m21},
-			{Type: model.Simple, Intervals: 50, Values: m50},
-			{Type: model.Simple, Intervals: 200, Values: m200},
-		},
-		AverageVolumeSeries: &model.AverageSeries{Type: model.Simple, Intervals: 50, Values: v50},
+		Interval:               model.Daily,
+		TradingSessionSeries:   &model.TradingSessionSeries{TradingSessions: ds},
+		OverlaySeriesSet:       overlays,
+		PaneSeriesSet:          panes,
+		RelativeStrengthSeries: rs,
+		AverageVolumeSeries:    &model.AverageSeries{Type: model.Simple, Intervals: 50, Values: v50},
 	}
 }
 
-func modelWeeklyChart(quote *iex.Quote, chart *iex.Chart) *model.Chart {
+func modelWeeklyChart(quote *iex.Quote, chart *iex.Chart, benchmark *stockData, indicators []indicator.Indicator) *model.Chart {
 	ds := modelTradingSessions(quote, chart)
 	ws := weeklyModelTradingSessions(ds)
 
-	m10 := modelSimpleMovingAverages(ws, 10)
-	m40 := modelSimpleMovingAverages(ws, 40)
+	overlays, panes := indicator.ComputeAll(&model.TradingSessionSeries{TradingSessions: ws}, indicators)
+
+	rs := modelRelativeStrengthSeries(model.Weekly, benchmark, ws)
 
 	v10 := modelAverageVolumes(ws, 10)
 
 	return &model.Chart{
-		Interval:             model.Weekly,
-		TradingSessionSeries: &model.TradingSessionSeries{TradingSessions: ws},
-		MovingAverageSeriesSet: []*model.AverageSeries{
-			{Type: model.Simple, Intervals: 10, Values: m10},
-			{Type: model.Simple, Intervals: 40, Values: m40},
-		},
-		AverageVolumeSeries: &model.AverageSeries{Type: model.Simple, Intervals: 10, Values: v10},
+		Interval:               model.Weekly,
+		TradingSessionSeries:   &model.TradingSessionSeries{TradingSessions: ws},
+		OverlaySeriesSet:       overlays,
+		PaneSeriesSet:          panes,
+		RelativeStrengthSeries: rs,
+		AverageVolumeSeries:    &model.AverageSeries{Type: model.Simple, Intervals: 10, Values: v10},
 	}
 }
 
@@ -239,10 +240,11 @@ func weeklyModelTradingSessions(ds []*model.TradingSession) (ws []*model.Trading
 			continue
 		}
 
-		// Append if different week as previous.
-		_, week := p.Date.ISOWeek()
-		_, prevWeek := ws[len(ws)-1].Date.ISOWeek()
-		if week != prevWeek {
+		// Append if different week as previous. ISO weeks run Monday through Sunday,
+		// so weekend sessions of cryptocurrencies are combined into the same week.
+		year, week := p.Date.ISOWeek()
+		prevYear, prevWeek := ws[len(ws)-1].Date.ISOWeek()
+		if year != prevYear || week != prevWeek {
 			pCopy := *p
 			ws = append(ws, &pCopy)
 			continue
@@ -270,62 +272,80 @@ func weeklyModelTradingSessions(ds []*model.TradingSession) (ws []*model.Trading
 	return ws
 }
 
-func modelExponentialMovingAverages(ts []*model.TradingSession, n int) []*model.AverageValue {
-	var values []*model.AverageValue
-
-	smoothing := 2.0 / (float32(n) + 1.0)
-
-	value := func(i int) (avg float32) {
-		var prevEMA float32
-		switch {
-		case i < n:
-			// Not enough points to calculate SMA.
-			return 0
-
-		case i == n:
-			// Use yesterday's SMA for today's previous EMA.
-			var sum float32
-			for j := 0; j < n; j++ {
-				sum += ts[i-1-j].Close
-			}
-			prevEMA = sum / float32(n)
-
-		default:
-			// Use prev EMA.
-			prevEMA = values[i-1].Value
+// modelRelativeStrengthSeries divides the closes by the benchmark's closes of the same day or week.
+// It returns nil if the benchmark is missing.
+func modelRelativeStrengthSeries(interval model.Interval, benchmark *stockData, ts []*model.TradingSession) *model.RelativeStrengthSeries {
+	if benchmark == nil || benchmark.chart == nil {
+		return nil
+	}
+
+	type sessionKey struct {
+		year, day int
+	}
+
+	var bts []*model.TradingSession
+	var key func(t time.Time) sessionKey
+	var lookback int
+
+	switch interval {
+	case model.Daily:
+		bts = modelTradingSessions(benchmark.quote, benchmark.chart)
+		key = func(t time.Time) sessionKey { return sessionKey{t.Year(), t.YearDay()} }
+		lookback = dailyRelativeStrengthLookback
+
+	case model.Weekly:
+		// Match weeks instead of dates since the first session of a week can differ.
+		bts = weeklyModelTradingSessions(modelTradingSessions(benchmark.quote, benchmark.chart))
+		key = func(t time.Time) sessionKey {
+			year, week := t.ISOWeek()
+			return sessionKey{year, week}
 		}
-		return ts[i].Close*smoothing + prevEMA*(1-smoothing)
+		lookback = weeklyRelativeStrengthLookback
+
+	default:
+		return nil
 	}
 
-	for i := range ts {
-		values = append(values, &model.AverageValue{
-			Date:  ts[i].Date,
-			Value: value(i),
-		})
+	key2Close := map[sessionKey]float32{}
+	for _, s := range bts {
+		key2Close[key(s.Date)] = s.Close
 	}
-	return values
-}
 
-func modelSimpleMovingAverages(ts []*model.TradingSession, n int) []*model.AverageValue {
-	average := func(i, n int) (avg float32) {
-		if i+1-n < 0 {
-			return 0 // Not enough data
+	rs := &model.RelativeStrengthSeries{Benchmark: benchmark.chart.Symbol}
+	for i, s := range ts {
+		v := &model.RelativeStrengthValue{Date: s.Date}
+		if c := key2Close[key(s.Date)]; c > 0 {
+			v.Value = s.Close / c
 		}
-		var sum float32
-		for j := 0; j < n; j++ {
-			sum += ts[i-j].Close
+
+		// Mark new highs of the line over the lookback while the price has yet to make one.
+		if i >= lookback && v.Value > 0 {
+			v.NewHigh = isNewRelativeStrengthHigh(rs.Values[i-lookback:], v.Value) && !isNewCloseHigh(ts[i-lookback:i], s.Close)
 		}
-		return sum / float32(n)
+
+		rs.Values = append(rs.Values, v)
 	}
+	return rs
+}
 
-	var ms []*model.AverageValue
-	for i := range ts {
-		ms = append(ms, &model.AverageValue{
-			Date:  ts[i].Date,
-			Value: average(i, n),
-		})
+// isNewRelativeStrengthHigh returns whether the value is above all the values.
+func isNewRelativeStrengthHigh(vs []*model.RelativeStrengthValue, value float32) bool {
+	for _, v := range vs {
+		if v.Value >= value {
+			return false
+		}
 	}
-	return ms
+	return true
+}
+
+// isNewCloseHigh returns whether the close is above all the closes of the sessions.
+func isNewCloseHigh(ts []*model.TradingSession, close float32) bool {
+	for _, s := range ts {
+		if s.Close >= close {
+			return false
+		}
+	}
+	return true
 }
 
 func modelAverageVolumes(ts []*model.TradingSession, n int) []*model.AverageValue {
@@ -359,7 +379,15 @@ func trimmedTradingSessions(vs []*model.TradingSession, start time.Time) []*mode
 	return vs
 }
 
-func trimmedMovingAverages(vs []*model.AverageValue, start time.Time) []*model.AverageValue {
+func trimmedIndicatorSeriesSet(ss []*model.IndicatorSeries, start time.Time) []*model.IndicatorSeries {
+	for _, s := range ss {
+		s.Values = trimmedIndicatorValues(s.Values, start)
+		s.LowerValues = trimmedIndicatorValues(s.LowerValues, start)
+	}
+	return ss
+}
+
+func trimmedIndicatorValues(vs []*model.IndicatorValue, start time.Time) []*model.IndicatorValue {
 	for i, v := range vs {
 		if v.Date == start {
 			return vs[i:]
@@ -368,6 +396,19 @@ func trimmedMovingAverages(vs []*model.AverageValue, start time.Time) []*model.A
 	return vs
 }
 
+func trimmedRelativeStrengthSeries(rs *model.RelativeStrengthSeries, start time.Time) *model.RelativeStrengthSeries {
+	if rs == nil {
+		return nil
+	}
+	for i, v := range rs.Values {
+		if v.Date == start {
+			rs.Values = rs.Values[i:]
+			break
+		}
+	}
+	return rs
+}
+
 func trimmedAverageVolumes(vs []*model.AverageValue, start time.Time) []*model.AverageValue {
 	for i, v := range vs {
 		if v.Date == start {
@@ -55,47 +55,43 @@ func TestModelIntradayChart(t *testing.T) {
 	}
 }
 
-func TestExponentialMovingAverages(t *testing.T) {
+func TestWeeklyModelTradingSessions(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
 	for _, tt := range []struct {
-		desc                 string
-		inputTradingSessions []*model.TradingSession
-		inputNumDays         int
-		want                 []*model.AverageValue
+		desc  string
+		input []*model.TradingSession
+		want  []*model.TradingSession
 	}{
 		{
-			inputTradingSessions: []*model.TradingSession{
-				{
-					Date:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
-					Close: 100,
-				},
-				{
-					Date:  time.Date(2018, time.September, 2, 0, 0, 0, 0, time.UTC),
-					Close: 200,
-				},
-				{
-					Date:  time.Date(2018, time.September, 3, 0, 0, 0, 0, time.UTC),
-					Close: 300,
-				},
+			desc: "weekend sessions combine into the same week",
+			input: []*model.TradingSession{
+				{Date: day(2018, time.September, 14), Open: 1, High: 2, Low: 1, Close: 2, Volume: 10},
+				{Date: day(2018, time.September, 15), Open: 2, High: 4, Low: 2, Close: 3, Volume: 10},
+				{Date: day(2018, time.September, 16), Open: 3, High: 3, Low: 0.5, Close: 1, Volume: 10},
+				{Date: day(2018, time.September, 17), Open: 1, High: 2, Low: 1, Close: 2, Volume: 5},
 			},
-			inputNumDays: 2,
-			want: []*model.AverageValue{
-				{
-					Date:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
-					Value: 0,
-				},
-				{
-					Date:  time.Date(2018, time.September, 2, 0, 0, 0, 0, time.UTC),
-					Value: 0,
-				},
-				{
-					Date:  time.Date(2018, time.September, 3, 0, 0, 0, 0, time.UTC),
-					Value: 250,
-				},
+			want: []*model.TradingSession{
+				{Date: day(2018, time.September, 14), Open: 1, High: 4, Low: 0.5, Close: 1, Volume: 30},
+				{Date: day(2018, time.September, 17), Open: 1, High: 2, Low: 1, Close: 2, Volume: 5},
+			},
+		},
+		{
+			desc: "same week number in different years",
+			input: []*model.TradingSession{
+				{Date: day(2017, time.September, 11), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1},
+				{Date: day(2018, time.September, 10), Open: 2, High: 2, Low: 2, Close: 2, Volume: 2},
+			},
+			want: []*model.TradingSession{
+				{Date: day(2017, time.September, 11), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1},
+				{Date: day(2018, time.September, 10), Open: 2, High: 2, Low: 2, Close: 2, Volume: 2},
 			},
 		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
-			got := modelExponentialMovingAverages(tt.inputTradingSessions, tt.inputNumDays)
+			got := weeklyModelTradingSessions(tt.input)
 
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("diff (-want, +got)\n%s", diff)
@@ -103,3 +99,88 @@ func TestExponentialMovingAverages(t *testing.T) {
 		})
 	}
 }
+
+func TestModelRelativeStrengthSeries(t *testing.T) {
+	day := func(i int) time.Time {
+		return time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i)
+	}
+
+	// chart returns a chart with a session for each close.
+	chart := func(symbol string, closes []float32) *iex.Chart {
+		ch := &iex.Chart{Symbol: symbol}
+		for i, c := range closes {
+			ch.ChartPoints = append(ch.ChartPoints, &iex.ChartPoint{Date: day(i), Open: c, High: c, Low: c, Close: c})
+		}
+		return ch
+	}
+
+	// closes returns a year of closes with a peak in the middle followed by the last close.
+	closes := func(value, peak, last float32) []float32 {
+		var cs []float32
+		for i := 0; i < dailyRelativeStrengthLookback; i++ {
+			c := value
+			if i == dailyRelativeStrengthLookback/2 {
+				c = peak
+			}
+			cs = append(cs, c)
+		}
+		return append(cs, last)
+	}
+
+	for _, tt := range []struct {
+		desc          string
+		closes        []float32
+		benchmark     *stockData
+		wantNil       bool
+		wantBenchmark string
+		wantNewHighs  []int
+	}{
+		{
+			desc:    "missing benchmark",
+			closes:  closes(10, 20, 15),
+			wantNil: true,
+		},
+		{
+			desc:          "new relative strength high before the price",
+			closes:        closes(10, 20, 15),
+			benchmark:     &stockData{chart: chart("SPY", closes(10, 40, 10))},
+			wantBenchmark: "SPY",
+			wantNewHighs:  []int{dailyRelativeStrengthLookback},
+		},
+		{
+			desc:          "new relative strength high with the price",
+			closes:        closes(10, 20, 25),
+			benchmark:     &stockData{chart: chart("QQQ", closes(10, 40, 10))},
+			wantBenchmark: "QQQ",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ts := modelTradingSessions(nil, chart("AAPL", tt.closes))
+
+			got := modelRelativeStrengthSeries(model.Daily, tt.benchmark, ts)
+
+			if gotNil := got == nil; gotNil != tt.wantNil {
+				t.Fatalf("got nil: %t, want nil: %t", gotNil, tt.wantNil)
+			}
+
+			if got == nil {
+				return
+			}
+
+			if got.Benchmark != tt.wantBenchmark {
+				t.Errorf("got benchmark: %s, want: %s", got.Benchmark, tt.wantBenchmark)
+			}
+
+			var gotNewHighs []int
+			for i, v := range got.Values {
+				if v.NewHigh {
+					gotNewHighs = append(gotNewHighs, i)
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantNewHighs, gotNewHighs); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
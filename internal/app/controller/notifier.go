@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/config"
+	"github.com/btmura/ponzi2/internal/logger"
+	"github.com/btmura/ponzi2/internal/notify"
+)
+
+// defaultNotifyRateLimit is how many notifications each notifier sends per minute by default.
+const defaultNotifyRateLimit = 5
+
+// notifyTimeout is how long to wait for a notifier to send a notification.
+const notifyTimeout = 30 * time.Second
+
+// notifyQueueSize is how many events can wait to be sent before new events are dropped.
+const notifyQueueSize = 100
+
+type notifier struct {
+	// settings are the user's settings that the notifiers were set up from.
+	settings config.NotifySettings
+
+	// notifiers are the rate limited notifiers to send events through.
+	notifiers []notify.Notifier
+
+	// queue is a queue of events to send. Events are dropped when it is full,
+	// since the notifiers can take up to the notifyTimeout to send each event.
+	queue chan *notify.Event
+
+	// ctx is canceled when stopping to stop queuing events and cancel the event being sent.
+	ctx context.Context
+
+	// cancel cancels ctx.
+	cancel context.CancelFunc
+
+	// done indicates sending is done and the program may quit.
+	done chan bool
+
+	// enabled enables sending events when set to true.
+	enabled bool
+}
+
+func newNotifier() *notifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &notifier{
+		queue:  make(chan *notify.Event, notifyQueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan bool),
+	}
+}
+
+// setSettings sets up the notifiers from the user's settings. Call before starting.
+func (n *notifier) setSettings(settings config.NotifySettings) {
+	n.settings = settings
+	n.notifiers = nil
+
+	rateLimit := defaultNotifyRateLimit
+	if r := settings.RateLimit; r > 0 {
+		rateLimit = r
+	}
+
+	if settings.Desktop {
+		d, err := notify.NewDesktopNotifier()
+		if err != nil {
+			logger.Errorf("desktop notifications disabled: %v", err)
+		} else {
+			n.notifiers = append(n.notifiers, notify.NewRateLimiter(d, rateLimit, time.Minute))
+		}
+	}
+
+	if u := settings.WebhookURL; u != "" {
+		w, err := notify.NewWebhookNotifier(u)
+		if err != nil {
+			logger.Errorf("webhook notifications disabled: %v", err)
+		} else {
+			n.notifiers = append(n.notifiers, notify.NewRateLimiter(w, rateLimit, time.Minute))
+		}
+	}
+}
+
+func (n *notifier) notifyLoop() {
+	for {
+		select {
+		case <-n.ctx.Done():
+			n.done <- true
+			return
+
+		case e := <-n.queue:
+			n.send(e)
+		}
+	}
+}
+
+// send sends the event through each notifier until the notifier is stopped.
+func (n *notifier) send(e *notify.Event) {
+	for _, nt := range n.notifiers {
+		if n.ctx.Err() != nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(n.ctx, notifyTimeout)
+		err := nt.Notify(ctx, e)
+		cancel()
+
+		switch {
+		case errors.Is(err, notify.ErrRateLimited):
+			logger.Infof("notification rate limited: %s", e.Title)
+		case err != nil:
+			logger.Errorf("failed to notify: %v", err)
+		}
+	}
+}
+
+func (n *notifier) start() {
+	n.enabled = true
+}
+
+func (n *notifier) notify(e *notify.Event) {
+	if !n.enabled || len(n.notifiers) == 0 {
+		return
+	}
+
+	if n.ctx.Err() != nil {
+		return
+	}
+
+	// Queue the event for sending without blocking, since sending can be slow.
+	select {
+	case n.queue <- e:
+	default:
+		logger.Errorf("notification queue full, dropping: %s", e.Title)
+	}
+}
+
+// stop cancels the event being sent and drops the queued events, so that quitting isn't held up by slow notifiers.
+func (n *notifier) stop() {
+	n.enabled = false
+	n.cancel()
+	<-n.done
+}
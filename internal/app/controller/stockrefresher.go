@@ -6,10 +6,16 @@ import (
 
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/indicator"
 	"github.com/btmura/ponzi2/internal/logger"
+	"github.com/btmura/ponzi2/internal/rating"
 	"github.com/btmura/ponzi2/internal/stock/iex"
 )
 
+// maxRSRatingBatchSize is the most symbols to request charts for at once when ranking,
+// since IEX rejects batch requests with more than 100 symbols.
+const maxRSRatingBatchSize = 100
+
 type stockRefresher struct {
 	// iexClient fetches stock data to update the model.
 	iexClient iexClientInterface
@@ -20,7 +26,15 @@ type stockRefresher struct {
 	// eventController allows the stockRefresher to post stock updates.
 	eventController *eventController
 
-	// refreshTicker ticks to trigger refreshes during market hours.
+	// indicators are the indicators to compute for each interval.
+	// Set before starting since refreshes read it from other goroutines.
+	indicators map[model.Interval][]indicator.Indicator
+
+	// benchmark is the symbol like SPY that is fetched with every refresh for relative strength.
+	// Set before starting since refreshes read it from other goroutines.
+	benchmark string
+
+	// refreshTicker ticks to trigger refreshes.
 	refreshTicker *time.Ticker
 
 	// enabled enables refreshing stocks when set to true.
@@ -36,7 +50,7 @@ func newStockRefresher(iexClient iexClientInterface, token string, eventControll
 	}
 }
 
-// refreshLoop refreshes stocks during market hours.
+// refreshLoop refreshes stocks during market hours and cryptocurrencies at all other times.
 func (s *stockRefresher) refreshLoop() {
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
@@ -48,13 +62,23 @@ func (s *stockRefresher) refreshLoop() {
 		openTime := time.Date(n.Year(), n.Month(), n.Day(), 9, 30, 0, 0, loc)
 		closeTime := time.Date(n.Year(), n.Month(), n.Day(), 16, 0, 0, 0, loc)
 
+		// Cryptocurrencies trade around the clock, so keep refreshing them when the stock market is closed.
 		if openTime.Weekday() == time.Saturday || openTime.Weekday() == time.Sunday || t.Before(openTime) || t.After(closeTime) {
+			s.eventController.addEventLocked(event{refreshCryptoStocks: true})
 			continue
 		}
 
 		s.eventController.addEventLocked(event{refreshAllStocks: true})
 	}
 }
+func (s *stockRefresher) setIndicators(indicators map[model.Interval][]indicator.Indicator) {
+	s.indicators = indicators
+}
+
+func (s *stockRefresher) setBenchmark(benchmark string) {
+	s.benchmark = benchmark
+}
+
 func (s *stockRefresher) start() {
 	s.enabled = true
 }
@@ -85,7 +109,7 @@ func (s *stockRefresher) refresh(ctx context.Context, d *dataRequestBuilder) err
 		return nil
 	}
 
-	reqs, err := d.dataRequests(s.token)
+	reqs, err := d.dataRequests(s.token, s.benchmark)
 	if err != nil {
 		return err
 	}
@@ -127,11 +151,6 @@ func (s *stockRefresher) refresh(ctx context.Context, d *dataRequestBuilder) err
 				return
 			}
 
-			type stockData struct {
-				quote *iex.Quote
-				chart *iex.Chart
-			}
-
 			symbol2StockData := map[string]*stockData{}
 
 			for _, q := range quotes {
@@ -152,9 +171,21 @@ func (s *stockRefresher) refresh(ctx context.Context, d *dataRequestBuilder) err
 				d.chart = ch
 			}
 
+			benchmark := symbol2StockData[s.benchmark]
+
+			requested := map[string]bool{}
+			for _, sym := range req.symbols {
+				requested[sym] = true
+			}
+
 			var es []event
 
 			for sym, stockData := range symbol2StockData {
+				// Skip the benchmark if it was only fetched for relative strength.
+				if !requested[sym] {
+					continue
+				}
+
 				q, err := modelQuote(stockData.quote)
 				if err != nil {
 					es = append(es, event{
@@ -175,7 +206,7 @@ func (s *stockRefresher) refresh(ctx context.Context, d *dataRequestBuilder) err
 						})
 
 					case model.Daily:
-						ch := modelDailyChart(stockData.quote, stockData.chart)
+						ch := modelDailyChart(stockData.quote, stockData.chart, benchmark, s.indicators[model.Daily])
 						es = append(es, event{
 							symbol: sym,
 							quote:  q,
@@ -183,7 +214,7 @@ func (s *stockRefresher) refresh(ctx context.Context, d *dataRequestBuilder) err
 						})
 
 					case model.Weekly:
-						ch := modelWeeklyChart(stockData.quote, stockData.chart)
+						ch := modelWeeklyChart(stockData.quote, stockData.chart, benchmark, s.indicators[model.Weekly])
 						es = append(es, event{
 							symbol: sym,
 							quote:  q,
@@ -210,6 +241,64 @@ func (s *stockRefresher) refresh(ctx context.Context, d *dataRequestBuilder) err
 	return nil
 }
 
+// refreshRSRatings ranks the symbols by relative strength using their daily chart history,
+// which the iex client mostly serves from its chart cache, and posts the ratings as an event.
+func (s *stockRefresher) refreshRSRatings(ctx context.Context, symbols []string) error {
+	if !s.enabled || len(symbols) == 0 {
+		return nil
+	}
+
+	for _, sym := range symbols {
+		if err := model.ValidateSymbol(sym); err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		symbol2Score := map[string]float32{}
+		for start := 0; start < len(symbols); start += maxRSRatingBatchSize {
+			end := start + maxRSRatingBatchSize
+			if end > len(symbols) {
+				end = len(symbols)
+			}
+
+			req := &iex.GetChartsRequest{
+				Token:   s.token,
+				Symbols: symbols[start:end],
+				Range:   iex.TwoYears,
+			}
+
+			// Bail out rather than rank a partial universe, which would inflate the ratings.
+			charts, err := s.iexClient.GetCharts(ctx, req)
+			if err != nil {
+				logger.Errorf("GetCharts: %v", err)
+				return
+			}
+
+			for _, ch := range charts {
+				if score, ok := rating.Score(modelTradingSessions(nil, ch)); ok {
+					symbol2Score[ch.Symbol] = score
+				}
+			}
+		}
+
+		ratings := rating.Rank(symbol2Score)
+		if ratings == nil {
+			ratings = map[string]int{}
+		}
+
+		s.eventController.addEventLocked(event{rsRatings: ratings})
+	}()
+
+	return nil
+}
+
+// stockData is the quote and chart of a symbol from a single request.
+type stockData struct {
+	quote *iex.Quote
+	chart *iex.Chart
+}
+
 // dataRequestBuilder accumulates symbols into request groups and then builds the requests.
 type dataRequestBuilder struct {
 	symbolGroups map[dataRequestGroup][]string
@@ -285,7 +374,9 @@ type dataRequest struct {
 	chartsRequest *iex.GetChartsRequest
 }
 
-func (d *dataRequestBuilder) dataRequests(token string) ([]*dataRequest, error) {
+// dataRequests returns the requests for the symbols. Requests with daily or weekly data
+// also fetch the benchmark, so that relative strength can be computed. Empty for no benchmark.
+func (d *dataRequestBuilder) dataRequests(token, benchmark string) ([]*dataRequest, error) {
 	var reqs []*dataRequest
 	for group, ss := range d.symbolGroups {
 		var iexRange iex.Range
@@ -297,19 +388,33 @@ func (d *dataRequestBuilder) dataRequests(token string) ([]*dataRequest, error)
 			return nil, errs.Errorf("bad group: %v", group)
 		}
 
+		fetched := ss
+		if benchmark != "" && !containsSymbol(ss, benchmark) {
+			fetched = append(append([]string(nil), ss...), benchmark)
+		}
+
 		reqs = append(reqs, &dataRequest{
 			symbols:   ss,
 			intervals: group.Intervals(),
 			quotesRequest: &iex.GetQuotesRequest{
 				Token:   token,
-				Symbols: ss,
+				Symbols: fetched,
 			},
 			chartsRequest: &iex.GetChartsRequest{
 				Token:   token,
-				Symbols: ss,
+				Symbols: fetched,
 				Range:   iexRange,
 			},
 		})
 	}
 	return reqs, nil
 }
+
+func containsSymbol(symbols []string, symbol string) bool {
+	for _, s := range symbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
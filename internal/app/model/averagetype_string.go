@@ -11,11 +11,12 @@ func _() {
 	_ = x[AverageTypeUnspecified-0]
 	_ = x[Simple-1]
 	_ = x[Exponential-2]
+	_ = x[Weighted-3]
 }
 
-const _AverageType_name = "AverageTypeUnspecifiedSimpleExponential"
+const _AverageType_name = "AverageTypeUnspecifiedSimpleExponentialWeighted"
 
-var _AverageType_index = [...]uint8{0, 22, 28, 39}
+var _AverageType_index = [...]uint8{0, 22, 28, 39, 47}
 
 func (i AverageType) String() string {
 	if i < 0 || i >= AverageType(len(_AverageType_index)-1) {
@@ -0,0 +1,83 @@
+package model
+
+import "github.com/btmura/ponzi2/internal/errs"
+
+// ChartLayout is how many charts the main area shows and how they are arranged.
+type ChartLayout int
+
+// ChartLayout values.
+const (
+	ChartLayoutUnspecified ChartLayout = iota
+
+	// SingleChart shows one chart filling the main area.
+	SingleChart
+
+	// SideBySide shows two charts next to each other.
+	SideBySide
+
+	// TwoByTwo shows four charts in two rows of two.
+	TwoByTwo
+
+	// ThreeByThree shows nine charts in three rows of three.
+	ThreeByThree
+
+	// Stacked shows two charts with one above the other.
+	Stacked
+)
+
+// String returns the spec of the layout like "2x2" that ParseChartLayout accepts.
+func (l ChartLayout) String() string {
+	switch l {
+	case SingleChart:
+		return "1"
+	case SideBySide:
+		return "2"
+	case Stacked:
+		return "2v"
+	case TwoByTwo:
+		return "2x2"
+	case ThreeByThree:
+		return "3x3"
+	default:
+		return "?"
+	}
+}
+
+// Rows returns the number of rows of charts.
+func (l ChartLayout) Rows() int {
+	switch l {
+	case Stacked, TwoByTwo:
+		return 2
+	case ThreeByThree:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Columns returns the number of charts in each row.
+func (l ChartLayout) Columns() int {
+	switch l {
+	case SideBySide, TwoByTwo:
+		return 2
+	case ThreeByThree:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Cells returns the total number of charts.
+func (l ChartLayout) Cells() int {
+	return l.Rows() * l.Columns()
+}
+
+// ParseChartLayout parses a layout spec like "1", "2", "2v", "2x2", or "3x3".
+func ParseChartLayout(spec string) (ChartLayout, error) {
+	for _, l := range []ChartLayout{SingleChart, SideBySide, Stacked, TwoByTwo, ThreeByThree} {
+		if l.String() == spec {
+			return l, nil
+		}
+	}
+	return ChartLayoutUnspecified, errs.Errorf("bad layout %q, want 1, 2, 2v, 2x2, or 3x3", spec)
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseChartLayout(t *testing.T) {
+	for _, tt := range []struct {
+		spec      string
+		want      ChartLayout
+		wantCells int
+		wantErr   bool
+	}{
+		{"1", SingleChart, 1, false},
+		{"2", SideBySide, 2, false},
+		{"2v", Stacked, 2, false},
+		{"2x2", TwoByTwo, 4, false},
+		{"3x3", ThreeByThree, 9, false},
+		{"", ChartLayoutUnspecified, 1, true},
+		{"4x4", ChartLayoutUnspecified, 1, true},
+	} {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, gotErr := ParseChartLayout(tt.spec)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCells, got.Cells()); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
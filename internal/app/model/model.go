@@ -2,7 +2,9 @@
 package model
 
 import (
+	"math"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/btmura/ponzi2/internal/errs"
@@ -15,18 +17,115 @@ var now = time.Now
 // validSymbolRegexp is a regexp that accepts valid stock symbols. Examples: X, FB, SPY, AAPL
 var validSymbolRegexp = regexp.MustCompile("^[A-Z]{1,5}$")
 
+// validCryptoSymbolRegexp is a regexp that accepts valid cryptocurrency symbols. Examples: BTCUSD, ETHUSDT
+var validCryptoSymbolRegexp = regexp.MustCompile("^[A-Z]{3,5}(USD|USDT|USDC)$")
+
 // Model models the app's state.
 type Model struct {
-	// currentSymbol is the symbol of the stock shown in the main area.
+	// currentSymbol is the symbol of the stock shown in the main area's focused chart.
 	currentSymbol string
 
-	// sidebarSymbols is an ordered list of symbols shown in the sidebar.
-	sidebarSymbols []string
+	// chartSymbols are the symbols of the stocks shown in all of the main area's charts.
+	chartSymbols []string
+
+	// watchlists are the named lists of symbols that the sidebar can show.
+	watchlists []*watchlist
+
+	// currentWatchlist is the watchlist shown in the sidebar.
+	currentWatchlist *watchlist
+
+	// comparisonSymbols is an ordered list of symbols compared against the current symbol.
+	comparisonSymbols []string
 
 	// symbol2Stock is map from symbol to Stock data.
 	symbol2Stock map[string]*Stock
 }
 
+// DefaultWatchlistName is the name of the watchlist that a new model starts with.
+const DefaultWatchlistName = "Watchlist"
+
+// watchlist is a named and ordered list of symbols grouped into sections.
+type watchlist struct {
+	// name is the non-empty name of the watchlist.
+	name string
+
+	// sections are the watchlist's sections in order.
+	// The first section is untitled and holds the symbols above the titled sections.
+	sections []*WatchlistSection
+}
+
+// WatchlistSection is a titled group of symbols in a watchlist like "Tech" that can be collapsed.
+type WatchlistSection struct {
+	// Name is the name of the section. Empty for the untitled first section.
+	Name string
+
+	// Collapsed is true if the section's symbols are hidden in the sidebar.
+	Collapsed bool
+
+	// Symbols is an ordered list of symbols in the section.
+	Symbols []string
+}
+
+// newWatchlist returns an empty watchlist with only the untitled section.
+func newWatchlist(name string) *watchlist {
+	return &watchlist{
+		name:     name,
+		sections: []*WatchlistSection{{}},
+	}
+}
+
+// symbols returns the symbols of all the sections in order.
+func (w *watchlist) symbols() []string {
+	var symbols []string
+	for _, sec := range w.sections {
+		symbols = append(symbols, sec.Symbols...)
+	}
+	return symbols
+}
+
+// sidebarSlot is a section header or a symbol in the order shown in the sidebar.
+type sidebarSlot struct {
+	// section is the section of the header or symbol.
+	section *WatchlistSection
+
+	// symbol is the symbol of the slot. Empty for a header.
+	symbol string
+}
+
+// slots returns the headers of the titled sections and the symbols in the order shown in the sidebar.
+func (w *watchlist) slots() []sidebarSlot {
+	var slots []sidebarSlot
+	for i, sec := range w.sections {
+		if i != 0 {
+			slots = append(slots, sidebarSlot{section: sec})
+		}
+		for _, s := range sec.Symbols {
+			slots = append(slots, sidebarSlot{section: sec, symbol: s})
+		}
+	}
+	return slots
+}
+
+// setSlots regroups the symbols into sections by the headers before them.
+func (w *watchlist) setSlots(slots []sidebarSlot) {
+	var sections []*WatchlistSection
+	current := w.sections[0]
+	current.Symbols = nil
+	sections = append(sections, current)
+
+	for _, slot := range slots {
+		if slot.symbol == "" {
+			current = slot.section
+			current.Symbols = nil
+			sections = append(sections, current)
+			continue
+		}
+		current.Symbols = append(current.Symbols, slot.symbol)
+	}
+
+	w.sections = sections
+}
+
 // Stock has a stock's symbol and charts.
 type Stock struct {
 	// Symbol is the stock's non-empty symbol.
@@ -41,11 +140,21 @@ type Stock struct {
 
 // Chart has multiple series of data to be graphed.
 type Chart struct {
-	Interval               Interval
-	TradingSessionSeries   *TradingSessionSeries
-	MovingAverageSeriesSet []*AverageSeries
-	AverageVolumeSeries    *AverageSeries
-	LastUpdateTime         time.Time
+	Interval             Interval
+	TradingSessionSeries *TradingSessionSeries
+
+	// OverlaySeriesSet are indicator series drawn on top of the prices like moving averages.
+	OverlaySeriesSet []*IndicatorSeries
+
+	// PaneSeriesSet are indicator series drawn in separate panes.
+	// Series with the same Pane are drawn together in the same pane.
+	PaneSeriesSet []*IndicatorSeries
+
+	// RelativeStrengthSeries compares the closes to a benchmark's closes. Nil if the benchmark is missing.
+	RelativeStrengthSeries *RelativeStrengthSeries
+
+	AverageVolumeSeries *AverageSeries
+	LastUpdateTime      time.Time
 }
 
 // Quote is the latest quote for the stock.
@@ -79,6 +188,21 @@ const (
 	LastTrade
 )
 
+// Market is the market where a symbol trades which determines its trading hours.
+type Market int
+
+// Market values.
+//go:generate stringer -type=Market
+const (
+	MarketUnspecified Market = iota
+
+	// StockMarket trades during regular hours on weekdays.
+	StockMarket
+
+	// CryptoMarket trades around the clock every day of the week.
+	CryptoMarket
+)
+
 // Interval is the interval spanned by each trading session.
 type Interval int
 
@@ -135,7 +259,7 @@ func (s *TradingSession) DeepCopy() *TradingSession {
 	return &deep
 }
 
-// AverageSeries is a time series of average values like a moving average or average volume.
+// AverageSeries is a time series of average values like the average volume.
 type AverageSeries struct {
 	// Type is the average type like simple or exponential.
 	Type AverageType
@@ -171,6 +295,7 @@ const (
 	AverageTypeUnspecified AverageType = iota
 	Simple
 	Exponential
+	Weighted
 )
 
 // AverageValue is a single data point in an AverageSeries.
@@ -191,10 +316,289 @@ func (a *AverageValue) DeepCopy() *AverageValue {
 	return &deep
 }
 
+// IndicatorSeries is a time series computed by an indicator like a moving average.
+type IndicatorSeries struct {
+	// Name is the short name shown in legends like "SMA 50".
+	Name string
+
+	// Pane is the name of the pane to draw the series in. Empty for overlays.
+	Pane string
+
+	// Range is the fixed range of the values like 0 to 100. Zero to fit the range to the values.
+	Range [2]float32
+
+	// Levels are values of guide lines drawn across the series' pane like 30 and 70.
+	Levels []float32
+
+	// Histogram is true to draw the values as bars from zero instead of a line.
+	Histogram bool
+
+	// Values are sorted by date in ascending order.
+	Values []*IndicatorValue
+
+	// LowerValues are the lower edge of a band whose upper edge is Values. Nil for lines.
+	LowerValues []*IndicatorValue
+}
+
+// DeepCopy returns a deep copy of the series.
+func (s *IndicatorSeries) DeepCopy() *IndicatorSeries {
+	if s == nil {
+		return nil
+	}
+	deep := *s
+	if len(deep.Levels) != 0 {
+		deep.Levels = append([]float32(nil), s.Levels...)
+	}
+	if len(deep.Values) != 0 {
+		deep.Values = make([]*IndicatorValue, len(s.Values))
+		for i, v := range s.Values {
+			deep.Values[i] = v.DeepCopy()
+		}
+	}
+	if len(deep.LowerValues) != 0 {
+		deep.LowerValues = make([]*IndicatorValue, len(s.LowerValues))
+		for i, v := range s.LowerValues {
+			deep.LowerValues[i] = v.DeepCopy()
+		}
+	}
+	return &deep
+}
+
+// IndicatorValue is a single data point in an IndicatorSeries.
+type IndicatorValue struct {
+	// Date is the start date of the data point.
+	Date time.Time
+
+	// Value is the indicator value.
+	Value float32
+}
+
+// DeepCopy returns a deep copy of the value.
+func (v *IndicatorValue) DeepCopy() *IndicatorValue {
+	if v == nil {
+		return nil
+	}
+	deep := *v
+	return &deep
+}
+
+// RelativeStrengthSeries is a time series of closes divided by a benchmark's closes.
+type RelativeStrengthSeries struct {
+	// Benchmark is the symbol like SPY that the closes are compared against.
+	Benchmark string
+
+	// Values are sorted by date in ascending order.
+	Values []*RelativeStrengthValue
+}
+
+// DeepCopy returns a deep copy of the series.
+func (r *RelativeStrengthSeries) DeepCopy() *RelativeStrengthSeries {
+	if r == nil {
+		return nil
+	}
+	deep := *r
+	if len(deep.Values) != 0 {
+		deep.Values = make([]*RelativeStrengthValue, len(r.Values))
+		for i, v := range r.Values {
+			deep.Values[i] = v.DeepCopy()
+		}
+	}
+	return &deep
+}
+
+// RelativeStrengthValue is a single data point in a RelativeStrengthSeries.
+type RelativeStrengthValue struct {
+	// Date is the start date of the data point.
+	Date time.Time
+
+	// Value is the close divided by the benchmark's close. Zero if the benchmark has no close.
+	Value float32
+
+	// NewHigh is true if the value is a new high while the close is not, so the line leads the price.
+	NewHigh bool
+}
+
+// DeepCopy returns a deep copy of the value.
+func (v *RelativeStrengthValue) DeepCopy() *RelativeStrengthValue {
+	if v == nil {
+		return nil
+	}
+	deep := *v
+	return &deep
+}
+
+// valueAreaFraction is the fraction of the total volume within a volume profile's value area.
+const valueAreaFraction = 0.7
+
+// VolumeProfile is the volume traded at each price level over a series of trading sessions.
+type VolumeProfile struct {
+	// Buckets are equal price ranges sorted by price in ascending order.
+	Buckets []*VolumeProfileBucket
+
+	// PointOfControl is the index of the bucket with the most volume.
+	PointOfControl int
+
+	// ValueAreaLow is the index of the lowest bucket in the value area.
+	// The value area is the buckets around the point of control with 70% of the total volume.
+	ValueAreaLow int
+
+	// ValueAreaHigh is the index of the highest bucket in the value area.
+	ValueAreaHigh int
+}
+
+// VolumeProfileBucket is the volume traded within a price range.
+type VolumeProfileBucket struct {
+	// Low is the inclusive bottom of the price range.
+	Low float32
+
+	// High is the exclusive top of the price range unless it is the top bucket.
+	High float32
+
+	// Volume is the volume traded within the price range.
+	Volume float32
+}
+
+// VolumeProfile returns the volume profile of the series with the given number of buckets.
+// Each session's volume is spread evenly over its range from low to high.
+// Nil if the series has no volume or the number of buckets is not positive.
+func (t *TradingSessionSeries) VolumeProfile(bucketCount int) *VolumeProfile {
+	if t == nil || bucketCount <= 0 {
+		return nil
+	}
+
+	var low float32 = math.MaxFloat32
+	var high float32
+	for _, s := range t.TradingSessions {
+		if s.Low <= 0 || s.High < s.Low {
+			continue
+		}
+		if s.Low < low {
+			low = s.Low
+		}
+		if s.High > high {
+			high = s.High
+		}
+	}
+
+	if low > high {
+		return nil
+	}
+
+	// Use a single bucket if every session traded at the same price.
+	if low == high {
+		bucketCount = 1
+	}
+
+	width := (high - low) / float32(bucketCount)
+
+	p := &VolumeProfile{}
+	for i := 0; i < bucketCount; i++ {
+		p.Buckets = append(p.Buckets, &VolumeProfileBucket{
+			Low:  low + float32(i)*width,
+			High: low + float32(i+1)*width,
+		})
+	}
+	p.Buckets[bucketCount-1].High = high
+
+	// bucket returns the index of the bucket with the price.
+	bucket := func(price float32) int {
+		if width == 0 {
+			return 0
+		}
+		i := int((price - low) / width)
+		if i >= bucketCount {
+			return bucketCount - 1
+		}
+		return i
+	}
+
+	var total float32
+	for _, s := range t.TradingSessions {
+		if s.Low <= 0 || s.High < s.Low || s.Volume <= 0 {
+			continue
+		}
+
+		volume := float32(s.Volume)
+		total += volume
+
+		if s.Low == s.High {
+			p.Buckets[bucket(s.Low)].Volume += volume
+			continue
+		}
+
+		for i := bucket(s.Low); i <= bucket(s.High); i++ {
+			b := p.Buckets[i]
+			overlap := float32(math.Min(float64(b.High), float64(s.High)) - math.Max(float64(b.Low), float64(s.Low)))
+			if overlap > 0 {
+				b.Volume += volume * overlap / (s.High - s.Low)
+			}
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	for i, b := range p.Buckets {
+		if b.Volume > p.Buckets[p.PointOfControl].Volume {
+			p.PointOfControl = i
+		}
+	}
+
+	// Grow the value area from the point of control towards the side with more volume.
+	p.ValueAreaLow, p.ValueAreaHigh = p.PointOfControl, p.PointOfControl
+	valueAreaVolume := p.Buckets[p.PointOfControl].Volume
+	for valueAreaVolume < total*valueAreaFraction {
+		var below, above float32 = -1, -1
+		if p.ValueAreaLow > 0 {
+			below = p.Buckets[p.ValueAreaLow-1].Volume
+		}
+		if p.ValueAreaHigh < bucketCount-1 {
+			above = p.Buckets[p.ValueAreaHigh+1].Volume
+		}
+
+		switch {
+		case below < 0 && above < 0:
+			return p
+
+		case above >= below:
+			p.ValueAreaHigh++
+			valueAreaVolume += above
+
+		default:
+			p.ValueAreaLow--
+			valueAreaVolume += below
+		}
+	}
+
+	return p
+}
+
+// NewHigh returns true if the last session's high is above the highs of the sessions before it
+// within the given number of sessions like 252 for a 52-week high.
+// False if the series does not span that many sessions.
+func (t *TradingSessionSeries) NewHigh(sessions int) bool {
+	if t == nil || sessions <= 1 || len(t.TradingSessions) < sessions {
+		return false
+	}
+
+	ts := t.TradingSessions[len(t.TradingSessions)-sessions:]
+	last := ts[len(ts)-1]
+	for _, s := range ts[:len(ts)-1] {
+		if s.High >= last.High {
+			return false
+		}
+	}
+	return true
+}
+
 // New creates a new Model.
 func New() *Model {
+	w := newWatchlist(DefaultWatchlistName)
 	return &Model{
-		symbol2Stock: map[string]*Stock{},
+		watchlists:       []*watchlist{w},
+		currentWatchlist: w,
+		symbol2Stock:     map[string]*Stock{},
 	}
 }
 
@@ -230,28 +634,402 @@ func (m *Model) SetCurrentSymbol(symbol string) (changed bool, err error) {
 	return true, nil
 }
 
-// SidebarSymbols returns the sidebar's symbols.
+// ClearCurrentSymbol clears the current symbol like when an empty chart is focused.
+func (m *Model) ClearCurrentSymbol() {
+	old := m.currentSymbol
+	m.currentSymbol = ""
+	if !m.containsSymbol(old) {
+		delete(m.symbol2Stock, old)
+	}
+}
+
+// ChartSymbols returns the symbols of the stocks shown in all of the main area's charts.
+func (m *Model) ChartSymbols() []string {
+	return append([]string(nil), m.chartSymbols...)
+}
+
+// SetChartSymbols sets the symbols of the stocks shown in all of the main area's charts.
+// Keeping the current symbol among them keeps its stock when another chart is focused.
+func (m *Model) SetChartSymbols(symbols []string) error {
+	for _, s := range symbols {
+		if err := ValidateSymbol(s); err != nil {
+			return err
+		}
+	}
+
+	old := m.chartSymbols
+	m.chartSymbols = append([]string(nil), symbols...)
+
+	// Remove the old stocks that are no longer in the model.
+	for _, s := range old {
+		if !m.containsSymbol(s) {
+			delete(m.symbol2Stock, s)
+		}
+	}
+
+	// Add stock placeholders for the new symbols if they don't exist.
+	for _, s := range symbols {
+		if m.symbol2Stock[s] == nil {
+			m.symbol2Stock[s] = &Stock{Symbol: s}
+		}
+	}
+
+	return nil
+}
+
+// SidebarSymbols returns the symbols of the current watchlist shown in the sidebar.
 func (m *Model) SidebarSymbols() []string {
+	return m.currentWatchlist.symbols()
+}
+
+// SidebarSections returns copies of the current watchlist's sections shown in the sidebar.
+func (m *Model) SidebarSections() []*WatchlistSection {
+	sections, _ := m.WatchlistSections(m.currentWatchlist.name)
+	return sections
+}
+
+// AddSidebarSymbol adds a symbol to the current watchlist and returns true if the stock was newly added.
+func (m *Model) AddSidebarSymbol(symbol string) (added bool, err error) {
+	return m.AddWatchlistSymbol(m.currentWatchlist.name, symbol)
+}
+
+// RemoveSidebarSymbol removes a symbol from the current watchlist and returns true if removed.
+func (m *Model) RemoveSidebarSymbol(symbol string) (removed bool, err error) {
+	if err := ValidateSymbol(symbol); err != nil {
+		return false, err
+	}
+
+	for _, sec := range m.currentWatchlist.sections {
+		for i, s := range sec.Symbols {
+			if s == symbol {
+				sec.Symbols = append(sec.Symbols[:i], sec.Symbols[i+1:]...)
+				if !m.containsSymbol(symbol) {
+					delete(m.symbol2Stock, symbol)
+				}
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// SwapSidebarSlots swaps two sidebar slots. The slots are the headers of the titled sections and the symbols
+// in the order shown in the sidebar, so swapping a symbol with a header moves the symbol into the next section.
+func (m *Model) SwapSidebarSlots(i, j int) (swapped bool) {
+	slots := m.currentWatchlist.slots()
+
+	if i < 0 || i >= len(slots) {
+		logger.Errorf("slot index i (%d) is out of bounds (%d)", i, len(slots))
+		return false
+	}
+
+	if j < 0 || j >= len(slots) {
+		logger.Errorf("slot index j (%d) is out of bounds (%d)", j, len(slots))
+		return false
+	}
+
+	if i == j {
+		return false
+	}
+
+	slots[i], slots[j] = slots[j], slots[i]
+	m.currentWatchlist.setSlots(slots)
+	return true
+}
+
+// WatchlistNames returns the names of the watchlists in order.
+func (m *Model) WatchlistNames() []string {
+	var names []string
+	for _, w := range m.watchlists {
+		names = append(names, w.name)
+	}
+	return names
+}
+
+// CurrentWatchlistName returns the name of the watchlist shown in the sidebar.
+func (m *Model) CurrentWatchlistName() string {
+	return m.currentWatchlist.name
+}
+
+// WatchlistSymbols returns the symbols of the named watchlist or an error if there is no such watchlist.
+func (m *Model) WatchlistSymbols(name string) ([]string, error) {
+	w, err := m.watchlist(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.symbols(), nil
+}
+
+// WatchlistSections returns copies of the named watchlist's sections or an error if there is no such watchlist.
+func (m *Model) WatchlistSections(name string) ([]*WatchlistSection, error) {
+	w, err := m.watchlist(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []*WatchlistSection
+	for _, sec := range w.sections {
+		sections = append(sections, &WatchlistSection{
+			Name:      sec.Name,
+			Collapsed: sec.Collapsed,
+			Symbols:   append([]string(nil), sec.Symbols...),
+		})
+	}
+	return sections, nil
+}
+
+// AddWatchlistSymbol adds a symbol to the last section of the named watchlist and returns true if the symbol was newly added.
+func (m *Model) AddWatchlistSymbol(name, symbol string) (added bool, err error) {
+	if err := ValidateSymbol(symbol); err != nil {
+		return false, err
+	}
+
+	w, err := m.watchlist(name)
+	if err != nil {
+		return false, err
+	}
+
+	for _, s := range w.symbols() {
+		if s == symbol {
+			return false, nil
+		}
+	}
+
+	last := w.sections[len(w.sections)-1]
+	last.Symbols = append(last.Symbols, symbol)
+
+	// Add a stock placeholder for the new symbol if it's shown and doesn't exist.
+	if w == m.currentWatchlist && m.symbol2Stock[symbol] == nil {
+		m.symbol2Stock[symbol] = &Stock{Symbol: symbol}
+	}
+
+	return true, nil
+}
+
+// AddWatchlist adds an empty watchlist with the name and returns true if the watchlist was newly added.
+func (m *Model) AddWatchlist(name string) (added bool, err error) {
+	if err := ValidateWatchlistName(name); err != nil {
+		return false, err
+	}
+
+	if _, err := m.watchlist(name); err == nil {
+		return false, nil
+	}
+
+	m.watchlists = append(m.watchlists, newWatchlist(name))
+	return true, nil
+}
+
+// RenameWatchlist renames a watchlist and returns true if renamed.
+// It returns an error if there is no such watchlist or another watchlist has the new name.
+func (m *Model) RenameWatchlist(oldName, newName string) (renamed bool, err error) {
+	if err := ValidateWatchlistName(newName); err != nil {
+		return false, err
+	}
+
+	w, err := m.watchlist(oldName)
+	if err != nil {
+		return false, err
+	}
+
+	if oldName == newName {
+		return false, nil
+	}
+
+	if _, err := m.watchlist(newName); err == nil {
+		return false, errs.Errorf("watchlist already exists: %q", newName)
+	}
+
+	w.name = newName
+	return true, nil
+}
+
+// RemoveWatchlist removes a watchlist and returns true if removed.
+// Removing the current watchlist shows the one before it. The last watchlist cannot be removed.
+func (m *Model) RemoveWatchlist(name string) (removed bool, err error) {
+	if len(m.watchlists) == 1 {
+		return false, errs.Errorf("cannot remove the last watchlist")
+	}
+
+	for i, w := range m.watchlists {
+		if w.name != name {
+			continue
+		}
+
+		m.watchlists = append(m.watchlists[:i], m.watchlists[i+1:]...)
+
+		if w == m.currentWatchlist {
+			if i > 0 {
+				i--
+			}
+			m.currentWatchlist = m.watchlists[i]
+			m.updateWatchlistStocks(w)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// SetCurrentWatchlist shows the named watchlist in the sidebar and returns true if the watchlist changed.
+func (m *Model) SetCurrentWatchlist(name string) (changed bool, err error) {
+	w, err := m.watchlist(name)
+	if err != nil {
+		return false, err
+	}
+
+	if w == m.currentWatchlist {
+		return false, nil
+	}
+
+	old := m.currentWatchlist
+	m.currentWatchlist = w
+	m.updateWatchlistStocks(old)
+
+	return true, nil
+}
+
+// AddWatchlistSection adds an empty section to the end of the named watchlist and returns true if newly added.
+func (m *Model) AddWatchlistSection(watchlistName, sectionName string) (added bool, err error) {
+	if err := ValidateSectionName(sectionName); err != nil {
+		return false, err
+	}
+
+	w, err := m.watchlist(watchlistName)
+	if err != nil {
+		return false, err
+	}
+
+	if w.section(sectionName) != nil {
+		return false, nil
+	}
+
+	w.sections = append(w.sections, &WatchlistSection{Name: sectionName})
+	return true, nil
+}
+
+// RemoveWatchlistSection removes a titled section of the named watchlist and returns true if removed.
+// The section's symbols move to the end of the section before it.
+func (m *Model) RemoveWatchlistSection(watchlistName, sectionName string) (removed bool, err error) {
+	w, err := m.watchlist(watchlistName)
+	if err != nil {
+		return false, err
+	}
+
+	for i, sec := range w.sections {
+		if i == 0 || sec.Name != sectionName {
+			continue
+		}
+
+		prev := w.sections[i-1]
+		prev.Symbols = append(prev.Symbols, sec.Symbols...)
+		w.sections = append(w.sections[:i], w.sections[i+1:]...)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// SetWatchlistSectionCollapsed collapses or expands a titled section of the named watchlist
+// and returns true if it changed. It returns an error if there is no such watchlist or section.
+func (m *Model) SetWatchlistSectionCollapsed(watchlistName, sectionName string, collapsed bool) (changed bool, err error) {
+	w, err := m.watchlist(watchlistName)
+	if err != nil {
+		return false, err
+	}
+
+	sec := w.section(sectionName)
+	if sec == nil || sec.Name == "" {
+		return false, errs.Errorf("no section named %q", sectionName)
+	}
+
+	if sec.Collapsed == collapsed {
+		return false, nil
+	}
+
+	sec.Collapsed = collapsed
+	return true, nil
+}
+
+// section returns the named section or nil if there is no such section.
+func (w *watchlist) section(name string) *WatchlistSection {
+	for _, sec := range w.sections {
+		if sec.Name == name {
+			return sec
+		}
+	}
+	return nil
+}
+
+// AverageChangePercent returns the average percent change of the symbols with quotes
+// and false if none of them have quotes.
+func (m *Model) AverageChangePercent(symbols []string) (avg float32, ok bool) {
+	var sum float32
+	var n int
+	for _, s := range symbols {
+		if st := m.symbol2Stock[s]; st != nil && st.Quote != nil {
+			sum += st.Quote.ChangePercent
+			n++
+		}
+	}
+
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float32(n), true
+}
+
+// updateWatchlistStocks removes the stocks of the old watchlist that are no longer shown
+// and adds placeholders for the stocks of the current watchlist.
+func (m *Model) updateWatchlistStocks(old *watchlist) {
+	for _, s := range old.symbols() {
+		if !m.containsSymbol(s) {
+			delete(m.symbol2Stock, s)
+		}
+	}
+
+	for _, s := range m.currentWatchlist.symbols() {
+		if m.symbol2Stock[s] == nil {
+			m.symbol2Stock[s] = &Stock{Symbol: s}
+		}
+	}
+}
+
+// watchlist returns the named watchlist or an error if there is no such watchlist.
+func (m *Model) watchlist(name string) (*watchlist, error) {
+	for _, w := range m.watchlists {
+		if w.name == name {
+			return w, nil
+		}
+	}
+	return nil, errs.Errorf("no watchlist named %q", name)
+}
+
+// ComparisonSymbols returns the symbols compared against the current symbol.
+func (m *Model) ComparisonSymbols() []string {
 	var symbols []string
-	for _, s := range m.sidebarSymbols {
+	for _, s := range m.comparisonSymbols {
 		symbols = append(symbols, s)
 	}
 	return symbols
 }
 
-// AddSidebarSymbol adds a symbol to the sidebar and returns true if the stock was newly added.
-func (m *Model) AddSidebarSymbol(symbol string) (added bool, err error) {
+// AddComparisonSymbol adds a symbol to compare against the current symbol and returns true if newly added.
+func (m *Model) AddComparisonSymbol(symbol string) (added bool, err error) {
 	if err := ValidateSymbol(symbol); err != nil {
 		return false, err
 	}
 
-	for _, s := range m.sidebarSymbols {
+	for _, s := range m.comparisonSymbols {
 		if s == symbol {
 			return false, nil
 		}
 	}
 
-	m.sidebarSymbols = append(m.sidebarSymbols, symbol)
+	m.comparisonSymbols = append(m.comparisonSymbols, symbol)
 
 	// Add a stock placeholder for the new symbol if it doesn't exist.
 	if m.symbol2Stock[symbol] == nil {
@@ -261,15 +1039,15 @@ func (m *Model) AddSidebarSymbol(symbol string) (added bool, err error) {
 	return true, nil
 }
 
-// RemoveSidebarSymbol removes a symbol from the sidebar and returns true if removed.
-func (m *Model) RemoveSidebarSymbol(symbol string) (removed bool, err error) {
+// RemoveComparisonSymbol removes a symbol compared against the current symbol and returns true if removed.
+func (m *Model) RemoveComparisonSymbol(symbol string) (removed bool, err error) {
 	if err := ValidateSymbol(symbol); err != nil {
 		return false, err
 	}
 
-	for i, s := range m.sidebarSymbols {
+	for i, s := range m.comparisonSymbols {
 		if s == symbol {
-			m.sidebarSymbols = append(m.sidebarSymbols[:i], m.sidebarSymbols[i+1:]...)
+			m.comparisonSymbols = append(m.comparisonSymbols[:i], m.comparisonSymbols[i+1:]...)
 			if !m.containsSymbol(symbol) {
 				delete(m.symbol2Stock, symbol)
 			}
@@ -280,26 +1058,6 @@ func (m *Model) RemoveSidebarSymbol(symbol string) (removed bool, err error) {
 	return false, nil
 }
 
-// SwapSidebarSlots swaps two sidebar slots.
-func (m *Model) SwapSidebarSlots(i, j int) (swapped bool) {
-	if i < 0 || i >= len(m.sidebarSymbols) {
-		logger.Errorf("slot index i (%d) is out of bounds (%d)", i, len(m.sidebarSymbols))
-		return false
-	}
-
-	if j < 0 || j >= len(m.sidebarSymbols) {
-		logger.Errorf("slot index j (%d) is out of bounds (%d)", j, len(m.sidebarSymbols))
-		return false
-	}
-
-	if i == j {
-		return false
-	}
-
-	m.sidebarSymbols[i], m.sidebarSymbols[j] = m.sidebarSymbols[j], m.sidebarSymbols[i]
-	return true
-}
-
 // Stock returns the stock for the symbol if it is in the model. Nil otherwise.
 func (m *Model) Stock(symbol string) (*Stock, error) {
 	if err := ValidateSymbol(symbol); err != nil {
@@ -363,13 +1121,25 @@ func (m *Model) UpdateStockChart(symbol string, chart *Chart) error {
 	return nil
 }
 
-// containsSymbol return true if the symbol is either the current symbol or in the sidebar.
+// containsSymbol return true if the symbol is the current symbol, in a chart, in the current watchlist, or compared.
 func (m *Model) containsSymbol(symbol string) bool {
 	if m.currentSymbol == symbol {
 		return true
 	}
 
-	for _, s := range m.sidebarSymbols {
+	for _, s := range m.chartSymbols {
+		if s == symbol {
+			return true
+		}
+	}
+
+	for _, s := range m.currentWatchlist.symbols() {
+		if s == symbol {
+			return true
+		}
+	}
+
+	for _, s := range m.comparisonSymbols {
 		if s == symbol {
 			return true
 		}
@@ -378,10 +1148,48 @@ func (m *Model) containsSymbol(symbol string) bool {
 	return false
 }
 
+// ValidateWatchlistName returns an error if the name is empty or has surrounding spaces.
+func ValidateWatchlistName(name string) error {
+	if name == "" {
+		return errs.Errorf("missing watchlist name")
+	}
+
+	if strings.TrimSpace(name) != name {
+		return errs.Errorf("watchlist name has surrounding spaces: %q", name)
+	}
+
+	return nil
+}
+
+// ValidateSectionName returns an error if the name is empty or has surrounding spaces.
+func ValidateSectionName(name string) error {
+	if name == "" {
+		return errs.Errorf("missing section name")
+	}
+
+	if strings.TrimSpace(name) != name {
+		return errs.Errorf("section name has surrounding spaces: %q", name)
+	}
+
+	return nil
+}
+
+// SymbolMarket returns the market where the symbol trades or unspecified if the symbol is invalid.
+func SymbolMarket(symbol string) Market {
+	switch {
+	case validSymbolRegexp.MatchString(symbol):
+		return StockMarket
+	case validCryptoSymbolRegexp.MatchString(symbol):
+		return CryptoMarket
+	default:
+		return MarketUnspecified
+	}
+}
+
 // ValidateSymbol validates a symbol and returns an error if it's invalid.
 func ValidateSymbol(symbol string) error {
-	if !validSymbolRegexp.MatchString(symbol) {
-		return errs.Errorf("bad symbol: got %s, want: %v", symbol, validSymbolRegexp)
+	if SymbolMarket(symbol) == MarketUnspecified {
+		return errs.Errorf("bad symbol: got %s, want: %v or %v", symbol, validSymbolRegexp, validCryptoSymbolRegexp)
 	}
 	return nil
 }
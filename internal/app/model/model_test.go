@@ -188,6 +188,193 @@ func TestSwapSidebarSlots(t *testing.T) {
 	}
 }
 
+func TestWatchlists(t *testing.T) {
+	m := New()
+
+	if diff := cmp.Diff([]string{DefaultWatchlistName}, m.WatchlistNames()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	m.AddSidebarSymbol("SPY")
+	m.AddSidebarSymbol("AAPL")
+
+	added, err := m.AddWatchlist("Leaders")
+	if !added || err != nil {
+		t.Errorf("AddWatchlist should add a new watchlist, got (%t, %v).", added, err)
+	}
+
+	added, err = m.AddWatchlist("Leaders")
+	if added || err != nil {
+		t.Errorf("AddWatchlist should not add an existing watchlist, got (%t, %v).", added, err)
+	}
+
+	if _, err := m.AddWatchlist(" "); err == nil {
+		t.Errorf("AddWatchlist should return an error if given an invalid name.")
+	}
+
+	// The same symbol can be in several watchlists, but only the current watchlist's stocks are kept.
+	m.AddWatchlistSymbol("Leaders", "AAPL")
+	m.AddWatchlistSymbol("Leaders", "NVDA")
+	if st, _ := m.Stock("NVDA"); st != nil {
+		t.Errorf("AddWatchlistSymbol should not insert a Stock for a hidden watchlist.")
+	}
+
+	changed, err := m.SetCurrentWatchlist("Leaders")
+	if !changed || err != nil {
+		t.Errorf("SetCurrentWatchlist should change the watchlist, got (%t, %v).", changed, err)
+	}
+
+	if diff := cmp.Diff([]string{"AAPL", "NVDA"}, m.SidebarSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if st, _ := m.Stock("SPY"); st != nil {
+		t.Errorf("SetCurrentWatchlist should remove the Stocks no longer shown.")
+	}
+	if st, _ := m.Stock("NVDA"); st == nil {
+		t.Errorf("SetCurrentWatchlist should insert the Stocks now shown.")
+	}
+
+	if _, err := m.SetCurrentWatchlist("Missing"); err == nil {
+		t.Errorf("SetCurrentWatchlist should return an error if given a missing watchlist.")
+	}
+
+	if _, err := m.RenameWatchlist("Leaders", DefaultWatchlistName); err == nil {
+		t.Errorf("RenameWatchlist should return an error if the new name is taken.")
+	}
+
+	renamed, err := m.RenameWatchlist("Leaders", "Holdings")
+	if !renamed || err != nil {
+		t.Errorf("RenameWatchlist should rename the watchlist, got (%t, %v).", renamed, err)
+	}
+
+	if diff := cmp.Diff([]string{DefaultWatchlistName, "Holdings"}, m.WatchlistNames()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if got := m.CurrentWatchlistName(); got != "Holdings" {
+		t.Errorf("CurrentWatchlistName() = %q, want %q", got, "Holdings")
+	}
+
+	removed, err := m.RemoveWatchlist("Holdings")
+	if !removed || err != nil {
+		t.Errorf("RemoveWatchlist should remove the watchlist, got (%t, %v).", removed, err)
+	}
+
+	if got := m.CurrentWatchlistName(); got != DefaultWatchlistName {
+		t.Errorf("CurrentWatchlistName() = %q, want %q", got, DefaultWatchlistName)
+	}
+
+	if diff := cmp.Diff([]string{"SPY", "AAPL"}, m.SidebarSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if st, _ := m.Stock("NVDA"); st != nil {
+		t.Errorf("RemoveWatchlist should remove the Stocks no longer shown.")
+	}
+
+	if _, err := m.RemoveWatchlist(DefaultWatchlistName); err == nil {
+		t.Errorf("RemoveWatchlist should return an error when removing the last watchlist.")
+	}
+}
+
+func TestSidebarSections(t *testing.T) {
+	m := New()
+	m.AddSidebarSymbol("SPY")
+
+	added, err := m.AddWatchlistSection(DefaultWatchlistName, "Tech")
+	if !added || err != nil {
+		t.Errorf("AddWatchlistSection should add a new section, got (%t, %v).", added, err)
+	}
+
+	added, err = m.AddWatchlistSection(DefaultWatchlistName, "Tech")
+	if added || err != nil {
+		t.Errorf("AddWatchlistSection should not add an existing section, got (%t, %v).", added, err)
+	}
+
+	if _, err := m.AddWatchlistSection(DefaultWatchlistName, ""); err == nil {
+		t.Errorf("AddWatchlistSection should return an error if given an invalid name.")
+	}
+
+	// New symbols go to the last section.
+	m.AddSidebarSymbol("AAPL")
+	m.AddSidebarSymbol("MSFT")
+	m.AddWatchlistSection(DefaultWatchlistName, "Energy")
+
+	want := []*WatchlistSection{
+		{Symbols: []string{"SPY"}},
+		{Name: "Tech", Symbols: []string{"AAPL", "MSFT"}},
+		{Name: "Energy"},
+	}
+	if diff := cmp.Diff(want, m.SidebarSections()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	// Slots are SPY, Tech, AAPL, MSFT, Energy. Swapping MSFT with the Energy header moves it into Energy.
+	if !m.SwapSidebarSlots(3, 4) {
+		t.Errorf("SwapSidebarSlots should return true when swap succeeds.")
+	}
+
+	// Swapping symbols in different sections exchanges them.
+	if !m.SwapSidebarSlots(0, 2) {
+		t.Errorf("SwapSidebarSlots should return true when swap succeeds.")
+	}
+
+	want = []*WatchlistSection{
+		{Symbols: []string{"AAPL"}},
+		{Name: "Tech", Symbols: []string{"SPY"}},
+		{Name: "Energy", Symbols: []string{"MSFT"}},
+	}
+	if diff := cmp.Diff(want, m.SidebarSections()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	changed, err := m.SetWatchlistSectionCollapsed(DefaultWatchlistName, "Energy", true)
+	if !changed || err != nil {
+		t.Errorf("SetWatchlistSectionCollapsed should collapse the section, got (%t, %v).", changed, err)
+	}
+
+	if _, err := m.SetWatchlistSectionCollapsed(DefaultWatchlistName, "Missing", true); err == nil {
+		t.Errorf("SetWatchlistSectionCollapsed should return an error if given a missing section.")
+	}
+
+	removed, err := m.RemoveWatchlistSection(DefaultWatchlistName, "Tech")
+	if !removed || err != nil {
+		t.Errorf("RemoveWatchlistSection should remove the section, got (%t, %v).", removed, err)
+	}
+
+	want = []*WatchlistSection{
+		{Symbols: []string{"AAPL", "SPY"}},
+		{Name: "Energy", Collapsed: true, Symbols: []string{"MSFT"}},
+	}
+	if diff := cmp.Diff(want, m.SidebarSections()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"AAPL", "SPY", "MSFT"}, m.SidebarSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
+
+func TestAverageChangePercent(t *testing.T) {
+	m := New()
+	for _, s := range []string{"SPY", "AAPL", "MSFT"} {
+		m.AddSidebarSymbol(s)
+	}
+	m.UpdateStockQuote("SPY", &Quote{ChangePercent: 0.01})
+	m.UpdateStockQuote("AAPL", &Quote{ChangePercent: 0.03})
+
+	// MSFT has no quote, so it should not count.
+	avg, ok := m.AverageChangePercent([]string{"SPY", "AAPL", "MSFT"})
+	if !ok || avg != 0.02 {
+		t.Errorf("AverageChangePercent() = (%v, %t), want (0.02, true)", avg, ok)
+	}
+
+	if _, ok := m.AverageChangePercent([]string{"MSFT"}); ok {
+		t.Errorf("AverageChangePercent should return false without quotes.")
+	}
+}
+
 func TestUpdateStockQuote(t *testing.T) {
 	old := now
 	defer func() { now = old }()
@@ -312,6 +499,90 @@ func TestUpdateStockChart(t *testing.T) {
 	}
 }
 
+func TestAddComparisonSymbol(t *testing.T) {
+	m := New()
+
+	if diff := cmp.Diff([]string(nil), m.ComparisonSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	added, err := m.AddComparisonSymbol("XLK")
+	if !added {
+		t.Errorf("AddComparisonSymbol should return true if the input symbol is new.")
+	}
+	if err != nil {
+		t.Errorf("AddComparisonSymbol should not return an error if given a valid symbol.")
+	}
+
+	added, err = m.AddComparisonSymbol("XLK")
+	if added {
+		t.Errorf("AddComparisonSymbol should return false if the input symbol exists.")
+	}
+	if err != nil {
+		t.Errorf("AddComparisonSymbol should not return an error if given a valid symbol.")
+	}
+
+	added, err = m.AddComparisonSymbol("XLK XLK")
+	if added {
+		t.Errorf("AddComparisonSymbol should return false if the input symbol is invalid.")
+	}
+	if err == nil {
+		t.Errorf("AddComparisonSymbol should return an error if the given symbol is invalid.")
+	}
+
+	if diff := cmp.Diff([]string{"XLK"}, m.ComparisonSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if st, _ := m.Stock("XLK"); st == nil {
+		t.Errorf("AddComparisonSymbol should insert the new Stock.")
+	}
+}
+
+func TestRemoveComparisonSymbol(t *testing.T) {
+	m := New()
+
+	m.AddComparisonSymbol("SPY")
+	m.AddComparisonSymbol("XLK")
+	m.AddSidebarSymbol("XLK")
+
+	removed, err := m.RemoveComparisonSymbol("XLK")
+	if !removed {
+		t.Errorf("RemoveComparisonSymbol should return true if the input symbol is compared.")
+	}
+	if err != nil {
+		t.Errorf("RemoveComparisonSymbol should not return an error if the given symbol is valid.")
+	}
+
+	if st, _ := m.Stock("XLK"); st == nil {
+		t.Errorf("RemoveComparisonSymbol should keep the Stock still in the sidebar.")
+	}
+
+	removed, err = m.RemoveComparisonSymbol("SPY")
+	if !removed {
+		t.Errorf("RemoveComparisonSymbol should return true if the input symbol is compared.")
+	}
+	if err != nil {
+		t.Errorf("RemoveComparisonSymbol should not return an error if the given symbol is valid.")
+	}
+
+	if st, _ := m.Stock("SPY"); st != nil {
+		t.Errorf("RemoveComparisonSymbol should remove the unused Stock.")
+	}
+
+	removed, err = m.RemoveComparisonSymbol("FB")
+	if removed {
+		t.Errorf("RemoveComparisonSymbol should return false if the input symbol is not compared.")
+	}
+	if err != nil {
+		t.Errorf("RemoveComparisonSymbol should not return an error if the given symbol is valid.")
+	}
+
+	if diff := cmp.Diff([]string(nil), m.ComparisonSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
+
 func TestStockBookkeeping(t *testing.T) {
 	m := New()
 	if st, _ := m.Stock("SPY"); st != nil {
@@ -337,6 +608,24 @@ func TestStockBookkeeping(t *testing.T) {
 	if st, _ := m.Stock("FB"); st != nil {
 		t.Error("RemoveSidebarSymbol should remove the unused Stock.")
 	}
+
+	m.SetChartSymbols([]string{"QQQ", "XLK"})
+	if st, _ := m.Stock("XLK"); st == nil {
+		t.Errorf("SetChartSymbols should insert the new Stocks.")
+	}
+
+	m.SetChartSymbols([]string{"QQQ"})
+	if st, _ := m.Stock("XLK"); st != nil {
+		t.Error("SetChartSymbols should remove the unused Stocks.")
+	}
+
+	m.ClearCurrentSymbol()
+	if st, _ := m.Stock("AAPL"); st != nil {
+		t.Error("ClearCurrentSymbol should remove the unused Stock.")
+	}
+	if diff := cmp.Diff("", m.CurrentSymbol()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
 }
 
 func TestContainsSymbol(t *testing.T) {
@@ -369,6 +658,18 @@ func TestContainsSymbol(t *testing.T) {
 	if m.containsSymbol("SPY") {
 		t.Errorf("containsSymbol should return false, since the sidebar no longer has SPY.")
 	}
+
+	m.AddComparisonSymbol("SPY")
+
+	if !m.containsSymbol("SPY") {
+		t.Errorf("containsSymbol should return true, since SPY is now compared.")
+	}
+
+	m.SetChartSymbols([]string{"QQQ"})
+
+	if !m.containsSymbol("QQQ") {
+		t.Errorf("containsSymbol should return true, since a chart now shows QQQ.")
+	}
 }
 
 func TestValidateSymbol(t *testing.T) {
@@ -405,6 +706,19 @@ func TestValidateSymbol(t *testing.T) {
 			input:   "",
 			wantErr: true,
 		},
+		{
+			desc:  "valid crypto symbol",
+			input: "BTCUSD",
+		},
+		{
+			desc:  "valid crypto symbol with stablecoin",
+			input: "ETHUSDT",
+		},
+		{
+			desc:    "unknown crypto quote currency",
+			input:   "BTCEUR",
+			wantErr: true,
+		},
 	} {
 		t.Run(tt.desc, func(t *testing.T) {
 			gotErr := ValidateSymbol(tt.input)
@@ -416,6 +730,38 @@ func TestValidateSymbol(t *testing.T) {
 	}
 }
 
+func TestSymbolMarket(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		input string
+		want  Market
+	}{
+		{
+			desc:  "stock",
+			input: "SPY",
+			want:  StockMarket,
+		},
+		{
+			desc:  "crypto",
+			input: "BTCUSD",
+			want:  CryptoMarket,
+		},
+		{
+			desc:  "invalid",
+			input: "spy",
+			want:  MarketUnspecified,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := SymbolMarket(tt.input)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateChart(t *testing.T) {
 	for _, tt := range []struct {
 		desc    string
@@ -446,3 +792,142 @@ func TestValidateChart(t *testing.T) {
 		})
 	}
 }
+
+func TestVolumeProfile(t *testing.T) {
+	series := func(sessions ...*TradingSession) *TradingSessionSeries {
+		return &TradingSessionSeries{TradingSessions: sessions}
+	}
+
+	for _, tt := range []struct {
+		desc        string
+		input       *TradingSessionSeries
+		bucketCount int
+		want        *VolumeProfile
+	}{
+		{
+			desc:        "nil series",
+			bucketCount: 4,
+		},
+		{
+			desc:        "no buckets",
+			input:       series(&TradingSession{Low: 10, High: 14, Volume: 400}),
+			bucketCount: 0,
+		},
+		{
+			desc:        "no volume",
+			input:       series(&TradingSession{Low: 10, High: 14}),
+			bucketCount: 4,
+		},
+		{
+			desc:        "single price",
+			input:       series(&TradingSession{Low: 10, High: 10, Volume: 100}),
+			bucketCount: 4,
+			want: &VolumeProfile{
+				Buckets: []*VolumeProfileBucket{
+					{Low: 10, High: 10, Volume: 100},
+				},
+			},
+		},
+		{
+			desc: "value area grows from the point of control",
+			input: series(
+				&TradingSession{Low: 10, High: 14, Volume: 400},
+				&TradingSession{Low: 12, High: 13, Volume: 500},
+			),
+			bucketCount: 4,
+			want: &VolumeProfile{
+				Buckets: []*VolumeProfileBucket{
+					{Low: 10, High: 11, Volume: 100},
+					{Low: 11, High: 12, Volume: 100},
+					{Low: 12, High: 13, Volume: 600},
+					{Low: 13, High: 14, Volume: 100},
+				},
+				PointOfControl: 2,
+				ValueAreaLow:   2,
+				ValueAreaHigh:  3,
+			},
+		},
+		{
+			desc: "value area grows away from the bottom",
+			input: series(
+				&TradingSession{Low: 10, High: 14, Volume: 400},
+				&TradingSession{Low: 10, High: 11, Volume: 300},
+			),
+			bucketCount: 4,
+			want: &VolumeProfile{
+				Buckets: []*VolumeProfileBucket{
+					{Low: 10, High: 11, Volume: 400},
+					{Low: 11, High: 12, Volume: 100},
+					{Low: 12, High: 13, Volume: 100},
+					{Low: 13, High: 14, Volume: 100},
+				},
+				PointOfControl: 0,
+				ValueAreaLow:   0,
+				ValueAreaHigh:  1,
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := tt.input.VolumeProfile(tt.bucketCount)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewHigh(t *testing.T) {
+	series := func(highs ...float32) *TradingSessionSeries {
+		ts := &TradingSessionSeries{}
+		for _, h := range highs {
+			ts.TradingSessions = append(ts.TradingSessions, &TradingSession{High: h})
+		}
+		return ts
+	}
+
+	for _, tt := range []struct {
+		desc     string
+		input    *TradingSessionSeries
+		sessions int
+		want     bool
+	}{
+		{
+			desc:     "nil series",
+			sessions: 3,
+		},
+		{
+			desc:     "too few sessions",
+			input:    series(10, 11),
+			sessions: 3,
+		},
+		{
+			desc:     "new high",
+			input:    series(10, 12, 11, 13),
+			sessions: 3,
+			want:     true,
+		},
+		{
+			desc:     "ties an earlier high",
+			input:    series(10, 13, 11, 13),
+			sessions: 3,
+		},
+		{
+			desc:     "earlier high outside the sessions",
+			input:    series(20, 12, 11, 13),
+			sessions: 3,
+			want:     true,
+		},
+		{
+			desc:     "below an earlier high",
+			input:    series(10, 14, 11, 13),
+			sessions: 3,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := tt.input.NewHigh(tt.sessions); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
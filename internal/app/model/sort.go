@@ -0,0 +1,179 @@
+package model
+
+import "sort"
+
+// SortMode is how the sidebar's symbols are ordered.
+type SortMode int
+
+// SortMode values.
+const (
+	SortModeUnspecified SortMode = iota
+
+	// ManualSort keeps the order that the user dragged the symbols into.
+	ManualSort
+
+	// SymbolSort orders the symbols alphabetically.
+	SymbolSort
+
+	// ChangeSort orders the symbols from the highest to the lowest percent change today.
+	ChangeSort
+
+	// HighSort orders the symbols from the closest to the farthest from their 52-week highs.
+	HighSort
+
+	// VolumeRatioSort orders the symbols from the highest to the lowest volume relative to the average volume.
+	VolumeRatioSort
+
+	// RSSort orders the symbols from the highest to the lowest relative strength rating.
+	RSSort
+)
+
+// SortModes are the sort modes in the order to cycle through them.
+var SortModes = []SortMode{ManualSort, SymbolSort, ChangeSort, HighSort, VolumeRatioSort, RSSort}
+
+// String returns the lowercase name of the mode like "symbol".
+func (s SortMode) String() string {
+	switch s {
+	case ManualSort:
+		return "manual"
+	case SymbolSort:
+		return "symbol"
+	case ChangeSort:
+		return "% change"
+	case HighSort:
+		return "52w high"
+	case VolumeRatioSort:
+		return "vol ratio"
+	case RSSort:
+		return "rs"
+	default:
+		return "?"
+	}
+}
+
+// Next returns the sort mode after this one in SortModes.
+func (s SortMode) Next() SortMode {
+	for i, m := range SortModes {
+		if m == s {
+			return SortModes[(i+1)%len(SortModes)]
+		}
+	}
+	return ManualSort
+}
+
+// highSessions is the number of daily sessions in 52 weeks.
+const highSessions = 252
+
+// SortedSidebarSymbols returns the current watchlist's symbols in the sort mode's order
+// without changing the manual order. Symbols without the data to sort by go last in manual order.
+func (m *Model) SortedSidebarSymbols(mode SortMode, rsRatings map[string]int) []string {
+	symbols := m.SidebarSymbols()
+
+	var value func(symbol string) (float32, bool)
+	switch mode {
+	case SymbolSort:
+		sort.Strings(symbols)
+		return symbols
+
+	case ChangeSort:
+		value = func(symbol string) (float32, bool) {
+			st := m.symbol2Stock[symbol]
+			if st == nil || st.Quote == nil {
+				return 0, false
+			}
+			return st.Quote.ChangePercent, true
+		}
+
+	case HighSort:
+		value = func(symbol string) (float32, bool) {
+			return distanceFromHigh(m.dailyChart(symbol))
+		}
+
+	case VolumeRatioSort:
+		value = func(symbol string) (float32, bool) {
+			return volumeRatio(m.dailyChart(symbol))
+		}
+
+	case RSSort:
+		value = func(symbol string) (float32, bool) {
+			r, ok := rsRatings[symbol]
+			return float32(r), ok && r != 0
+		}
+
+	default:
+		return symbols
+	}
+
+	type sortValue struct {
+		value float32
+		ok    bool
+	}
+
+	values := map[string]sortValue{}
+	for _, s := range symbols {
+		v, ok := value(s)
+		values[s] = sortValue{v, ok}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		vi, vj := values[symbols[i]], values[symbols[j]]
+		if vi.ok != vj.ok {
+			return vi.ok
+		}
+		return vi.value > vj.value
+	})
+	return symbols
+}
+
+// dailyChart returns the symbol's daily chart or nil if it has not loaded.
+func (m *Model) dailyChart(symbol string) *Chart {
+	st := m.symbol2Stock[symbol]
+	if st == nil {
+		return nil
+	}
+	for _, ch := range st.Charts {
+		if ch.Interval == Daily {
+			return ch
+		}
+	}
+	return nil
+}
+
+// distanceFromHigh returns the last close's distance from the highest high of the last 52 weeks
+// as a fraction that is zero at the high and negative below it.
+func distanceFromHigh(ch *Chart) (float32, bool) {
+	if ch == nil || ch.TradingSessionSeries == nil || len(ch.TradingSessionSeries.TradingSessions) == 0 {
+		return 0, false
+	}
+
+	ts := ch.TradingSessionSeries.TradingSessions
+	if len(ts) > highSessions {
+		ts = ts[len(ts)-highSessions:]
+	}
+
+	var high float32
+	for _, s := range ts {
+		if s.High > high {
+			high = s.High
+		}
+	}
+
+	if high == 0 {
+		return 0, false
+	}
+	return (ts[len(ts)-1].Close - high) / high, true
+}
+
+// volumeRatio returns the last session's volume divided by the last average volume.
+func volumeRatio(ch *Chart) (float32, bool) {
+	if ch == nil || ch.TradingSessionSeries == nil || ch.AverageVolumeSeries == nil {
+		return 0, false
+	}
+
+	ts := ch.TradingSessionSeries.TradingSessions
+	av := ch.AverageVolumeSeries.Values
+	if len(ts) == 0 || len(av) == 0 || av[len(av)-1].Value == 0 {
+		return 0, false
+	}
+	return float32(ts[len(ts)-1].Volume) / av[len(av)-1].Value, true
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSortedSidebarSymbols(t *testing.T) {
+	m := New()
+	for _, s := range []string{"SPY", "AAPL", "CEF", "X"} {
+		m.AddSidebarSymbol(s)
+	}
+
+	dailyChart := func(high, close float32, volume int, avgVolume float32) *Chart {
+		return &Chart{
+			Interval: Daily,
+			TradingSessionSeries: &TradingSessionSeries{
+				TradingSessions: []*TradingSession{
+					{High: high, Close: high},
+					{High: close, Close: close, Volume: volume},
+				},
+			},
+			AverageVolumeSeries: &AverageSeries{
+				Values: []*AverageValue{{Value: avgVolume}},
+			},
+		}
+	}
+
+	// X has no data, so it should always be last except when sorted by symbol.
+	for _, u := range []struct {
+		symbol string
+		quote  *Quote
+		chart  *Chart
+	}{
+		{"SPY", &Quote{ChangePercent: 0.01}, dailyChart(100, 90, 100, 100)},
+		{"AAPL", &Quote{ChangePercent: -0.02}, dailyChart(100, 99, 300, 100)},
+		{"CEF", &Quote{ChangePercent: 0.03}, dailyChart(100, 50, 200, 100)},
+	} {
+		if err := m.UpdateStockQuote(u.symbol, u.quote); err != nil {
+			t.Fatalf("UpdateStockQuote returned error: %v", err)
+		}
+		if err := m.UpdateStockChart(u.symbol, u.chart); err != nil {
+			t.Fatalf("UpdateStockChart returned error: %v", err)
+		}
+	}
+
+	rsRatings := map[string]int{"SPY": 80, "AAPL": 95, "CEF": 40}
+
+	for _, tt := range []struct {
+		mode SortMode
+		want []string
+	}{
+		{ManualSort, []string{"SPY", "AAPL", "CEF", "X"}},
+		{SymbolSort, []string{"AAPL", "CEF", "SPY", "X"}},
+		{ChangeSort, []string{"CEF", "SPY", "AAPL", "X"}},
+		{HighSort, []string{"AAPL", "SPY", "CEF", "X"}},
+		{VolumeRatioSort, []string{"AAPL", "CEF", "SPY", "X"}},
+		{RSSort, []string{"AAPL", "SPY", "CEF", "X"}},
+	} {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, m.SortedSidebarSymbols(tt.mode, rsRatings)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+
+	// Sorting should not change the manual order.
+	if diff := cmp.Diff([]string{"SPY", "AAPL", "CEF", "X"}, m.SidebarSymbols()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
+
+func TestSortModeNext(t *testing.T) {
+	var got []SortMode
+	for m := ManualSort; len(got) < len(SortModes)+1; m = m.Next() {
+		got = append(got, m)
+	}
+
+	want := append(append([]SortMode(nil), SortModes...), ManualSort)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
@@ -6680,6 +6680,20 @@ R3wDK6qyBhXPSSpbSxg6eHn8Iplv5oPEPF39XNY5JTQBAgAA//+yDEtFqgAAAA==
 `,
 	},
 
+	"/data/areabutton.png": {
+		name:    "areabutton.png",
+		local:   "data/areabutton.png",
+		size:    250,
+		modtime: 1337,
+		compressed: `
+H4sIAAAAAAAC/+oM8HPn5ZLiYmBg4PX0cAliYGBwAGEONgYGhlWZhfcYGBgOero4hlTcent7t2CLgcCB
+gF/5UdcZGOWliz3mFe5ZPf1BC++NM+XcCgyuL+5dZEhgO8DjwJDEcIah/fF+jgKtf9kMLApsB3gaJBmM
+GRg7GA2YE9gerGVob9urUMd1YGVjPPMOk5LEPwENtw+LH9FnTyj8Y9Cw0NtGwp4l4ZbRfwaD2oUnP25n
+mBfFpXxg8nMhhjSGMwwzGDkcIOalMTAJMAs4MDCyJPzn+iLApdDAwMQBJwyYvzzPYGCseMff7xK6b+OT
+ADUGBgYGT1c/l3VOCU2AAQCMN/fb+gAAAA==
+`,
+	},
+
 	"/data/barbutton.png": {
 		name:    "barbutton.png",
 		local:   "data/barbutton.png",
@@ -6727,6 +6741,61 @@ z/X5vV6BgYGBwdPVz2WdU0ITIAAA//+HYlJ7XQIAAA==
 `,
 	},
 
+	"/data/heikinashibutton.png": {
+		name:    "heikinashibutton.png",
+		local:   "data/heikinashibutton.png",
+		size:    206,
+		modtime: 1337,
+		compressed: `
+H4sIAAAAAAAC/+oM8HPn5ZLiYmBg4PX0cAliYGBwAGEONgYGhlWZhfcYGBimero4hlTcenvbkO+AAgdr
+wLv6TKuvLDO4vkw0sPrB9tet90yPfTnDDcOJ2xlA4EBYQxYjwwaOBzdmeHaLM8BAQybDciMbBgZuBq3k
+Y3LtjDksO/bV3t0nxQyRnsa85dwHBoYa7gt6h5kZDNgT3jh7MiCAVFoFA8MfmGKGK78Y2VRkFgveiH/G
+wMDA4Onq57LOKaEJMABSaLlKzgAAAA==
+`,
+	},
+
+	"/data/linebutton.png": {
+		name:    "linebutton.png",
+		local:   "data/linebutton.png",
+		size:    316,
+		modtime: 1337,
+		compressed: `
+H4sIAAAAAAAC/wA8AcP+iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAABA0lEQVR42u3a
+UQ7EIAgEUO5/6dkLdBMVBGSGxO8yz6htqgEw5mECEIAABCAAAQhAAMPHV9EAgBkAzAAQADEAmAGWw08E
+2Ao/DWA7PBvA6DdBMAMch58A4Ar/OoA7/HSA0Z/DYAYIC/8iQGj4SICQZrLDRwGEN5X5nIxZQdfZ9wKc
+VKvwFQDoFN4D4K0W4U8BIqt8c721Hr0I6Aiw29QpAhKPVrt9Dt+okn+Dt9dzSfhVgMxdPTX8CkDFud4G
+4GZTLcJ7AV75jjgCyGqsNPw/gIpZKQn/BYAOs1J1R4gu/C7A6FtiEABh+FUAYwYwZgBdlRWAAAQgAAEI
+QAACEIAABDB2/ABaoB6h3JNmFQAAAABJRU5ErkJgggMAgfSxaTwBAAA=
+`,
+	},
+
+	"/data/logscalebutton.png": {
+		name:    "logscalebutton.png",
+		local:   "data/logscalebutton.png",
+		size:    293,
+		modtime: 1337,
+		compressed: `
+H4sIAAAAAAAC/wAlAdr+iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAAA7ElEQVR42u3b
+QQrEMAxDUd//0u52YCiUxHYkRYJsuvuPrkoamRk3nzDA/8PfGcAABqA/b5MF+DopgJVJAOyMGqBilABl
+4WwApdEsAG3RDADt4agAY+GIAKPhSABHwlEAjkQjABwPPwkAEz8NABU+DQAZPwUAGT4FAB3fDQAf3wkA
+H94JQBN/EkD2szhVfDUAXXwlAGV8FQBtfAUAdfwuAH38DkAaQCB+FUAmfgVAKr4DQPqGiFx8JYD8HSHJ
+eAMUAFxxTS4NYADN+C8AaQAD6MYbYBEgDGCAewHCAAa4FyD8BmjNAAbwv8MGMMAD6k8ZtLDRKboAAAAA
+SUVORK5CYIIDAEq3tA4lAQAA
+`,
+	},
+
+	"/data/macdbutton.png": {
+		name:    "macdbutton.png",
+		local:   "data/macdbutton.png",
+		size:    151,
+		modtime: 1337,
+		compressed: `
+H4sIAAAAAAAC/+oM8HPn5ZLiYmBg4PX0cAliYGBwAGEONgYGhlWZhfcYGBjiPF0cQypuvb2xkZPBgIPB
+8Xv8rS8rmRozPuQxy0v3MjAwMOw61cvAxKFwYIXGYqY5fNoNYM57xrw2UQcGxnvsJ56xbNjExKHQwMBk
+EcfyYYPIgngRRhYBBgYGhoYs7oY8K2nWqR+0GRgYGDxd/VzWOSU0AQYA5d2T6ZcAAAA=
+`,
+	},
+
 	"/data/refreshbutton.png": {
 		name:    "refreshbutton.png",
 		local:   "data/refreshbutton.png",
@@ -6767,6 +6836,19 @@ RK5CYIIBAAD//+DMCYgSAgAA
 `,
 	},
 
+	"/data/volumeprofilebutton.png": {
+		name:    "volumeprofilebutton.png",
+		local:   "data/volumeprofilebutton.png",
+		size:    185,
+		modtime: 1337,
+		compressed: `
+H4sIAAAAAAAC/+oM8HPn5ZLiYmBg4PX0cAliYGBwAGEONgYGhlWZhfcYGBgaPF0cQypuvb12kJdBgYPZ
+4Vu81H4+h5Ans0oaqwOUjJOLmBkYGBgYGBg+BH9neMETIMjAwMCwgYmBYQ9rgREDAwNDAisDwwrWFyIw
+8TuMEvXMDAwMD/gNmhkYYvaKwhT9YE74w8vAwNBgf4AnTJyBgYGBgUHiDuuTp4J73BY5GzMwMDB4uvq5
+rHNKaAIMAFQf+gO5AAAA
+`,
+	},
+
 	"/data": {
 		name:  "data",
 		local: `data`,
@@ -6779,10 +6861,16 @@ var _escDirs = map[string][]os.FileInfo{
 	"data": {
 		_escData["/data/DejaVuSans.ttf"],
 		_escData["/data/addbutton.png"],
+		_escData["/data/areabutton.png"],
 		_escData["/data/barbutton.png"],
 		_escData["/data/candlestickbutton.png"],
 		_escData["/data/erroricon.png"],
+		_escData["/data/heikinashibutton.png"],
+		_escData["/data/linebutton.png"],
+		_escData["/data/logscalebutton.png"],
+		_escData["/data/macdbutton.png"],
 		_escData["/data/refreshbutton.png"],
 		_escData["/data/removebutton.png"],
+		_escData["/data/volumeprofilebutton.png"],
 	},
 }
@@ -3,6 +3,7 @@ package chart
 import (
 	"image"
 	"math"
+	"time"
 
 	"golang.org/x/image/font/gofont/goregular"
 
@@ -25,6 +26,7 @@ const (
 	chartSectionPadding = 5
 	chartTextPadding    = 20
 	chartVolumePercent  = 0.25
+	chartPanePercent    = 0.15
 )
 
 var (
@@ -44,19 +46,6 @@ var (
 	cursorVertLine  = vao.VertLine(view.LightGray, view.LightGray)
 )
 
-var movingAverageColors = map[model.Interval]map[int]view.Color{
-	model.Daily: {
-		8:   view.Purple,
-		21:  view.Green,
-		50:  view.Red,
-		200: view.White,
-	},
-	model.Weekly: {
-		10: view.Red,
-		40: view.White,
-	},
-}
-
 // PriceStyle is visual style of the chart's prices.
 type PriceStyle int
 
@@ -66,6 +55,20 @@ const (
 	PriceStyleUnspecified PriceStyle = iota
 	Bar
 	Candlestick
+	HeikinAshi
+	Line
+	Area
+)
+
+// PriceScale is how prices map to the Y-axis of the price section.
+type PriceScale int
+
+// PriceScale values.
+//go:generate stringer -type=PriceScale
+const (
+	PriceScaleUnspecified PriceScale = iota
+	LinearScale
+	LogScale
 )
 
 // ZoomChange specifies whether the user has zoomed in or not.
@@ -92,19 +95,42 @@ type Chart struct {
 	priceCursor   *priceCursor
 	priceTimeline *timeline
 
-	movingAverages []*movingAverage
+	overlays []*overlay
+
+	relativeStrength *relativeStrength
+
+	// volumeProfile shows the volume traded at each price level on the right side of the prices.
+	volumeProfile *volumeProfile
+
+	// costBasis shows lines at the prices paid for the user's position.
+	costBasis *costBasis
+
+	// tradeMarkers shows arrows at the bars of the user's trades.
+	tradeMarkers *tradeMarkers
+
+	// drawingLayer shows the user's trendlines, rays, and levels and lets the user edit them with the mouse.
+	drawingLayer *drawingLayer
+
+	// comparison shows percent change lines instead of prices when comparing symbols.
+	comparison *comparison
 
 	volume         *volume
 	volumeLevel    *volumeLevel
 	volumeCursor   *volumeCursor
 	volumeTimeline *timeline
 
+	// panes are the sections between the prices and volume with indicators like the RSI.
+	panes []*paneSection
+
 	priceLegend  *priceLegend
 	volumeLegend *volumeLegend
 
 	timelineAxis   *timelineAxis
 	timelineCursor *timelineCursor
 
+	// linkedCursor marks the sessions that overlap the session hovered in a linked chart.
+	linkedCursor *linkedCursor
+
 	// loadingTextBox renders the loading text shown when loading from a fresh state.
 	loadingTextBox *text.Box
 
@@ -123,8 +149,11 @@ type Chart struct {
 	// fadeIn fades in the data after it loads.
 	fadeIn *animation.Animation
 
-	// showMovingAverages is whether to render the moving averages.
-	showMovingAverages bool
+	// showOverlays is whether to render the overlays like moving averages.
+	showOverlays bool
+
+	// showVolumeProfile is whether to render the volume profile.
+	showVolumeProfile bool
 
 	// bounds is the rect with global coords that should be drawn within.
 	bounds image.Rectangle
@@ -149,14 +178,22 @@ func NewChart(priceStyle PriceStyle) *Chart {
 	return &Chart{
 		frameBubble: rect.NewBubble(chartRounding),
 		header: newHeader(&headerArgs{
-			SymbolQuoteTextRenderer: chartSymbolQuoteTextRenderer,
-			QuotePrinter:            chartQuotePrinter,
-			ShowBarButton:           true,
-			ShowCandlestickButton:   true,
-			ShowRefreshButton:       true,
-			ShowAddButton:           true,
-			Rounding:                chartRounding,
-			Padding:                 chartSectionPadding,
+			SymbolQuoteTextRenderer:  chartSymbolQuoteTextRenderer,
+			QuotePrinter:             chartQuotePrinter,
+			ShowBarButton:            true,
+			ShowCandlestickButton:    true,
+			ShowHeikinAshiButton:     true,
+			ShowLineButton:           true,
+			ShowAreaButton:           true,
+			ShowLogScaleButton:       true,
+			ShowVolumeProfileButton:  true,
+			ShowPosition:             true,
+			ShowMACDButton:           true,
+			ShowRefreshButton:        true,
+			ShowAddButton:            true,
+			ShowMovingAverageToggles: true,
+			Rounding:                 chartRounding,
+			Padding:                  chartSectionPadding,
 		}),
 
 		price:         newPrice(priceStyle),
@@ -164,6 +201,15 @@ func NewChart(priceStyle PriceStyle) *Chart {
 		priceCursor:   new(priceCursor),
 		priceTimeline: newTimeline(view.TransparentLightGray, view.LightGray, view.TransparentGray, view.Gray),
 
+		relativeStrength: new(relativeStrength),
+
+		volumeProfile: new(volumeProfile),
+		costBasis:     new(costBasis),
+		tradeMarkers:  new(tradeMarkers),
+		drawingLayer:  newDrawingLayer(),
+
+		comparison: new(comparison),
+
 		volume:         newVolume(priceStyle),
 		volumeLevel:    newVolumeLevel(),
 		volumeCursor:   new(volumeCursor),
@@ -175,6 +221,8 @@ func NewChart(priceStyle PriceStyle) *Chart {
 		timelineAxis:   new(timelineAxis),
 		timelineCursor: new(timelineCursor),
 
+		linkedCursor: new(linkedCursor),
+
 		loadingTextBox: text.NewBox(chartSymbolQuoteTextRenderer, "LOADING...", text.Padding(chartTextPadding)),
 		errorTextBox:   text.NewBox(chartSymbolQuoteTextRenderer, "ERROR", text.Color(view.Orange), text.Padding(chartTextPadding)),
 		loading:        true,
@@ -218,6 +266,36 @@ type Data struct {
 
 	// Chart is optional chart data. Nil when data hasn't been received yet.
 	Chart *model.Chart
+
+	// MovingAverages are the configured moving averages for the chart's interval.
+	MovingAverages []*MovingAverage
+
+	// ShowMACD is whether to show the MACD pane.
+	ShowMACD bool
+
+	// ShowVolumeProfile is whether to show the volume profile on the right side of the prices.
+	ShowVolumeProfile bool
+
+	// Comparisons are other symbols to compare against the symbol by percent change.
+	Comparisons []*Comparison
+
+	// PriceScale is whether to show prices on a linear or log scale. Unspecified uses the log scale.
+	PriceScale PriceScale
+
+	// RSRating is the relative strength rating from 1 to 99. Zero if unknown.
+	RSRating int
+
+	// AlertTriggered is true if an alert on the symbol triggered and has not been acknowledged.
+	AlertTriggered bool
+
+	// Position is the user's position in the symbol. Nil if the user has no position.
+	Position *Position
+
+	// Trades are the user's trades in the symbol from the journal.
+	Trades []*Trade
+
+	// Drawings are the user's trendlines, rays, and levels on the symbol's prices.
+	Drawings []*Drawing
 }
 
 // SetData sets the data to be shown on the chart.
@@ -236,32 +314,64 @@ func (ch *Chart) SetData(data Data) {
 
 	switch dc.Interval {
 	case model.Intraday:
-		ch.showMovingAverages = false
+		ch.showOverlays = false
 	case model.Daily, model.Weekly:
-		ch.showMovingAverages = true
+		ch.showOverlays = true
 	default:
 		logger.Errorf("bad interval: %v", dc.Interval)
 		return
 	}
 
 	ts := dc.TradingSessionSeries
+	overlaySeriesSet, overlayColors := visibleOverlays(dc.OverlaySeriesSet, data.MovingAverages)
+
+	ch.comparison.SetData(comparisonData{dc.Interval, data.Symbol, ts, data.Comparisons})
 
-	ch.price.SetData(priceData{ts})
-	ch.priceLevel.SetData(priceLevelData{ts})
-	ch.priceCursor.SetData(priceCursorData{ts})
+	// Label the price section with percent changes when comparing symbols.
+	var percentRange *[2]float32
+	var comparisonSeriesSet []*comparisonSeries
+	if ch.comparison.Comparing() {
+		r := ch.comparison.PercentRange()
+		percentRange = &r
+		comparisonSeriesSet = ch.comparison.Series()
+	}
+
+	ch.price.SetData(priceData{ts, data.PriceScale})
+	ch.priceLevel.SetData(priceLevelData{ts, data.PriceScale, percentRange})
+	ch.priceCursor.SetData(priceCursorData{ts, data.PriceScale, percentRange})
 	ch.priceTimeline.SetData(timelineData{dc.Interval, ts})
 
-	if ch.showMovingAverages {
-		for _, ma := range ch.movingAverages {
-			ma.Close()
+	ch.showVolumeProfile = data.ShowVolumeProfile
+	ch.volumeProfile.SetData(volumeProfileData{ts, data.PriceScale})
+	ch.costBasis.SetData(costBasisData{ts, data.PriceScale, data.Position})
+
+	// Hide the trades when comparing, since the prices are replaced by percent changes.
+	trades := data.Trades
+	if ch.comparison.Comparing() {
+		trades = nil
+	}
+	ch.tradeMarkers.SetData(tradeMarkersData{ts, data.PriceScale, trades})
+
+	// Hide the drawings when comparing for the same reason, which also keeps them from being edited.
+	if ch.comparison.Comparing() {
+		ch.drawingLayer.Close()
+	} else {
+		ch.drawingLayer.SetData(drawingLayerData{dc.Interval, ts, data.PriceScale, data.Drawings})
+	}
+
+	if ch.showOverlays {
+		for _, o := range ch.overlays {
+			o.Close()
 		}
 
-		ch.movingAverages = nil
-		for _, ma := range dc.MovingAverageSeriesSet {
-			m := newMovingAverage(movingAverageColors[dc.Interval][ma.Intervals])
-			m.SetData(movingAverageData{ts, ma})
-			ch.movingAverages = append(ch.movingAverages, m)
+		ch.overlays = nil
+		for i, s := range overlaySeriesSet {
+			o := newOverlay(overlayColors[i])
+			o.SetData(overlayData{ts, s, data.PriceScale})
+			ch.overlays = append(ch.overlays, o)
 		}
+
+		ch.relativeStrength.SetData(relativeStrengthData{dc.RelativeStrengthSeries})
 	}
 
 	ch.volume.SetData(volumeData{ts, dc.AverageVolumeSeries})
@@ -269,10 +379,21 @@ func (ch *Chart) SetData(data Data) {
 	ch.volumeCursor.SetData(volumeCursorData{ts})
 	ch.volumeTimeline.SetData(timelineData{dc.Interval, ts})
 
+	for _, p := range ch.panes {
+		p.Close()
+	}
+	ch.panes = paneSections(dc.Interval, ts, visiblePaneSeriesSet(dc.PaneSeriesSet, data.ShowMACD))
+
 	ch.timelineAxis.SetData(timelineAxisData{dc.Interval, ts})
 	ch.timelineCursor.SetData(timelineCursorData{dc.Interval, ts})
+	ch.linkedCursor.SetData(linkedCursorData{dc.Interval, ts})
 
-	ch.priceLegend.SetData(priceLegendData{dc.Interval, ts, dc.MovingAverageSeriesSet})
+	// Show the percent changes in the legend instead of the overlays that are hidden when comparing.
+	legendRelativeStrength := dc.RelativeStrengthSeries
+	if comparisonSeriesSet != nil {
+		overlaySeriesSet, overlayColors, legendRelativeStrength = nil, nil, nil
+	}
+	ch.priceLegend.SetData(priceLegendData{dc.Interval, ts, overlaySeriesSet, overlayColors, legendRelativeStrength, comparisonSeriesSet})
 	ch.volumeLegend.SetData(volumeLegendData{dc.Interval, ts, dc.AverageVolumeSeries})
 }
 
@@ -293,34 +414,59 @@ func (ch *Chart) ProcessInput(input *view.Input) {
 	// Calculate percentage needed for each section.
 	timeLabelsPercent := float32(ch.timelineAxis.MaxLabelSize.Y+chartSectionPadding*2) / float32(r.Dy())
 
-	// Divide up the rectangle into sections.
-	rects := rect.Slice(r, timeLabelsPercent, chartVolumePercent)
+	// Divide up the rectangle into sections with the panes between the prices and volume.
+	percents := []float32{timeLabelsPercent, chartVolumePercent}
+	for range ch.panes {
+		percents = append(percents, chartPanePercent)
+	}
+	rects := rect.Slice(r, percents...)
 
-	pr, vr, tr := rects[2], rects[1], rects[0]
+	pr, vr, tr := rects[len(rects)-1], rects[1], rects[0]
 
-	ch.sectionDividers = []image.Rectangle{vr, tr}
+	// Order the pane rects from top to bottom to match the panes.
+	var panes []image.Rectangle
+	for i := len(rects) - 2; i >= 2; i-- {
+		panes = append(panes, rects[i])
+	}
+
+	ch.sectionDividers = append([]image.Rectangle{vr, tr}, panes...)
 
 	// Pad all the rects.
 	pr = pr.Inset(chartSectionPadding)
 	vr = vr.Inset(chartSectionPadding)
 	tr = tr.Inset(chartSectionPadding)
+	for i := range panes {
+		panes[i] = panes[i].Inset(chartSectionPadding)
+	}
 
 	// Create separate rects for each section's labels shown on the right.
 	plr, vlr := pr, vr
+	paneLabels := append([]image.Rectangle(nil), panes...)
 
 	// Figure out width to trim off on the right of each rect for the labels.
 	maxWidth := ch.priceLevel.MaxLabelSize.X
 	if w := ch.volumeLevel.MaxLabelSize.X; w > maxWidth {
 		maxWidth = w
 	}
+	for _, p := range ch.panes {
+		if w := p.level.MaxLabelSize.X; w > maxWidth {
+			maxWidth = w
+		}
+	}
 
 	// Set left side of label rects.
 	plr.Min.X = pr.Max.X - maxWidth
 	vlr.Min.X = vr.Max.X - maxWidth
+	for i := range paneLabels {
+		paneLabels[i].Min.X = panes[i].Max.X - maxWidth
+	}
 
 	// Trim off the label rects from the main rects.
 	pr.Max.X = plr.Min.X - chartSectionPadding
 	vr.Max.X = vlr.Min.X - chartSectionPadding
+	for i := range panes {
+		panes[i].Max.X = paneLabels[i].Min.X - chartSectionPadding
+	}
 
 	// Time labels and its cursors labels overlap and use the same rect.
 	tr.Max.X = plr.Min.X
@@ -331,17 +477,28 @@ func (ch *Chart) ProcessInput(input *view.Input) {
 	ch.priceCursor.SetBounds(pr, plr)
 	ch.priceTimeline.SetBounds(pr)
 
-	for _, ma := range ch.movingAverages {
-		ma.SetBounds(pr)
+	for _, o := range ch.overlays {
+		o.SetBounds(pr)
 	}
+	ch.relativeStrength.SetBounds(pr)
+	ch.comparison.SetBounds(pr)
+	ch.volumeProfile.SetBounds(pr)
+	ch.costBasis.SetBounds(pr)
+	ch.tradeMarkers.SetBounds(pr)
+	ch.drawingLayer.SetBounds(pr)
 
 	ch.volume.SetBounds(vr)
 	ch.volumeLevel.SetBounds(vr, vlr)
 	ch.volumeCursor.SetBounds(vr, vlr)
 	ch.volumeTimeline.SetBounds(vr)
 
+	for i, p := range ch.panes {
+		p.SetBounds(panes[i], paneLabels[i])
+	}
+
 	ch.timelineAxis.SetBounds(tr)
 	ch.timelineCursor.SetBounds(tr, tlr)
+	ch.linkedCursor.SetBounds(pr, vr)
 
 	ch.priceLegend.SetBounds(pr)
 	ch.volumeLegend.SetBounds(vr)
@@ -349,9 +506,16 @@ func (ch *Chart) ProcessInput(input *view.Input) {
 	ch.priceCursor.ProcessInput(input)
 	ch.volumeCursor.ProcessInput(input)
 	ch.timelineCursor.ProcessInput(input)
+	ch.linkedCursor.ProcessInput(input)
+
+	for _, p := range ch.panes {
+		p.ProcessInput(input)
+	}
 
 	ch.priceLegend.ProcessInput(input)
 	ch.volumeLegend.ProcessInput(input)
+	ch.tradeMarkers.ProcessInput(input)
+	ch.drawingLayer.ProcessInput(input)
 
 	if input.MouseScrolled.In(ch.bounds) && ch.zoomChangeCallback != nil {
 		zoomChange := ZoomChangeUnspecified
@@ -390,6 +554,11 @@ func (ch *Chart) Update() (dirty bool) {
 	if ch.volumeLegend.Update() {
 		dirty = true
 	}
+	for _, p := range ch.panes {
+		if p.Update() {
+			dirty = true
+		}
+	}
 	if ch.loadingTextBox.Update() {
 		dirty = true
 	}
@@ -432,11 +601,24 @@ func (ch *Chart) Render(fudge float32) {
 
 	ch.priceTimeline.Render(fudge)
 	ch.priceLevel.Render(fudge)
-	ch.price.Render(fudge)
-	if ch.showMovingAverages {
-		for _, ma := range ch.movingAverages {
-			ma.Render(fudge)
+	switch {
+	case ch.comparison.Comparing():
+		ch.comparison.Render(fudge)
+
+	default:
+		if ch.showVolumeProfile {
+			ch.volumeProfile.Render(fudge)
+		}
+		ch.costBasis.Render(fudge)
+		ch.price.Render(fudge)
+		if ch.showOverlays {
+			for _, o := range ch.overlays {
+				o.Render(fudge)
+			}
+			ch.relativeStrength.Render(fudge)
 		}
+		ch.tradeMarkers.Render(fudge)
+		ch.drawingLayer.Render(fudge)
 	}
 	ch.priceCursor.Render(fudge)
 
@@ -445,11 +627,43 @@ func (ch *Chart) Render(fudge float32) {
 	ch.volume.Render(fudge)
 	ch.volumeCursor.Render(fudge)
 
+	for _, p := range ch.panes {
+		p.Render(fudge)
+	}
+
 	ch.timelineAxis.Render(fudge)
 	ch.timelineCursor.Render(fudge)
+	ch.linkedCursor.Render(fudge)
 
 	ch.priceLegend.Render(fudge)
 	ch.volumeLegend.Render(fudge)
+	for _, p := range ch.panes {
+		p.RenderLegend(fudge)
+	}
+	ch.tradeMarkers.RenderTooltip(fudge)
+}
+
+// HoveredSpan returns the time span of the session under the mouse pointer like the week of a weekly bar.
+// False if the mouse pointer is not over the chart's sessions.
+func (ch *Chart) HoveredSpan() (start, end time.Time, ok bool) {
+	return ch.linkedCursor.HoveredSpan()
+}
+
+// SetLinkedSpan marks the sessions that overlap the time span of the session hovered in a linked chart
+// of the same stock. Zero times clear the marks.
+func (ch *Chart) SetLinkedSpan(start, end time.Time) {
+	ch.linkedCursor.SetSpan(start, end)
+}
+
+// SetDrawingTool sets the kind of drawing created by dragging over empty space on the prices.
+// Unspecified only lets existing drawings be selected, dragged, and deleted.
+func (ch *Chart) SetDrawingTool(tool DrawingKind) {
+	ch.drawingLayer.SetTool(tool)
+}
+
+// SetDrawingsChangeCallback sets the callback called with all the drawings when one is created, moved, or deleted.
+func (ch *Chart) SetDrawingsChangeCallback(cb func(drawings []*Drawing)) {
+	ch.drawingLayer.SetChangeCallback(cb)
 }
 
 // SetBarButtonClickCallback sets the callback for bar button clicks.
@@ -462,6 +676,36 @@ func (ch *Chart) SetCandlestickButtonClickCallback(cb func()) {
 	ch.header.SetCandlestickButtonClickCallback(cb)
 }
 
+// SetHeikinAshiButtonClickCallback sets the callback for the Heikin-Ashi button clicks.
+func (ch *Chart) SetHeikinAshiButtonClickCallback(cb func()) {
+	ch.header.SetHeikinAshiButtonClickCallback(cb)
+}
+
+// SetLineButtonClickCallback sets the callback for the line button clicks.
+func (ch *Chart) SetLineButtonClickCallback(cb func()) {
+	ch.header.SetLineButtonClickCallback(cb)
+}
+
+// SetAreaButtonClickCallback sets the callback for the area button clicks.
+func (ch *Chart) SetAreaButtonClickCallback(cb func()) {
+	ch.header.SetAreaButtonClickCallback(cb)
+}
+
+// SetLogScaleButtonClickCallback sets the callback for log scale button clicks.
+func (ch *Chart) SetLogScaleButtonClickCallback(cb func()) {
+	ch.header.SetLogScaleButtonClickCallback(cb)
+}
+
+// SetVolumeProfileButtonClickCallback sets the callback for volume profile button clicks.
+func (ch *Chart) SetVolumeProfileButtonClickCallback(cb func()) {
+	ch.header.SetVolumeProfileButtonClickCallback(cb)
+}
+
+// SetMACDButtonClickCallback sets the callback for MACD button clicks.
+func (ch *Chart) SetMACDButtonClickCallback(cb func()) {
+	ch.header.SetMACDButtonClickCallback(cb)
+}
+
 // SetRefreshButtonClickCallback sets the callback for refresh button clicks.
 func (ch *Chart) SetRefreshButtonClickCallback(cb func()) {
 	ch.header.SetRefreshButtonClickCallback(cb)
@@ -472,6 +716,12 @@ func (ch *Chart) SetAddButtonClickCallback(cb func()) {
 	ch.header.SetAddButtonClickCallback(cb)
 }
 
+// SetMovingAverageToggleCallback sets the callback for moving average toggle clicks.
+// The callback receives the index of the moving average in the data's MovingAverages.
+func (ch *Chart) SetMovingAverageToggleCallback(cb func(i int)) {
+	ch.header.SetMovingAverageToggleCallback(cb)
+}
+
 // SetZoomChangeCallback sets the callback for zoom changes.
 func (ch *Chart) SetZoomChangeCallback(cb func(zoomChange ZoomChange)) {
 	ch.zoomChangeCallback = cb
@@ -484,16 +734,28 @@ func (ch *Chart) Close() {
 	ch.priceLevel.Close()
 	ch.priceCursor.Close()
 	ch.priceTimeline.Close()
-	for _, ma := range ch.movingAverages {
-		ma.Close()
+	for _, o := range ch.overlays {
+		o.Close()
 	}
-	ch.movingAverages = nil
+	ch.overlays = nil
+	ch.relativeStrength.Close()
+	ch.comparison.Close()
+	ch.volumeProfile.Close()
+	ch.costBasis.Close()
+	ch.tradeMarkers.Close()
+	ch.drawingLayer.Close()
+	ch.drawingLayer.SetChangeCallback(nil)
 	ch.volume.Close()
 	ch.volumeLevel.Close()
 	ch.volumeCursor.Close()
 	ch.volumeTimeline.Close()
+	for _, p := range ch.panes {
+		p.Close()
+	}
+	ch.panes = nil
 	ch.timelineAxis.Close()
 	ch.timelineCursor.Close()
+	ch.linkedCursor.Close()
 	ch.priceLegend.Close()
 	ch.volumeLegend.Close()
 	ch.zoomChangeCallback = nil
@@ -0,0 +1,277 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
+)
+
+// comparisonSymbolColor is the color of the main symbol's percent change line.
+var comparisonSymbolColor = view.White
+
+// comparisonColors are cycled through to color the compared symbols' percent change lines.
+var comparisonColors = []view.Color{
+	view.Yellow,
+	view.Purple,
+	view.Orange,
+	view.Green,
+	view.Blue,
+}
+
+// Comparison is another symbol's chart to compare against the main symbol by percent change.
+type Comparison struct {
+	// Symbol is the compared symbol.
+	Symbol string
+
+	// Chart is the compared symbol's chart with the same interval as the main chart.
+	Chart *model.Chart
+}
+
+// comparisonSeries is the percent change of a symbol's closes from the shared start date.
+type comparisonSeries struct {
+	// Symbol is the symbol of the series.
+	Symbol string
+
+	// Color is the color of the series' line and legend marker.
+	Color view.Color
+
+	// PercentChanges are fractions like 0.1 for 10% aligned with the main symbol's trading sessions.
+	// NaN where the symbol has no close or the session is before the start date.
+	PercentChanges []float32
+}
+
+// comparison draws the percent change lines of the main symbol and the compared symbols.
+// It replaces the prices in the price section when there is something to compare.
+type comparison struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// series are the main symbol's series followed by the compared symbols' series.
+	series []*comparisonSeries
+
+	// percentRange is the inclusive range from min to max percent change.
+	percentRange [2]float32
+
+	// lines are the VAOs with the percent change lines.
+	lines []*gfx.VAO
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+type comparisonData struct {
+	Interval             model.Interval
+	Symbol               string
+	TradingSessionSeries *model.TradingSessionSeries
+	Comparisons          []*Comparison
+}
+
+func (c *comparison) SetData(data comparisonData) {
+	// Reset everything.
+	c.Close()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil {
+		return
+	}
+
+	series := comparisonSeriesSet(data.Interval, data.Symbol, ts.TradingSessions, data.Comparisons)
+
+	// Bail out if there is nothing to compare against.
+	if len(series) < 2 {
+		return
+	}
+
+	c.series = series
+	c.percentRange = comparisonRange(series)
+
+	for _, s := range series {
+		var yPercentValues []float32
+		for _, v := range s.PercentChanges {
+			yPercentValues = append(yPercentValues, comparisonPercent(c.percentRange, v))
+		}
+		c.lines = append(c.lines, vao.DataLine(yPercentValues, s.Color))
+	}
+
+	c.renderable = true
+}
+
+// Comparing returns true if there are percent change lines to show instead of prices.
+func (c *comparison) Comparing() bool {
+	return c.renderable
+}
+
+// PercentRange returns the range of percent changes to label the price section with.
+func (c *comparison) PercentRange() [2]float32 {
+	return c.percentRange
+}
+
+// Series returns the percent change series to show in the legend.
+func (c *comparison) Series() []*comparisonSeries {
+	return c.series
+}
+
+func (c *comparison) SetBounds(bounds image.Rectangle) {
+	c.bounds = bounds
+}
+
+func (c *comparison) Render(float32) {
+	if !c.renderable {
+		return
+	}
+
+	gfx.SetModelMatrixRect(c.bounds)
+	for _, l := range c.lines {
+		l.Render()
+	}
+}
+
+func (c *comparison) Close() {
+	c.renderable = false
+	for _, l := range c.lines {
+		l.Delete()
+	}
+	c.lines = nil
+	c.series = nil
+}
+
+// comparisonSeriesSet returns the percent changes of the main symbol followed by the compared symbols
+// from the first session where all of them have a close. Compared symbols without data are skipped.
+func comparisonSeriesSet(interval model.Interval, symbol string, ts []*model.TradingSession, comparisons []*Comparison) []*comparisonSeries {
+	// key matches sessions by date or by week, since weeks can start on different days.
+	key := func(t time.Time) string {
+		if interval == model.Weekly {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%d", year, week)
+		}
+		return t.Format("2006-01-02")
+	}
+
+	var symbols []string
+	var closes []map[string]float32
+	for _, c := range comparisons {
+		if c == nil || c.Symbol == symbol || c.Chart == nil || c.Chart.TradingSessionSeries == nil {
+			continue
+		}
+
+		m := map[string]float32{}
+		for _, s := range c.Chart.TradingSessionSeries.TradingSessions {
+			if s.Close > 0 {
+				m[key(s.Date)] = s.Close
+			}
+		}
+
+		symbols = append(symbols, c.Symbol)
+		closes = append(closes, m)
+	}
+
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	// Find the shared start date where every symbol has a close.
+	start := -1
+	for i, s := range ts {
+		if s.Close <= 0 {
+			continue
+		}
+		found := true
+		for _, m := range closes {
+			if m[key(s.Date)] <= 0 {
+				found = false
+				break
+			}
+		}
+		if found {
+			start = i
+			break
+		}
+	}
+
+	if start < 0 {
+		return nil
+	}
+
+	nan := float32(math.NaN())
+
+	percentChanges := func(closeAt func(i int) float32) []float32 {
+		base := closeAt(start)
+		var vs []float32
+		for i := range ts {
+			c := closeAt(i)
+			if i < start || c <= 0 {
+				vs = append(vs, nan)
+				continue
+			}
+			vs = append(vs, c/base-1)
+		}
+		return vs
+	}
+
+	series := []*comparisonSeries{
+		{
+			Symbol: symbol,
+			Color:  comparisonSymbolColor,
+			PercentChanges: percentChanges(func(i int) float32 {
+				return ts[i].Close
+			}),
+		},
+	}
+
+	for j, sym := range symbols {
+		m := closes[j]
+		series = append(series, &comparisonSeries{
+			Symbol: sym,
+			Color:  comparisonColors[j%len(comparisonColors)],
+			PercentChanges: percentChanges(func(i int) float32 {
+				return m[key(ts[i].Date)]
+			}),
+		})
+	}
+
+	return series
+}
+
+// comparisonRange returns the padded range of the percent changes ignoring missing values.
+func comparisonRange(series []*comparisonSeries) [2]float32 {
+	var low float32 = math.MaxFloat32
+	var high float32 = -math.MaxFloat32
+	for _, s := range series {
+		for _, v := range s.PercentChanges {
+			if math.IsNaN(float64(v)) {
+				continue
+			}
+			if v < low {
+				low = v
+			}
+			if v > high {
+				high = v
+			}
+		}
+	}
+
+	if low > high {
+		return [2]float32{0, 0}
+	}
+
+	// Pad the high and low, so the lines have space around them like the prices.
+	padding := (high - low) * .05
+	if padding == 0 {
+		padding = .01
+	}
+	return [2]float32{low - padding, high + padding}
+}
+
+func comparisonPercent(percentRange [2]float32, value float32) float32 {
+	if math.IsNaN(float64(value)) || percentRange[0] == percentRange[1] {
+		return 0
+	}
+	return (value - percentRange[0]) / (percentRange[1] - percentRange[0])
+}
@@ -0,0 +1,604 @@
+package chart
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+)
+
+// drawingHitDistance is how many pixels away from a drawing the mouse can be to select or drag it.
+const drawingHitDistance = 6
+
+// rayLength is how many times longer than the distance between its points a ray is drawn,
+// so that it reaches the edge of the prices after clipping.
+const rayLength = 1000
+
+var (
+	drawingColor         = view.Orange
+	selectedDrawingColor = view.White
+)
+
+// DrawingKind is the kind of drawing.
+type DrawingKind int
+
+// DrawingKind values.
+const (
+	DrawingKindUnspecified DrawingKind = iota
+
+	// TrendlineDrawing is a line segment between two points.
+	TrendlineDrawing
+
+	// RayDrawing is a line that starts at one point and extends through another to the edge of the prices.
+	RayDrawing
+
+	// LevelDrawing is a horizontal line across the prices at a price.
+	LevelDrawing
+)
+
+// DrawingPoint is a point anchored to a date and a price, so it stays put when the interval or size changes.
+type DrawingPoint struct {
+	// Date is the time of the point, which can fall between the start times of sessions.
+	Date time.Time
+
+	// Price is the price of the point.
+	Price float32
+}
+
+// Drawing is a line the user drew on the prices.
+type Drawing struct {
+	// Kind is the kind of drawing.
+	Kind DrawingKind
+
+	// Start is where trendlines and rays start. Levels are only at the start's price.
+	Start DrawingPoint
+
+	// End is where trendlines end and rays pass through. Unused by levels.
+	End DrawingPoint
+}
+
+// drawingHandle is the part of a drawing that the mouse grabbed.
+type drawingHandle int
+
+// drawingHandle values.
+const (
+	drawingHandleUnspecified drawingHandle = iota
+
+	// startHandle moves the start point.
+	startHandle
+
+	// endHandle moves the end point.
+	endHandle
+
+	// wholeDrawing moves both points.
+	wholeDrawing
+)
+
+// drawingDrag is a drag in progress that creates or moves a drawing.
+type drawingDrag struct {
+	// i is the index of the dragged drawing.
+	i int
+
+	// handle is the part of the drawing being dragged.
+	handle drawingHandle
+
+	// pressedPos is where the mouse was pressed to start the drag.
+	pressedPos image.Point
+
+	// original is the drawing before the drag, so moves are relative to where it started.
+	original Drawing
+}
+
+// drawingLayer draws the user's trendlines, rays, and levels on the prices
+// and lets the user create, select, drag, and delete them with the mouse.
+type drawingLayer struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// data is the data necessary to render.
+	data drawingLayerData
+
+	// priceRange is the range of prices that the prices are drawn within.
+	priceRange [2]float32
+
+	// drawings are the drawings in the order they were drawn, so later ones are on top.
+	drawings []*Drawing
+
+	// tool is the kind of drawing created by dragging over empty space. Unspecified to not create drawings.
+	tool DrawingKind
+
+	// selected is the index of the selected drawing. -1 if nothing is selected.
+	selected int
+
+	// drag is the drag in progress. Nil if nothing is being dragged.
+	drag *drawingDrag
+
+	// lines is the VAO with the drawings' lines. Nil if there are no lines.
+	lines *gfx.VAO
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+
+	// changeCallback is called with all the drawings when one is created, moved, or deleted.
+	changeCallback func(drawings []*Drawing)
+}
+
+type drawingLayerData struct {
+	Interval             model.Interval
+	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
+	Drawings             []*Drawing
+}
+
+func newDrawingLayer() *drawingLayer {
+	return &drawingLayer{selected: -1}
+}
+
+func (l *drawingLayer) SetData(data drawingLayerData) {
+	// Keep the drawings being dragged, since the data's drawings do not have the changes yet.
+	dragging := l.drag != nil && l.renderable
+
+	// Reset everything.
+	l.renderable = false
+	l.deleteLines()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil || len(ts.TradingSessions) == 0 {
+		l.drawings = nil
+		l.selected = -1
+		l.drag = nil
+		return
+	}
+
+	l.data = data
+	l.priceRange = priceRange(ts.TradingSessions)
+
+	if !dragging {
+		l.drawings = nil
+		for _, d := range data.Drawings {
+			d := *d
+			l.drawings = append(l.drawings, &d)
+		}
+
+		if l.selected >= len(l.drawings) {
+			l.selected = -1
+		}
+	}
+
+	l.renderable = true
+	l.updateLines()
+}
+
+func (l *drawingLayer) SetBounds(bounds image.Rectangle) {
+	l.bounds = bounds
+}
+
+// SetTool sets the kind of drawing created by dragging over empty space.
+func (l *drawingLayer) SetTool(tool DrawingKind) {
+	l.tool = tool
+}
+
+func (l *drawingLayer) ProcessInput(input *view.Input) {
+	if !l.renderable || l.bounds.Empty() {
+		return
+	}
+
+	l.processDrag(input)
+
+	// Clicking a drawing selects it and clicking elsewhere on the prices deselects it.
+	if input.MouseLeftButtonClicked.In(l.bounds) {
+		i, _ := l.drawingAt(input.MouseLeftButtonClicked.ReleasedPos.Point)
+		if i != l.selected {
+			l.selected = i
+			l.updateLines()
+		}
+		if i >= 0 {
+			input.ClearMouseInput()
+		}
+	}
+
+	// Backspace deletes the selected drawing.
+	if l.selected >= 0 && input.KeyReleased.GetKey() == view.KeyBackspace {
+		l.drawings = append(l.drawings[:l.selected], l.drawings[l.selected+1:]...)
+		l.selected = -1
+		l.updateLines()
+		input.ClearKeyboardInput()
+		l.fireChangeCallback(input)
+	}
+}
+
+// processDrag creates or moves a drawing while the mouse is dragged from within the prices.
+func (l *drawingLayer) processDrag(input *view.Input) {
+	e := input.MouseLeftButtonDragging
+	if e == nil {
+		return
+	}
+
+	if l.drag == nil {
+		if !e.PressedIn(l.bounds) {
+			return
+		}
+
+		l.drag = l.startDrag(e.PressedPos.Point)
+		if l.drag == nil {
+			return
+		}
+	}
+
+	l.moveDrag(e.CurrentPos.Point)
+
+	if e.ReleasedPos != nil {
+		// Drop trendlines and rays without a direction, since they can't be drawn.
+		if d := l.drawings[l.drag.i]; d.Kind != LevelDrawing && d.Start == d.End {
+			l.drawings = append(l.drawings[:l.drag.i], l.drawings[l.drag.i+1:]...)
+			l.selected = -1
+		}
+		l.drag = nil
+		l.fireChangeCallback(input)
+	}
+
+	l.updateLines()
+	input.ClearMouseInput()
+}
+
+// startDrag grabs the drawing under the point or creates one with the tool.
+// Nil if there is nothing to drag.
+func (l *drawingLayer) startDrag(pt image.Point) *drawingDrag {
+	if i, h := l.drawingAt(pt); i >= 0 {
+		l.selected = i
+		return &drawingDrag{
+			i:          i,
+			handle:     h,
+			pressedPos: pt,
+			original:   *l.drawings[i],
+		}
+	}
+
+	if l.tool == DrawingKindUnspecified {
+		return nil
+	}
+
+	p, ok := l.pointAt(float32(pt.X), float32(pt.Y))
+	if !ok {
+		return nil
+	}
+
+	d := &Drawing{Kind: l.tool, Start: p, End: p}
+	l.drawings = append(l.drawings, d)
+	l.selected = len(l.drawings) - 1
+
+	// Levels only have a start, so move it rather than the unused end.
+	h := endHandle
+	if d.Kind == LevelDrawing {
+		h = startHandle
+	}
+
+	return &drawingDrag{
+		i:          l.selected,
+		handle:     h,
+		pressedPos: pt,
+		original:   *d,
+	}
+}
+
+// moveDrag moves the dragged part of the drawing to the point. Moves that would make a price negative are ignored.
+func (l *drawingLayer) moveDrag(pt image.Point) {
+	g := l.drag
+	d := l.drawings[g.i]
+
+	switch g.handle {
+	case startHandle:
+		if p, ok := l.pointAt(float32(pt.X), float32(pt.Y)); ok {
+			d.Start = p
+		}
+
+	case endHandle:
+		if p, ok := l.pointAt(float32(pt.X), float32(pt.Y)); ok {
+			d.End = p
+		}
+
+	case wholeDrawing:
+		delta := pt.Sub(g.pressedPos)
+
+		start, ok := l.movedPoint(g.original.Start, delta)
+		if !ok {
+			return
+		}
+
+		end := g.original.End
+		if d.Kind != LevelDrawing {
+			if end, ok = l.movedPoint(g.original.End, delta); !ok {
+				return
+			}
+		}
+
+		d.Start, d.End = start, end
+	}
+}
+
+// fireChangeCallback fires the change callback with copies of the drawings.
+func (l *drawingLayer) fireChangeCallback(input *view.Input) {
+	var drawings []*Drawing
+	for _, d := range l.drawings {
+		d := *d
+		drawings = append(drawings, &d)
+	}
+
+	input.AddFiredCallback(func() {
+		if l.changeCallback != nil {
+			l.changeCallback(drawings)
+		}
+	})
+}
+
+// drawingAt returns the index of the topmost drawing within the hit distance of the point
+// and the part of it that is there. -1 if there is no drawing there.
+func (l *drawingLayer) drawingAt(pt image.Point) (int, drawingHandle) {
+	near := func(x, y float32) bool {
+		return math.Hypot(float64(x)-float64(pt.X), float64(y)-float64(pt.Y)) <= drawingHitDistance
+	}
+
+	for i := len(l.drawings) - 1; i >= 0; i-- {
+		d := l.drawings[i]
+
+		if d.Kind != LevelDrawing {
+			if x, y := l.pos(d.Start); near(x, y) {
+				return i, startHandle
+			}
+			if x, y := l.pos(d.End); near(x, y) {
+				return i, endHandle
+			}
+		}
+
+		x0, y0, x1, y1, ok := l.segment(d)
+		if !ok {
+			continue
+		}
+
+		dx, dy := float32(l.bounds.Dx()), float32(l.bounds.Dy())
+		minX, minY := float32(l.bounds.Min.X), float32(l.bounds.Min.Y)
+		if segmentDistance(float32(pt.X), float32(pt.Y), minX+x0*dx, minY+y0*dy, minX+x1*dx, minY+y1*dy) <= drawingHitDistance {
+			return i, wholeDrawing
+		}
+	}
+
+	return -1, drawingHandleUnspecified
+}
+
+func (l *drawingLayer) Render(float32) {
+	if !l.renderable || l.lines == nil {
+		return
+	}
+
+	gfx.SetModelMatrixRect(l.bounds)
+	l.lines.Render()
+
+	// Mark the ends of the selected trendline or ray, since they can be dragged.
+	if l.selected < 0 {
+		return
+	}
+
+	d := l.drawings[l.selected]
+	if d.Kind == LevelDrawing {
+		return
+	}
+
+	h := symbol("●", selectedDrawingColor)
+	for _, p := range []DrawingPoint{d.Start, d.End} {
+		x, y := l.pos(p)
+		pt := image.Pt(int(x), int(y))
+		if pt.In(l.bounds) {
+			h.Render(pt.Sub(h.size.Div(2)))
+		}
+	}
+}
+
+func (l *drawingLayer) Close() {
+	l.renderable = false
+	l.drag = nil
+	l.deleteLines()
+}
+
+// SetChangeCallback sets the callback called with all the drawings when one is created, moved, or deleted.
+func (l *drawingLayer) SetChangeCallback(cb func(drawings []*Drawing)) {
+	l.changeCallback = cb
+}
+
+// updateLines recreates the VAO with the drawings' lines.
+func (l *drawingLayer) updateLines() {
+	l.deleteLines()
+
+	var vertices []float32
+	var colors []float32
+	var indices []uint16
+
+	for i, d := range l.drawings {
+		x0, y0, x1, y1, ok := l.segment(d)
+		if !ok {
+			continue
+		}
+
+		color := drawingColor
+		if i == l.selected {
+			color = selectedDrawingColor
+		}
+
+		idx := uint16(len(vertices) / 3)
+		vertices = append(vertices, 2*x0-1, 2*y0-1, 0, 2*x1-1, 2*y1-1, 0)
+		colors = append(colors, color[0], color[1], color[2], color[3], color[0], color[1], color[2], color[3])
+		indices = append(indices, idx, idx+1)
+	}
+
+	if len(vertices) == 0 {
+		return
+	}
+
+	l.lines = gfx.NewVAO(
+		&gfx.VAOVertexData{
+			Mode:     gfx.Lines,
+			Vertices: vertices,
+			Colors:   colors,
+			Indices:  indices,
+		},
+	)
+}
+
+func (l *drawingLayer) deleteLines() {
+	if l.lines != nil {
+		l.lines.Delete()
+		l.lines = nil
+	}
+}
+
+// segment returns the drawing's line in x and y percentages clipped to the bounds. False if it is outside.
+func (l *drawingLayer) segment(d *Drawing) (x0, y0, x1, y1 float32, ok bool) {
+	switch d.Kind {
+	case TrendlineDrawing:
+		return clipSegment(l.xPercent(d.Start.Date), l.yPercent(d.Start.Price), l.xPercent(d.End.Date), l.yPercent(d.End.Price))
+
+	case RayDrawing:
+		x0, y0 := l.xPercent(d.Start.Date), l.yPercent(d.Start.Price)
+		x1, y1 := l.xPercent(d.End.Date), l.yPercent(d.End.Price)
+		return clipSegment(x0, y0, x0+(x1-x0)*rayLength, y0+(y1-y0)*rayLength)
+
+	case LevelDrawing:
+		y := l.yPercent(d.Start.Price)
+		return clipSegment(0, y, 1, y)
+
+	default:
+		return 0, 0, 0, 0, false
+	}
+}
+
+// pos returns the global coords of the point, which can be outside the bounds.
+func (l *drawingLayer) pos(p DrawingPoint) (x, y float32) {
+	x = float32(l.bounds.Min.X) + l.xPercent(p.Date)*float32(l.bounds.Dx())
+	y = float32(l.bounds.Min.Y) + l.yPercent(p.Price)*float32(l.bounds.Dy())
+	return x, y
+}
+
+// pointAt returns the date and price at the global coords. False if the price there is not positive.
+func (l *drawingLayer) pointAt(x, y float32) (DrawingPoint, bool) {
+	xPercent := (x - float32(l.bounds.Min.X)) / float32(l.bounds.Dx())
+	yPercent := (y - float32(l.bounds.Min.Y)) / float32(l.bounds.Dy())
+
+	price := priceValue(l.priceRange, l.data.PriceScale, yPercent)
+	if !(price > 0) || math.IsInf(float64(price), 0) {
+		return DrawingPoint{}, false
+	}
+
+	return DrawingPoint{Date: l.date(xPercent), Price: price}, true
+}
+
+// movedPoint returns the point moved by the pixel delta. False if the moved price is not positive.
+func (l *drawingLayer) movedPoint(p DrawingPoint, delta image.Point) (DrawingPoint, bool) {
+	x, y := l.pos(p)
+	return l.pointAt(x+float32(delta.X), y+float32(delta.Y))
+}
+
+// xPercent returns the x percentage within the bounds of the date.
+// Dates outside the shown sessions are outside [0, 1] as if the first or last session continued.
+func (l *drawingLayer) xPercent(date time.Time) float32 {
+	ts := l.data.TradingSessionSeries.TradingSessions
+
+	// Find the last session starting at or before the date, since sessions have their start dates.
+	i := len(ts) - 1
+	for i > 0 && ts[i].Date.After(date) {
+		i--
+	}
+
+	start, end := ts[i].Date, sessionEnd(ts, i, l.data.Interval)
+	fraction := float64(date.Sub(start)) / float64(end.Sub(start))
+	return float32((float64(i) + fraction) / float64(len(ts)))
+}
+
+// date returns the date at the x percentage within the bounds. It is the inverse of xPercent.
+func (l *drawingLayer) date(xPercent float32) time.Time {
+	ts := l.data.TradingSessionSeries.TradingSessions
+
+	p := float64(xPercent) * float64(len(ts))
+	i := int(math.Floor(p))
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(ts) {
+		i = len(ts) - 1
+	}
+
+	start, end := ts[i].Date, sessionEnd(ts, i, l.data.Interval)
+	return start.Add(time.Duration((p - float64(i)) * float64(end.Sub(start))))
+}
+
+// yPercent returns the y percentage within the bounds of the price.
+// Unlike pricePercent, prices outside the price range are outside [0, 1].
+func (l *drawingLayer) yPercent(price float32) float32 {
+	r := l.priceRange
+	if l.data.PriceScale == LinearScale {
+		return (price - r[0]) / (r[1] - r[0])
+	}
+	return float32((math.Log(float64(price)) - math.Log(float64(r[0]))) / (math.Log(float64(r[1])) - math.Log(float64(r[0]))))
+}
+
+// clipSegment clips the line segment to the unit square using the Liang–Barsky algorithm.
+// False if the segment is entirely outside of it.
+func clipSegment(x0, y0, x1, y1 float32) (cx0, cy0, cx1, cy1 float32, ok bool) {
+	t0, t1 := float32(0), float32(1)
+	dx, dy := x1-x0, y1-y0
+
+	for _, pq := range [][2]float32{
+		{-dx, x0},
+		{dx, 1 - x0},
+		{-dy, y0},
+		{dy, 1 - y0},
+	} {
+		p, q := pq[0], pq[1]
+		if p == 0 {
+			// Parallel to this edge, so it's either entirely inside or outside of it.
+			if q < 0 {
+				return 0, 0, 0, 0, false
+			}
+			continue
+		}
+
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return 0, 0, 0, 0, false
+			}
+			if t > t0 {
+				t0 = t
+			}
+		} else {
+			if t < t0 {
+				return 0, 0, 0, 0, false
+			}
+			if t < t1 {
+				t1 = t
+			}
+		}
+	}
+
+	return x0 + t0*dx, y0 + t0*dy, x0 + t1*dx, y0 + t1*dy, true
+}
+
+// segmentDistance returns the distance from the point (x, y) to the line segment from (x0, y0) to (x1, y1).
+func segmentDistance(x, y, x0, y0, x1, y1 float32) float32 {
+	dx, dy := x1-x0, y1-y0
+
+	t := float32(0)
+	if lengthSquared := dx*dx + dy*dy; lengthSquared != 0 {
+		t = ((x-x0)*dx + (y-y0)*dy) / lengthSquared
+		if t < 0 {
+			t = 0
+		}
+		if t > 1 {
+			t = 1
+		}
+	}
+
+	return float32(math.Hypot(float64(x-(x0+t*dx)), float64(y-(y0+t*dy))))
+}
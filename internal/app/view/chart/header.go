@@ -2,6 +2,7 @@ package chart
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 
 	"github.com/btmura/ponzi2/internal/app/gfx"
@@ -14,12 +15,18 @@ import (
 )
 
 var (
-	addButtonVAO         = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/addbutton.png")))
-	barButtonVAO         = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/barbutton.png")))
-	candlestickButtonVAO = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/candlestickbutton.png")))
-	errorIconVAO         = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/erroricon.png")))
-	refreshButtonVAO     = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/refreshbutton.png")))
-	removeButtonVAO      = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/removebutton.png")))
+	addButtonVAO           = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/addbutton.png")))
+	areaButtonVAO          = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/areabutton.png")))
+	barButtonVAO           = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/barbutton.png")))
+	candlestickButtonVAO   = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/candlestickbutton.png")))
+	errorIconVAO           = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/erroricon.png")))
+	heikinAshiButtonVAO    = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/heikinashibutton.png")))
+	lineButtonVAO          = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/linebutton.png")))
+	logScaleButtonVAO      = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/logscalebutton.png")))
+	macdButtonVAO          = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/macdbutton.png")))
+	refreshButtonVAO       = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/refreshbutton.png")))
+	removeButtonVAO        = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/removebutton.png")))
+	volumeProfileButtonVAO = vao.TexturedSquare(bytes.NewReader(_escFSMustByte(false, "/data/volumeprofilebutton.png")))
 )
 
 // header shows a header for charts and thumbnails with a clickable button.
@@ -33,6 +40,15 @@ type header struct {
 	// quoteColor is the color to render the quote text.
 	quoteColor view.Color
 
+	// showPosition is whether to show the profit or loss of the user's position after the quote.
+	showPosition bool
+
+	// positionText is the text with the profit or loss of the user's position. Empty if no position.
+	positionText string
+
+	// positionColor is the color to render the position text.
+	positionColor view.Color
+
 	// symbolQuoteTextRenderer renders the symbol and quote text.
 	symbolQuoteTextRenderer *gfx.TextRenderer
 
@@ -45,6 +61,31 @@ type header struct {
 	// candlestickButton is the button to show price candlesticks.
 	candlestickButton *headerButton
 
+	// heikinAshiButton is the button to show Heikin-Ashi candlesticks.
+	heikinAshiButton *headerButton
+
+	// lineButton is the button to show a line of closing prices.
+	lineButton *headerButton
+
+	// areaButton is the button to show an area under the closing prices.
+	areaButton *headerButton
+
+	// priceStyle is the current price style used to pick the price style button.
+	priceStyle PriceStyle
+
+	// priceStyleButtons are buttons showing the current price style that switch to the next style when clicked.
+	// Only the button of the current price style is shown.
+	priceStyleButtons map[PriceStyle]*headerButton
+
+	// logScaleButton is the button to switch between a linear and log price scale.
+	logScaleButton *headerButton
+
+	// volumeProfileButton is the button to show or hide the volume profile.
+	volumeProfileButton *headerButton
+
+	// macdButton is the button to show or hide the MACD.
+	macdButton *headerButton
+
 	// refreshButton is the button to refresh the chart.
 	refreshButton *headerButton
 
@@ -54,6 +95,42 @@ type header struct {
 	// removeButton is the button to remove the symbol.
 	removeButton *headerButton
 
+	// movingAverages are the configured moving averages shown as toggles.
+	movingAverages []*MovingAverage
+
+	// showMovingAverageToggles is whether to show the moving average toggles.
+	showMovingAverageToggles bool
+
+	// movingAverageToggleBounds are the bounds of each moving average toggle.
+	movingAverageToggleBounds []image.Rectangle
+
+	// movingAverageToggleCallback is called with the index of the clicked moving average toggle.
+	movingAverageToggleCallback func(i int)
+
+	// rsRating is the relative strength rating to show. Zero if unknown.
+	rsRating int
+
+	// showRSRating is whether to show the relative strength rating.
+	showRSRating bool
+
+	// rsRatingBounds is the bounds of the relative strength rating. Empty if not shown.
+	rsRatingBounds image.Rectangle
+
+	// rsRatingClickCallback is called when the relative strength rating is clicked.
+	rsRatingClickCallback func()
+
+	// alertTriggered is true if an alert triggered and has not been acknowledged.
+	alertTriggered bool
+
+	// showAlertBadge is whether to show a badge when an alert triggered.
+	showAlertBadge bool
+
+	// alertBadgeBounds is the bounds of the alert badge. Empty if not shown.
+	alertBadgeBounds image.Rectangle
+
+	// alertBadgeClickCallback is called when the alert badge is clicked.
+	alertBadgeClickCallback func()
+
 	// rounding is only used to layout the symbol and quote text.
 	rounding int
 
@@ -87,15 +164,26 @@ type headerButton struct {
 
 // headerArgs are passed to newChartHeader.
 type headerArgs struct {
-	SymbolQuoteTextRenderer *gfx.TextRenderer
-	QuotePrinter            func(*model.Quote) string
-	ShowBarButton           bool
-	ShowCandlestickButton   bool
-	ShowRefreshButton       bool
-	ShowAddButton           bool
-	ShowRemoveButton        bool
-	Rounding                int
-	Padding                 int
+	SymbolQuoteTextRenderer  *gfx.TextRenderer
+	QuotePrinter             func(*model.Quote) string
+	ShowBarButton            bool
+	ShowCandlestickButton    bool
+	ShowHeikinAshiButton     bool
+	ShowLineButton           bool
+	ShowAreaButton           bool
+	ShowPriceStyleButton     bool
+	ShowLogScaleButton       bool
+	ShowVolumeProfileButton  bool
+	ShowMACDButton           bool
+	ShowRefreshButton        bool
+	ShowAddButton            bool
+	ShowRemoveButton         bool
+	ShowMovingAverageToggles bool
+	ShowRSRating             bool
+	ShowAlertBadge           bool
+	ShowPosition             bool
+	Rounding                 int
+	Padding                  int
 }
 
 func newHeader(args *headerArgs) *header {
@@ -110,6 +198,37 @@ func newHeader(args *headerArgs) *header {
 			Button:  button.New(candlestickButtonVAO),
 			enabled: args.ShowCandlestickButton,
 		},
+		heikinAshiButton: &headerButton{
+			Button:  button.New(heikinAshiButtonVAO),
+			enabled: args.ShowHeikinAshiButton,
+		},
+		lineButton: &headerButton{
+			Button:  button.New(lineButtonVAO),
+			enabled: args.ShowLineButton,
+		},
+		areaButton: &headerButton{
+			Button:  button.New(areaButtonVAO),
+			enabled: args.ShowAreaButton,
+		},
+		priceStyleButtons: map[PriceStyle]*headerButton{
+			Bar:         {Button: button.New(barButtonVAO), enabled: args.ShowPriceStyleButton},
+			Candlestick: {Button: button.New(candlestickButtonVAO), enabled: args.ShowPriceStyleButton},
+			HeikinAshi:  {Button: button.New(heikinAshiButtonVAO), enabled: args.ShowPriceStyleButton},
+			Line:        {Button: button.New(lineButtonVAO), enabled: args.ShowPriceStyleButton},
+			Area:        {Button: button.New(areaButtonVAO), enabled: args.ShowPriceStyleButton},
+		},
+		logScaleButton: &headerButton{
+			Button:  button.New(logScaleButtonVAO),
+			enabled: args.ShowLogScaleButton,
+		},
+		volumeProfileButton: &headerButton{
+			Button:  button.New(volumeProfileButtonVAO),
+			enabled: args.ShowVolumeProfileButton,
+		},
+		macdButton: &headerButton{
+			Button:  button.New(macdButtonVAO),
+			enabled: args.ShowMACDButton,
+		},
 		refreshButton: &headerButton{
 			Button:  button.New(refreshButtonVAO),
 			enabled: args.ShowRefreshButton,
@@ -122,9 +241,13 @@ func newHeader(args *headerArgs) *header {
 			Button:  button.New(removeButtonVAO),
 			enabled: args.ShowRemoveButton,
 		},
-		rounding: args.Rounding,
-		padding:  args.Padding,
-		fadeIn:   animation.New(1 * view.FPS),
+		showMovingAverageToggles: args.ShowMovingAverageToggles,
+		showRSRating:             args.ShowRSRating,
+		showAlertBadge:           args.ShowAlertBadge,
+		showPosition:             args.ShowPosition,
+		rounding:                 args.Rounding,
+		padding:                  args.Padding,
+		fadeIn:                   animation.New(1 * view.FPS),
 	}
 }
 
@@ -157,8 +280,16 @@ func (h *header) SetData(data Data) {
 
 	h.symbol = data.Symbol
 
+	h.movingAverages = data.MovingAverages
+
+	h.rsRating = data.RSRating
+
+	h.alertTriggered = data.AlertTriggered
+
 	h.quoteText = h.quotePrinter(data.Quote)
 
+	h.positionText, h.positionColor = positionText(data.Position)
+
 	var c float32
 	if q := data.Quote; q != nil {
 		c = q.ChangePercent
@@ -184,6 +315,27 @@ type headerClicks struct {
 	// CandlestickButtonClicked is true if the candlestick button wan clicked.
 	CandlestickButtonClicked bool
 
+	// HeikinAshiButtonClicked is true if the Heikin-Ashi button was clicked.
+	HeikinAshiButtonClicked bool
+
+	// LineButtonClicked is true if the line button was clicked.
+	LineButtonClicked bool
+
+	// AreaButtonClicked is true if the area button was clicked.
+	AreaButtonClicked bool
+
+	// PriceStyleButtonClicked is true if the price style button was clicked.
+	PriceStyleButtonClicked bool
+
+	// LogScaleButtonClicked is true if the log scale button was clicked.
+	LogScaleButtonClicked bool
+
+	// VolumeProfileButtonClicked is true if the volume profile button was clicked.
+	VolumeProfileButtonClicked bool
+
+	// MACDButtonClicked is true if the MACD button was clicked.
+	MACDButtonClicked bool
+
 	// AddButtonClicked is true if the add button was clicked.
 	AddButtonClicked bool
 
@@ -192,15 +344,34 @@ type headerClicks struct {
 
 	// RemoveButtonClicked is true if the remove button was clicked.
 	RemoveButtonClicked bool
+
+	// MovingAverageToggleClicked is true if a moving average toggle was clicked.
+	MovingAverageToggleClicked bool
+
+	// RSRatingClicked is true if the relative strength rating was clicked.
+	RSRatingClicked bool
+
+	// AlertBadgeClicked is true if the alert badge was clicked.
+	AlertBadgeClicked bool
 }
 
 // HasClicks returns true if a clickable part of the header was clicked.
 func (c headerClicks) HasClicks() bool {
 	return c.BarButtonClicked ||
 		c.CandlestickButtonClicked ||
+		c.HeikinAshiButtonClicked ||
+		c.LineButtonClicked ||
+		c.AreaButtonClicked ||
+		c.PriceStyleButtonClicked ||
+		c.LogScaleButtonClicked ||
+		c.VolumeProfileButtonClicked ||
+		c.MACDButtonClicked ||
 		c.AddButtonClicked ||
 		c.RefreshButtonClicked ||
-		c.RemoveButtonClicked
+		c.RemoveButtonClicked ||
+		c.MovingAverageToggleClicked ||
+		c.RSRatingClicked ||
+		c.AlertBadgeClicked
 }
 
 func (h *header) SetBounds(bounds image.Rectangle) {
@@ -221,6 +392,12 @@ func (h *header) ProcessInput(input *view.Input) (body image.Rectangle, clicks h
 		bounds = rect.Translate(bounds, -buttonSize.X, 0)
 	}
 
+	if b := h.priceStyleButtons[h.priceStyle]; b != nil && b.enabled {
+		b.SetBounds(bounds)
+		clicks.PriceStyleButtonClicked = b.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
 	if h.addButton.enabled {
 		h.addButton.SetBounds(bounds)
 		clicks.AddButtonClicked = h.addButton.ProcessInput(input)
@@ -233,6 +410,24 @@ func (h *header) ProcessInput(input *view.Input) (body image.Rectangle, clicks h
 		bounds = rect.Translate(bounds, -buttonSize.X, 0)
 	}
 
+	if h.areaButton.enabled {
+		h.areaButton.SetBounds(bounds)
+		clicks.AreaButtonClicked = h.areaButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	if h.lineButton.enabled {
+		h.lineButton.SetBounds(bounds)
+		clicks.LineButtonClicked = h.lineButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	if h.heikinAshiButton.enabled {
+		h.heikinAshiButton.SetBounds(bounds)
+		clicks.HeikinAshiButtonClicked = h.heikinAshiButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
 	if h.candlestickButton.enabled {
 		h.candlestickButton.SetBounds(bounds)
 		clicks.CandlestickButtonClicked = h.candlestickButton.ProcessInput(input)
@@ -242,6 +437,86 @@ func (h *header) ProcessInput(input *view.Input) (body image.Rectangle, clicks h
 	if h.barButton.enabled {
 		h.barButton.SetBounds(bounds)
 		clicks.BarButtonClicked = h.barButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	if h.macdButton.enabled {
+		h.macdButton.SetBounds(bounds)
+		clicks.MACDButtonClicked = h.macdButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	if h.logScaleButton.enabled {
+		h.logScaleButton.SetBounds(bounds)
+		clicks.LogScaleButtonClicked = h.logScaleButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	if h.volumeProfileButton.enabled {
+		h.volumeProfileButton.SetBounds(bounds)
+		clicks.VolumeProfileButtonClicked = h.volumeProfileButton.ProcessInput(input)
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	if h.hasError {
+		bounds = rect.Translate(bounds, -buttonSize.X, 0)
+	}
+
+	// Layout the alert badge next to the buttons.
+	h.alertBadgeBounds = image.Rectangle{}
+	if h.showAlertBadge && h.alertTriggered {
+		w := h.symbolQuoteTextRenderer.Measure(alertBadgeText).X + h.padding*2
+		h.alertBadgeBounds = image.Rect(bounds.Max.X-w, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		bounds = rect.Translate(bounds, -w, 0)
+
+		if input.MouseLeftButtonClicked.In(h.alertBadgeBounds) {
+			clicks.AlertBadgeClicked = true
+			input.AddFiredCallback(func() {
+				if h.alertBadgeClickCallback != nil {
+					h.alertBadgeClickCallback()
+				}
+			})
+		}
+	}
+
+	// Layout the relative strength rating next to the buttons.
+	h.rsRatingBounds = image.Rectangle{}
+	if h.showRSRating && h.rsRating > 0 {
+		w := h.symbolQuoteTextRenderer.Measure(rsRatingText(h.rsRating)).X + h.padding*2
+		h.rsRatingBounds = image.Rect(bounds.Max.X-w, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		bounds = rect.Translate(bounds, -w, 0)
+
+		if input.MouseLeftButtonClicked.In(h.rsRatingBounds) {
+			clicks.RSRatingClicked = true
+			input.AddFiredCallback(func() {
+				if h.rsRatingClickCallback != nil {
+					h.rsRatingClickCallback()
+				}
+			})
+		}
+	}
+
+	// Layout the moving average toggles from right to left next to the buttons.
+	h.movingAverageToggleBounds = nil
+	if h.showMovingAverageToggles {
+		right := bounds.Max.X
+		h.movingAverageToggleBounds = make([]image.Rectangle, len(h.movingAverages))
+		for i := len(h.movingAverages) - 1; i >= 0; i-- {
+			w := legendTextRenderer.Measure(h.movingAverages[i].Name()).X + h.padding*2
+			b := image.Rect(right-w, bounds.Min.Y, right, bounds.Max.Y)
+			h.movingAverageToggleBounds[i] = b
+			right -= w
+
+			if input.MouseLeftButtonClicked.In(b) {
+				clicks.MovingAverageToggleClicked = true
+				i := i
+				input.AddFiredCallback(func() {
+					if h.movingAverageToggleCallback != nil {
+						h.movingAverageToggleCallback(i)
+					}
+				})
+			}
+		}
 	}
 
 	// Don't report clicks when the refresh button is just an indicator.
@@ -261,6 +536,29 @@ func (h *header) Update() (dirty bool) {
 	if h.candlestickButton.Update() {
 		dirty = true
 	}
+	if h.heikinAshiButton.Update() {
+		dirty = true
+	}
+	if h.lineButton.Update() {
+		dirty = true
+	}
+	if h.areaButton.Update() {
+		dirty = true
+	}
+	for _, b := range h.priceStyleButtons {
+		if b.Update() {
+			dirty = true
+		}
+	}
+	if h.macdButton.Update() {
+		dirty = true
+	}
+	if h.logScaleButton.Update() {
+		dirty = true
+	}
+	if h.volumeProfileButton.Update() {
+		dirty = true
+	}
 	if h.refreshButton.Update() {
 		dirty = true
 	}
@@ -290,6 +588,11 @@ func (h *header) Render(fudge float32) {
 		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
 	}
 
+	if b := h.priceStyleButtons[h.priceStyle]; b != nil && b.enabled {
+		b.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
 	if h.addButton.enabled {
 		h.addButton.Render(fudge)
 		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
@@ -300,6 +603,21 @@ func (h *header) Render(fudge float32) {
 		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
 	}
 
+	if h.areaButton.enabled {
+		h.areaButton.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
+	if h.lineButton.enabled {
+		h.lineButton.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
+	if h.heikinAshiButton.enabled {
+		h.heikinAshiButton.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
 	if h.candlestickButton.enabled {
 		h.candlestickButton.Render(fudge)
 		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
@@ -310,6 +628,21 @@ func (h *header) Render(fudge float32) {
 		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
 	}
 
+	if h.macdButton.enabled {
+		h.macdButton.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
+	if h.logScaleButton.enabled {
+		h.logScaleButton.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
+	if h.volumeProfileButton.enabled {
+		h.volumeProfileButton.Render(fudge)
+		h.bounds = rect.Translate(h.bounds, -buttonSize.X, 0)
+	}
+
 	if h.hasError {
 		gfx.SetModelMatrixRect(h.bounds)
 		errorIconVAO.Render()
@@ -318,6 +651,44 @@ func (h *header) Render(fudge float32) {
 
 	buttonEdge := h.bounds.Min.X + buttonSize.X
 
+	// Render the alert badge to stand out from the quote.
+	if b := h.alertBadgeBounds; !b.Empty() {
+		pt := image.Pt(b.Min.X+h.padding, b.Min.Y+(b.Dy()-h.symbolQuoteTextRenderer.LineHeight())/2)
+		h.symbolQuoteTextRenderer.Render(alertBadgeText, pt, gfx.TextColor(view.Yellow))
+		if b.Min.X < buttonEdge {
+			buttonEdge = b.Min.X
+		}
+	}
+
+	// Render the relative strength rating colored by its strength.
+	if b := h.rsRatingBounds; !b.Empty() {
+		pt := image.Pt(b.Min.X+h.padding, b.Min.Y+(b.Dy()-h.symbolQuoteTextRenderer.LineHeight())/2)
+		h.symbolQuoteTextRenderer.Render(rsRatingText(h.rsRating), pt, gfx.TextColor(rsRatingColor(h.rsRating)))
+		if b.Min.X < buttonEdge {
+			buttonEdge = b.Min.X
+		}
+	}
+
+	// Render the moving average toggles with hidden ones grayed out.
+	for i, b := range h.movingAverageToggleBounds {
+		if i >= len(h.movingAverages) {
+			break
+		}
+
+		ma := h.movingAverages[i]
+		color := view.LightGray
+		if ma.Visible {
+			color = ma.Color
+		}
+
+		pt := image.Pt(b.Min.X+h.padding, b.Min.Y+(b.Dy()-legendTextRenderer.LineHeight())/2)
+		legendTextRenderer.Render(ma.Name(), pt, gfx.TextColor(color))
+
+		if b.Min.X < buttonEdge {
+			buttonEdge = b.Min.X
+		}
+	}
+
 	// Start rendering from the top left. Track position with point.
 	pt := image.Pt(r.Min.X, r.Max.Y)
 	pt.Y -= h.padding + h.symbolQuoteTextRenderer.LineHeight()
@@ -331,6 +702,11 @@ func (h *header) Render(fudge float32) {
 			old := gfx.Alpha()
 			gfx.SetAlpha(old * h.fadeIn.Value(fudge))
 			pt.X += h.symbolQuoteTextRenderer.Render(h.quoteText, pt, gfx.TextColor(h.quoteColor), gfx.TextRenderMaxWidth(w))
+			pt.X += h.padding
+
+			if w := buttonEdge - pt.X; w > 0 && h.showPosition && h.positionText != "" {
+				pt.X += h.symbolQuoteTextRenderer.Render(h.positionText, pt, gfx.TextColor(h.positionColor), gfx.TextRenderMaxWidth(w))
+			}
 			gfx.SetAlpha(old)
 		}
 	}
@@ -346,6 +722,48 @@ func (h *header) SetCandlestickButtonClickCallback(cb func()) {
 	h.candlestickButton.SetClickCallback(cb)
 }
 
+// SetHeikinAshiButtonClickCallback sets the callback for Heikin-Ashi button clicks.
+func (h *header) SetHeikinAshiButtonClickCallback(cb func()) {
+	h.heikinAshiButton.SetClickCallback(cb)
+}
+
+// SetLineButtonClickCallback sets the callback for line button clicks.
+func (h *header) SetLineButtonClickCallback(cb func()) {
+	h.lineButton.SetClickCallback(cb)
+}
+
+// SetAreaButtonClickCallback sets the callback for area button clicks.
+func (h *header) SetAreaButtonClickCallback(cb func()) {
+	h.areaButton.SetClickCallback(cb)
+}
+
+// SetPriceStyle sets the price style that the price style button shows.
+func (h *header) SetPriceStyle(priceStyle PriceStyle) {
+	h.priceStyle = priceStyle
+}
+
+// SetPriceStyleButtonClickCallback sets the callback for price style button clicks.
+func (h *header) SetPriceStyleButtonClickCallback(cb func()) {
+	for _, b := range h.priceStyleButtons {
+		b.SetClickCallback(cb)
+	}
+}
+
+// SetLogScaleButtonClickCallback sets the callback for log scale button clicks.
+func (h *header) SetLogScaleButtonClickCallback(cb func()) {
+	h.logScaleButton.SetClickCallback(cb)
+}
+
+// SetVolumeProfileButtonClickCallback sets the callback for volume profile button clicks.
+func (h *header) SetVolumeProfileButtonClickCallback(cb func()) {
+	h.volumeProfileButton.SetClickCallback(cb)
+}
+
+// SetMACDButtonClickCallback sets the callback for MACD button clicks.
+func (h *header) SetMACDButtonClickCallback(cb func()) {
+	h.macdButton.SetClickCallback(cb)
+}
+
 // SetRefreshButtonClickCallback sets the callback for refresh button clicks.
 func (h *header) SetRefreshButtonClickCallback(cb func()) {
 	h.refreshButton.SetClickCallback(cb)
@@ -361,11 +779,57 @@ func (h *header) SetRemoveButtonClickCallback(cb func()) {
 	h.removeButton.SetClickCallback(cb)
 }
 
+// SetMovingAverageToggleCallback sets the callback for moving average toggle clicks.
+func (h *header) SetMovingAverageToggleCallback(cb func(i int)) {
+	h.movingAverageToggleCallback = cb
+}
+
+// SetRSRatingClickCallback sets the callback for relative strength rating clicks.
+func (h *header) SetRSRatingClickCallback(cb func()) {
+	h.rsRatingClickCallback = cb
+}
+
+// SetAlertBadgeClickCallback sets the callback for alert badge clicks.
+func (h *header) SetAlertBadgeClickCallback(cb func()) {
+	h.alertBadgeClickCallback = cb
+}
+
 // Close frees the resources backing the ChartHeader.
 func (h *header) Close() {
 	h.barButton.Close()
 	h.candlestickButton.Close()
+	h.heikinAshiButton.Close()
+	h.lineButton.Close()
+	h.areaButton.Close()
+	for _, b := range h.priceStyleButtons {
+		b.Close()
+	}
+	h.macdButton.Close()
+	h.logScaleButton.Close()
+	h.volumeProfileButton.Close()
 	h.refreshButton.Close()
 	h.addButton.Close()
 	h.removeButton.Close()
+	h.movingAverageToggleCallback = nil
+	h.rsRatingClickCallback = nil
+	h.alertBadgeClickCallback = nil
+}
+
+// alertBadgeText is the text of the badge shown when an alert triggered.
+const alertBadgeText = "ALERT"
+
+func rsRatingText(rating int) string {
+	return fmt.Sprintf("RS %d", rating)
+}
+
+// rsRatingColor returns green for the strongest ratings and red for the weakest.
+func rsRatingColor(rating int) view.Color {
+	switch {
+	case rating >= 80:
+		return view.Green
+	case rating <= 20:
+		return view.Red
+	default:
+		return view.White
+	}
 }
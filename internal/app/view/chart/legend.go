@@ -8,7 +8,6 @@ import (
 	"golang.org/x/image/font/gofont/goregular"
 
 	"github.com/btmura/ponzi2/internal/app/gfx"
-	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view"
 	"github.com/btmura/ponzi2/internal/app/view/rect"
 )
@@ -121,17 +120,6 @@ func symbolLabel(value, threshold float32) string {
 	return "☒"
 }
 
-func typeLabel(avgType model.AverageType) string {
-	switch avgType {
-	case model.Simple:
-		return "SMA"
-	case model.Exponential:
-		return "EMA"
-	default:
-		return "?"
-	}
-}
-
 func formatFloat(value float32) string {
 	return fmt.Sprintf("%.2f", value)
 }
@@ -156,6 +144,10 @@ func formatWeekday(day time.Weekday) string {
 		return "R"
 	case time.Friday:
 		return "F"
+	case time.Saturday:
+		return "S"
+	case time.Sunday:
+		return "U"
 	}
 	return "?"
 }
@@ -0,0 +1,136 @@
+package chart
+
+import (
+	"image"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
+)
+
+// linkedCursorLine is the line at the edges of the linked sessions.
+var linkedCursorLine = vao.VertLine(view.Yellow, view.Yellow)
+
+// linkedCursor marks the sessions that overlap the session under the mouse pointer in a linked chart
+// of the same stock, like the weekly bar that contains a hovered daily bar.
+type linkedCursor struct {
+	// data is the data necessary to render.
+	data linkedCursorData
+
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// start is the start of the linked time span to mark. Zero if nothing is linked.
+	start time.Time
+
+	// end is the exclusive end of the linked time span to mark.
+	end time.Time
+
+	// priceRect is the rectangle where the price candlesticks are drawn.
+	priceRect image.Rectangle
+
+	// volumeRect is the rectangle where the volume bars are drawn.
+	volumeRect image.Rectangle
+
+	// mousePos is the current mouse position. Nil for no mouse input.
+	mousePos *view.MousePosition
+}
+
+type linkedCursorData struct {
+	Interval             model.Interval
+	TradingSessionSeries *model.TradingSessionSeries
+}
+
+func (l *linkedCursor) SetData(data linkedCursorData) {
+	// Reset everything.
+	l.Close()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil || len(ts.TradingSessions) == 0 {
+		return
+	}
+
+	l.data = data
+	l.renderable = true
+}
+
+func (l *linkedCursor) SetBounds(priceRect, volumeRect image.Rectangle) {
+	l.priceRect = priceRect
+	l.volumeRect = volumeRect
+}
+
+// SetSpan sets the time span of the session hovered in the linked chart. Zero times clear it.
+func (l *linkedCursor) SetSpan(start, end time.Time) {
+	l.start = start
+	l.end = end
+}
+
+// HoveredSpan returns the time span of the session under the mouse pointer. False if no session is hovered.
+func (l *linkedCursor) HoveredSpan() (start, end time.Time, ok bool) {
+	if !l.renderable {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if !l.mousePos.In(l.priceRect) && !l.mousePos.In(l.volumeRect) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	ts := l.data.TradingSessionSeries.TradingSessions
+	i, s := tradingSessionAtX(ts, l.priceRect, l.mousePos.X)
+	return s.Date, sessionEnd(ts, i, l.data.Interval), true
+}
+
+func (l *linkedCursor) ProcessInput(input *view.Input) {
+	l.mousePos = input.MousePos
+}
+
+func (l *linkedCursor) Render(fudge float32) {
+	if !l.renderable || l.start.IsZero() {
+		return
+	}
+
+	ts := l.data.TradingSessionSeries.TradingSessions
+
+	first, last := -1, -1
+	for i, s := range ts {
+		if s.Date.Before(l.end) && sessionEnd(ts, i, l.data.Interval).After(l.start) {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+
+	if first < 0 {
+		return
+	}
+
+	for _, r := range []image.Rectangle{l.priceRect, l.volumeRect} {
+		for _, x := range []int{
+			r.Min.X + r.Dx()*first/len(ts),
+			r.Min.X + r.Dx()*(last+1)/len(ts),
+		} {
+			gfx.SetModelMatrixRect(image.Rect(x, r.Min.Y, x, r.Max.Y))
+			linkedCursorLine.Render()
+		}
+	}
+}
+
+func (l *linkedCursor) Close() {
+	l.renderable = false
+}
+
+// sessionEnd returns when the i-th session ends, which is when the next one starts.
+func sessionEnd(ts []*model.TradingSession, i int, interval model.Interval) time.Time {
+	if i+1 < len(ts) {
+		return ts[i+1].Date
+	}
+
+	if interval == model.Weekly {
+		return ts[i].Date.AddDate(0, 0, 7)
+	}
+	return ts[i].Date.AddDate(0, 0, 1)
+}
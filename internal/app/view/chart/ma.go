@@ -1,75 +1,87 @@
 package chart
 
 import (
-	"image"
+	"fmt"
 
-	"github.com/btmura/ponzi2/internal/app/gfx"
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view"
-	"github.com/btmura/ponzi2/internal/app/view/vao"
+	"github.com/btmura/ponzi2/internal/indicator"
 )
 
-type movingAverage struct {
-	renderable bool
-	color      view.Color
-	line       *gfx.VAO
-	bounds     image.Rectangle
-}
-
-func newMovingAverage(color view.Color) *movingAverage {
-	return &movingAverage{color: color}
-}
+// MovingAverage configures a moving average drawn on top of the prices.
+type MovingAverage struct {
+	// Type is the average type like simple or exponential.
+	Type model.AverageType
 
-type movingAverageData struct {
-	TradingSessionSeries *model.TradingSessionSeries
-	MovingAverageSeries  *model.AverageSeries
-}
+	// Periods is how many days or weeks each value spans.
+	Periods int
 
-func (m *movingAverage) SetData(data movingAverageData) {
-	// Reset everything.
-	m.Close()
+	// Color is the color of the line and its legend symbol.
+	Color view.Color
 
-	// Bail out if there is not enough data yet.
-	ts := data.TradingSessionSeries
-	if ts == nil {
-		return
-	}
+	// Visible is whether the moving average is drawn.
+	Visible bool
+}
 
-	ms := data.MovingAverageSeries
-	if ms == nil {
-		return
+// DefaultMovingAverages returns the moving averages to show when the user hasn't configured any.
+func DefaultMovingAverages(interval model.Interval) []*MovingAverage {
+	switch interval {
+	case model.Daily:
+		return []*MovingAverage{
+			{Type: model.Exponential, Periods: 8, Color: view.Purple, Visible: true},
+			{Type: model.Exponential, Periods: 21, Color: view.Green, Visible: true},
+			{Type: model.Simple, Periods: 50, Color: view.Red, Visible: true},
+			{Type: model.Simple, Periods: 200, Color: view.White, Visible: true},
+		}
+	case model.Weekly:
+		return []*MovingAverage{
+			{Type: model.Simple, Periods: 10, Color: view.Red, Visible: true},
+			{Type: model.Simple, Periods: 40, Color: view.White, Visible: true},
+		}
+	default:
+		return nil
 	}
-
-	yRange := priceRange(ts.TradingSessions)
-
-	m.line = movingAverageDataLine(ms.Values, yRange, m.color)
-
-	m.renderable = true
 }
 
-func (m *movingAverage) SetBounds(bounds image.Rectangle) {
-	m.bounds = bounds
+// Name returns the moving average's legend name like "SMA 50" that matches its series.
+func (m *MovingAverage) Name() string {
+	return fmt.Sprintf("%s %d", indicator.AverageTypeLabel(m.Type), m.Periods)
 }
 
-func (m *movingAverage) Render(float32) {
-	if m.line == nil {
-		return
-	}
-	gfx.SetModelMatrixRect(m.bounds)
-	m.line.Render()
+// overlayFallbackColors are cycled through for overlays that aren't configured moving averages.
+var overlayFallbackColors = []view.Color{
+	view.Yellow,
+	view.Blue,
+	view.Orange,
+	view.Purple,
+	view.Green,
 }
 
-func (m *movingAverage) Close() {
-	m.renderable = false
-	if m.line != nil {
-		m.line.Delete()
+// visibleOverlays returns the overlay series that should be drawn with their colors.
+// Series of hidden moving averages are left out.
+func visibleOverlays(series []*model.IndicatorSeries, mas []*MovingAverage) (visible []*model.IndicatorSeries, colors []view.Color) {
+	name2MovingAverage := map[string]*MovingAverage{}
+	for _, ma := range mas {
+		name2MovingAverage[ma.Name()] = ma
 	}
-}
 
-func movingAverageDataLine(ms []*model.AverageValue, yRange [2]float32, color view.Color) *gfx.VAO {
-	var yPercentValues []float32
-	for _, m := range ms {
-		yPercentValues = append(yPercentValues, pricePercent(yRange, m.Value))
+	var fallbacks int
+	for _, s := range series {
+		ma, ok := name2MovingAverage[s.Name]
+		if ok && !ma.Visible {
+			continue
+		}
+
+		var color view.Color
+		if ok {
+			color = ma.Color
+		} else {
+			color = overlayFallbackColors[fallbacks%len(overlayFallbackColors)]
+			fallbacks++
+		}
+
+		visible = append(visible, s)
+		colors = append(colors, color)
 	}
-	return vao.DataLine(yPercentValues, color)
+	return visible, colors
 }
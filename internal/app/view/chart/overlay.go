@@ -0,0 +1,111 @@
+package chart
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
+)
+
+// overlayBandAlpha is the alpha of the translucent fill between a band's edges.
+const overlayBandAlpha = 0.15
+
+// overlay is a line or band drawn on top of the prices like a moving average or Bollinger Bands.
+type overlay struct {
+	renderable bool
+	color      view.Color
+	line       *gfx.VAO
+	lowerLine  *gfx.VAO
+	band       *gfx.VAO
+	bounds     image.Rectangle
+}
+
+func newOverlay(color view.Color) *overlay {
+	return &overlay{color: color}
+}
+
+type overlayData struct {
+	TradingSessionSeries *model.TradingSessionSeries
+	IndicatorSeries      *model.IndicatorSeries
+	PriceScale           PriceScale
+}
+
+func (m *overlay) SetData(data overlayData) {
+	// Reset everything.
+	m.Close()
+
+	// Bail out if there is not enough data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil {
+		return
+	}
+
+	is := data.IndicatorSeries
+	if is == nil {
+		return
+	}
+
+	yRange := priceRange(ts.TradingSessions)
+
+	m.line = overlayDataLine(is.Values, yRange, data.PriceScale, m.color)
+
+	if is.LowerValues != nil {
+		fillColor := m.color
+		fillColor[3] = overlayBandAlpha
+		m.lowerLine = overlayDataLine(is.LowerValues, yRange, data.PriceScale, m.color)
+		m.band = overlayDataBand(is.Values, is.LowerValues, yRange, data.PriceScale, fillColor)
+	}
+
+	m.renderable = true
+}
+
+func (m *overlay) SetBounds(bounds image.Rectangle) {
+	m.bounds = bounds
+}
+
+func (m *overlay) Render(float32) {
+	if !m.renderable {
+		return
+	}
+	gfx.SetModelMatrixRect(m.bounds)
+	if m.band != nil {
+		m.band.Render()
+	}
+	m.line.Render()
+	if m.lowerLine != nil {
+		m.lowerLine.Render()
+	}
+}
+
+func (m *overlay) Close() {
+	m.renderable = false
+	for _, v := range []*gfx.VAO{m.line, m.lowerLine, m.band} {
+		if v != nil {
+			v.Delete()
+		}
+	}
+	m.line, m.lowerLine, m.band = nil, nil, nil
+}
+
+func overlayDataLine(vs []*model.IndicatorValue, yRange [2]float32, scale PriceScale, color view.Color) *gfx.VAO {
+	var yPercentValues []float32
+	for _, v := range vs {
+		yPercentValues = append(yPercentValues, pricePercent(yRange, scale, v.Value))
+	}
+	return vao.DataLine(yPercentValues, color)
+}
+
+func overlayDataBand(upper, lower []*model.IndicatorValue, yRange [2]float32, scale PriceScale, color view.Color) *gfx.VAO {
+	if len(upper) != len(lower) {
+		return gfx.EmptyVAO()
+	}
+
+	var upperYPercentValues, lowerYPercentValues []float32
+	for i := range upper {
+		upperYPercentValues = append(upperYPercentValues, pricePercent(yRange, scale, upper[i].Value))
+		lowerYPercentValues = append(lowerYPercentValues, pricePercent(yRange, scale, lower[i].Value))
+	}
+	return vao.DataBand(upperYPercentValues, lowerYPercentValues, color)
+}
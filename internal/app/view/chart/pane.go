@@ -0,0 +1,302 @@
+package chart
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
+	"github.com/btmura/ponzi2/internal/indicator"
+)
+
+// paneSeriesColors are cycled through to color the series within a pane.
+var paneSeriesColors = []view.Color{
+	view.Yellow,
+	view.Blue,
+	view.Orange,
+	view.Purple,
+}
+
+// paneSection has the parts of a chart section that shows indicator series like the RSI.
+type paneSection struct {
+	pane     *pane
+	level    *paneLevel
+	cursor   *paneCursor
+	timeline *timeline
+	legend   *paneLegend
+}
+
+func newPaneSection() *paneSection {
+	return &paneSection{
+		pane:     new(pane),
+		level:    new(paneLevel),
+		cursor:   new(paneCursor),
+		timeline: newTimeline(view.TransparentLightGray, view.LightGray, view.TransparentGray, view.Gray),
+		legend:   new(paneLegend),
+	}
+}
+
+// visiblePaneSeriesSet returns the pane series without the MACD series if the MACD is hidden.
+func visiblePaneSeriesSet(series []*model.IndicatorSeries, showMACD bool) []*model.IndicatorSeries {
+	var visible []*model.IndicatorSeries
+	for _, s := range series {
+		if s.Pane == indicator.MACDPane && !showMACD {
+			continue
+		}
+		visible = append(visible, s)
+	}
+	return visible
+}
+
+// paneSections groups the pane series by pane and returns a section for each pane in order.
+func paneSections(interval model.Interval, ts *model.TradingSessionSeries, series []*model.IndicatorSeries) []*paneSection {
+	var names []string
+	name2Series := map[string][]*model.IndicatorSeries{}
+	for _, s := range series {
+		if name2Series[s.Pane] == nil {
+			names = append(names, s.Pane)
+		}
+		name2Series[s.Pane] = append(name2Series[s.Pane], s)
+	}
+
+	var sections []*paneSection
+	for _, n := range names {
+		ss := name2Series[n]
+
+		var colors []view.Color
+		for i := range ss {
+			colors = append(colors, paneSeriesColors[i%len(paneSeriesColors)])
+		}
+
+		p := newPaneSection()
+		p.pane.SetData(paneData{ts, ss, colors})
+		p.level.SetData(paneLevelData{ss})
+		p.cursor.SetData(paneCursorData{ss})
+		p.timeline.SetData(timelineData{interval, ts})
+		p.legend.SetData(paneLegendData{ts, ss, colors})
+		sections = append(sections, p)
+	}
+	return sections
+}
+
+func (p *paneSection) SetBounds(bounds, labelBounds image.Rectangle) {
+	p.pane.SetBounds(bounds)
+	p.level.SetBounds(bounds, labelBounds)
+	p.cursor.SetBounds(bounds, labelBounds)
+	p.timeline.SetBounds(bounds)
+	p.legend.SetBounds(bounds)
+}
+
+func (p *paneSection) ProcessInput(input *view.Input) {
+	p.cursor.ProcessInput(input)
+	p.legend.ProcessInput(input)
+}
+
+func (p *paneSection) Update() (dirty bool) {
+	return p.legend.Update()
+}
+
+// Render renders everything except the legend, so that legends can be rendered on top of everything.
+func (p *paneSection) Render(fudge float32) {
+	p.timeline.Render(fudge)
+	p.level.Render(fudge)
+	p.pane.Render(fudge)
+	p.cursor.Render(fudge)
+}
+
+func (p *paneSection) RenderLegend(fudge float32) {
+	p.legend.Render(fudge)
+}
+
+func (p *paneSection) Close() {
+	p.pane.Close()
+	p.level.Close()
+	p.cursor.Close()
+	p.timeline.Close()
+	p.legend.Close()
+}
+
+// pane renders the lines of indicator series that share a pane.
+type pane struct {
+	// renderable is whether the pane can be rendered.
+	renderable bool
+
+	// lines are the VAOs with the series lines.
+	lines []*gfx.VAO
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+type paneData struct {
+	TradingSessionSeries *model.TradingSessionSeries
+	IndicatorSeriesSet   []*model.IndicatorSeries
+	Colors               []view.Color
+}
+
+func (p *pane) SetData(data paneData) {
+	// Reset everything.
+	p.Close()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil || len(data.IndicatorSeriesSet) == 0 {
+		return
+	}
+
+	yRange := paneRange(data.IndicatorSeriesSet)
+
+	for i, s := range data.IndicatorSeriesSet {
+		// Put histograms first so that the lines are drawn on top of them.
+		if s.Histogram {
+			p.lines = append([]*gfx.VAO{paneHistogramVAO(s.Values, yRange)}, p.lines...)
+			continue
+		}
+		p.lines = append(p.lines, paneDataLine(s.Values, yRange, data.Colors[i]))
+	}
+
+	p.renderable = true
+}
+
+func (p *pane) SetBounds(bounds image.Rectangle) {
+	p.bounds = bounds
+}
+
+func (p *pane) Render(float32) {
+	if !p.renderable {
+		return
+	}
+
+	gfx.SetModelMatrixRect(p.bounds)
+	for _, l := range p.lines {
+		l.Render()
+	}
+}
+
+func (p *pane) Close() {
+	p.renderable = false
+	for _, l := range p.lines {
+		l.Delete()
+	}
+	p.lines = nil
+}
+
+// paneRange returns the fixed range of the series or a range that fits all their values and levels.
+func paneRange(series []*model.IndicatorSeries) [2]float32 {
+	for _, s := range series {
+		if s.Range != [2]float32{} {
+			return s.Range
+		}
+	}
+
+	var r [2]float32
+	first := true
+	add := func(v float32) {
+		if first || v < r[0] {
+			r[0] = v
+		}
+		if first || v > r[1] {
+			r[1] = v
+		}
+		first = false
+	}
+	for _, s := range series {
+		for _, v := range s.Values {
+			add(v.Value)
+		}
+		for _, l := range s.Levels {
+			add(l)
+		}
+	}
+	return r
+}
+
+func panePercent(paneRange [2]float32, value float32) (percent float32) {
+	if paneRange[0] == paneRange[1] {
+		return 0
+	}
+	return (value - paneRange[0]) / (paneRange[1] - paneRange[0])
+}
+
+func paneValue(paneRange [2]float32, percent float32) (value float32) {
+	return paneRange[0] + percent*(paneRange[1]-paneRange[0])
+}
+
+func paneDataLine(vs []*model.IndicatorValue, yRange [2]float32, color view.Color) *gfx.VAO {
+	var yPercentValues []float32
+	for _, v := range vs {
+		yPercentValues = append(yPercentValues, panePercent(yRange, v.Value))
+	}
+	return vao.DataLine(yPercentValues, color)
+}
+
+// paneHistogramColor returns the color of a histogram bar with the given value.
+func paneHistogramColor(value float32) view.Color {
+	switch {
+	case value > 0:
+		return view.Green
+	case value < 0:
+		return view.Red
+	default:
+		return view.White
+	}
+}
+
+// paneHistogramVAO returns a VAO with bars that extend from zero to the values.
+func paneHistogramVAO(vs []*model.IndicatorValue, yRange [2]float32) *gfx.VAO {
+	var vertices []float32
+	var colors []float32
+	var lineIndices []uint16
+
+	dx := 2.0 / float32(len(vs)) // (-1 to 1) on X-axis
+	calcX := func(i int) (centerX float32) {
+		x := -1.0 + dx*float32(i)
+		return x + dx*.5
+	}
+	calcY := func(value float32) float32 {
+		return 2*panePercent(yRange, value) - 1
+	}
+
+	zeroY := calcY(0)
+	for i, v := range vs {
+		if v.Value == 0 {
+			continue
+		}
+
+		centerX := calcX(i)
+
+		// Add the vertices needed to create the histogram bar.
+		idxOffset := len(vertices) / 3
+		vertices = append(vertices,
+			centerX, calcY(v.Value), 0, // 0
+			centerX, zeroY, 0, // 1
+		)
+
+		// Add the colors corresponding to the vertices.
+		c := paneHistogramColor(v.Value)
+		colors = append(colors,
+			c[0], c[1], c[2], c[3], // 0
+			c[0], c[1], c[2], c[3], // 1
+		)
+
+		// idx is function to refer to the vertices above.
+		idx := func(j uint16) uint16 {
+			return uint16(idxOffset) + j
+		}
+
+		// Add the vertex indices to render the bars.
+		lineIndices = append(lineIndices,
+			idx(0), idx(1),
+		)
+	}
+
+	return gfx.NewVAO(
+		&gfx.VAOVertexData{
+			Mode:     gfx.Lines,
+			Vertices: vertices,
+			Colors:   colors,
+			Indices:  lineIndices,
+		},
+	)
+}
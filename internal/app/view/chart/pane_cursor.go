@@ -0,0 +1,74 @@
+package chart
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+)
+
+// paneCursor renders crosshairs at the mouse pointer
+// with the corresponding indicator value on the y-axis.
+type paneCursor struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// paneRange represents the inclusive range from min to max value.
+	paneRange [2]float32
+
+	// paneRect is the rectangle where the series are drawn.
+	paneRect image.Rectangle
+
+	// labelRect is the rectangle where the axis labels are drawn.
+	labelRect image.Rectangle
+
+	// mousePos is the current mouse position. Nil for no mouse input.
+	mousePos *view.MousePosition
+}
+
+type paneCursorData struct {
+	IndicatorSeriesSet []*model.IndicatorSeries
+}
+
+func (p *paneCursor) SetData(data paneCursorData) {
+	// Reset everything.
+	p.Close()
+
+	// Bail out if there is no data yet.
+	if len(data.IndicatorSeriesSet) == 0 {
+		return
+	}
+
+	p.paneRange = paneRange(data.IndicatorSeriesSet)
+
+	p.renderable = true
+}
+
+func (p *paneCursor) SetBounds(paneRect, labelRect image.Rectangle) {
+	p.paneRect = paneRect
+	p.labelRect = labelRect
+}
+
+func (p *paneCursor) ProcessInput(input *view.Input) {
+	p.mousePos = input.MousePos
+}
+
+func (p *paneCursor) Render(fudge float32) {
+	if !p.renderable {
+		return
+	}
+
+	if p.mousePos == nil {
+		return
+	}
+
+	renderCursorLines(p.paneRect, p.mousePos)
+
+	if p.mousePos.In(p.paneRect) {
+		renderPaneLabel(fudge, p.paneRange, p.labelRect, p.mousePos.Point, true)
+	}
+}
+
+func (p *paneCursor) Close() {
+	p.renderable = false
+}
@@ -0,0 +1,152 @@
+package chart
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/rect"
+)
+
+// paneLegend is a bubble that shows the indicator values where the mouse cursor is.
+type paneLegend struct {
+	// data is the data necessary to render.
+	data paneLegendData
+	// bounds is the bounds to draw the paneLegend within.
+	bounds image.Rectangle
+	// mousePos is the current mouse position. Nil for no mouse input.
+	mousePos *view.MousePosition
+
+	// table renders the indicator values of a single trading session.
+	table legendTable
+
+	// needUpdate is true if the table and tableBubble need updating.
+	needUpdate bool
+	// renderable is true if there is something to render.
+	renderable bool
+}
+
+type paneLegendData struct {
+	TradingSessionSeries *model.TradingSessionSeries
+	IndicatorSeriesSet   []*model.IndicatorSeries
+	Colors               []view.Color
+}
+
+func (p *paneLegend) SetData(data paneLegendData) {
+	p.data = data
+	p.needUpdate = true
+}
+
+func (p *paneLegend) SetBounds(bounds image.Rectangle) {
+	if p.bounds == bounds {
+		return
+	}
+	p.bounds = bounds
+	p.needUpdate = true
+}
+
+func (p *paneLegend) ProcessInput(input *view.Input) {
+	if p.mousePos == input.MousePos {
+		return
+	}
+	p.mousePos = input.MousePos
+	p.needUpdate = true
+}
+
+func (p *paneLegend) Update() (dirty bool) {
+	if !p.needUpdate {
+		return false
+	}
+
+	defer func() { p.needUpdate = false }()
+
+	if p.data.TradingSessionSeries == nil {
+		p.renderable = false
+		return true
+	}
+
+	tss := p.data.TradingSessionSeries.TradingSessions
+	if len(tss) == 0 {
+		p.renderable = false
+		return true
+	}
+
+	i := len(tss) - 1
+	if p.mousePos.WithinX(p.bounds) {
+		i, _ = tradingSessionAtX(tss, p.bounds, p.mousePos.X)
+	}
+
+	var rows [][3]legendCell
+	for j, series := range p.data.IndicatorSeriesSet {
+		if len(series.Values) != len(tss) {
+			continue
+		}
+		value := series.Values[i].Value
+		color := p.data.Colors[j]
+		if series.Histogram {
+			color = paneHistogramColor(value)
+		}
+		rows = append(rows, [3]legendCell{
+			symbol("◼", color),
+			legendText(series.Name),
+			legendText(formatFloat(value)),
+		})
+	}
+
+	if len(rows) == 0 {
+		p.renderable = false
+		return true
+	}
+
+	columns := [3]legendColumn{}
+	for i := range rows {
+		for j := range columns {
+			if w := rows[i][j].size.X; w > columns[j].maxWidth {
+				columns[j].maxWidth = w
+			}
+		}
+	}
+
+	tableBounds := image.Rect(
+		0,
+		0,
+		legendTablePadding+columns[0].maxWidth+
+			legendTablePadding+columns[1].maxWidth+
+			legendTablePadding+columns[2].maxWidth+legendTablePadding,
+		legendTablePadding+len(rows)*legendTextRenderer.LineHeight()+legendTablePadding,
+	)
+
+	// Move the table to the upper left.
+	bounds := p.bounds.Inset(legendBubbleMargin)
+	tableBounds = tableBounds.Add(image.Pt(
+		bounds.Min.X,
+		bounds.Max.Y-tableBounds.Dy(),
+	))
+
+	// Move the table to the right if the mouse is in the bounds.
+	if p.mousePos.In(tableBounds) {
+		tableBounds = tableBounds.Add(image.Pt(bounds.Dx()-tableBounds.Dx(), 0))
+	}
+
+	p.table = legendTable{
+		bubble:  rect.NewBubble(legendBubbleRounding),
+		rows:    rows,
+		columns: columns,
+	}
+	p.table.SetBounds(tableBounds)
+	p.renderable = true
+
+	return true
+}
+
+func (p *paneLegend) Render(fudge float32) {
+	if !p.renderable {
+		return
+	}
+
+	p.table.Render(fudge)
+}
+
+func (p *paneLegend) Close() {
+	p.renderable = false
+}
@@ -0,0 +1,140 @@
+package chart
+
+import (
+	"image"
+	"strconv"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
+)
+
+// paneLevelLine is the horizontal guide lines rendered behind the pane's series.
+var paneLevelLine = vao.HorizLine(view.LightGray, view.LightGray)
+
+// paneLevel renders the horizontal guide lines of a pane and their labels.
+type paneLevel struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// paneRange represents the inclusive range from min to max value.
+	paneRange [2]float32
+
+	// levels are the values of the guide lines. The range ends if the series have no levels.
+	levels []float32
+
+	// MaxLabelSize is the maximum label size useful for rendering measurements.
+	MaxLabelSize image.Point
+
+	// lineBounds is the rectangle where the horizontal lines should be drawn within.
+	lineBounds image.Rectangle
+
+	// labelBounds is the rectangle where the labels for the lines should be drawn within.
+	labelBounds image.Rectangle
+}
+
+type paneLevelData struct {
+	IndicatorSeriesSet []*model.IndicatorSeries
+}
+
+func (p *paneLevel) SetData(data paneLevelData) {
+	// Reset everything.
+	p.Close()
+
+	// Bail out if there is no data yet.
+	if len(data.IndicatorSeriesSet) == 0 {
+		return
+	}
+
+	p.paneRange = paneRange(data.IndicatorSeriesSet)
+
+	for _, s := range data.IndicatorSeriesSet {
+		p.levels = append(p.levels, s.Levels...)
+	}
+	if len(p.levels) == 0 {
+		p.levels = []float32{p.paneRange[0], p.paneRange[1]}
+	}
+
+	// Measure the max label size by creating labels with the range ends.
+	for _, v := range p.paneRange {
+		if s := makePaneLabel(v).size; s.X > p.MaxLabelSize.X {
+			p.MaxLabelSize = s
+		}
+	}
+
+	p.renderable = true
+}
+
+func (p *paneLevel) SetBounds(lineBounds, labelBounds image.Rectangle) {
+	p.lineBounds = lineBounds
+	p.labelBounds = labelBounds
+}
+
+func (p *paneLevel) Render(fudge float32) {
+	if !p.renderable {
+		return
+	}
+
+	r := p.lineBounds
+	for _, v := range p.levels {
+		y := r.Min.Y + int(float32(r.Dy())*panePercent(p.paneRange, v))
+		gfx.SetModelMatrixRect(image.Rect(r.Min.X, y, r.Max.X, y))
+		paneLevelLine.Render()
+	}
+
+	r = p.labelBounds
+	for _, v := range p.levels {
+		y := r.Min.Y + int(float32(r.Dy())*panePercent(p.paneRange, v))
+		renderPaneLabel(fudge, p.paneRange, r, image.Pt(0, y), false)
+	}
+}
+
+func (p *paneLevel) Close() {
+	p.renderable = false
+	p.levels = nil
+}
+
+// paneLabel is a right-justified Y-axis label with an indicator value.
+type paneLabel struct {
+	text string
+	size image.Point
+}
+
+func makePaneLabel(v float32) paneLabel {
+	t := strconv.FormatFloat(float64(v), 'f', 2, 32)
+	return paneLabel{
+		text: t,
+		size: axisLabelTextRenderer.Measure(t),
+	}
+}
+
+func renderPaneLabel(fudge float32, paneRange [2]float32, r image.Rectangle, pt image.Point, includeBubble bool) {
+	yPercent := float32(pt.Y-r.Min.Y) / float32(r.Dy())
+	value := paneValue(paneRange, yPercent)
+	label := makePaneLabel(value)
+
+	textPt := image.Point{
+		X: r.Max.X - label.size.X,
+		Y: r.Min.Y + int(float32(r.Dy())*yPercent) - label.size.Y/2,
+	}
+	bubbleRect := image.Rectangle{
+		Min: textPt,
+		Max: textPt.Add(label.size),
+	}.Inset(-axisLabelPadding)
+
+	// Move the label to the left if the mouse is overlapping.
+	if pt.In(bubbleRect) {
+		textPt.X = r.Min.X
+		bubbleRect = image.Rectangle{
+			Min: textPt,
+			Max: textPt.Add(label.size),
+		}.Inset(-axisLabelPadding)
+	}
+
+	if includeBubble {
+		axisLabelBubble.SetBounds(bubbleRect)
+		axisLabelBubble.Render(fudge)
+	}
+	axisLabelTextRenderer.Render(label.text, textPt, gfx.TextColor(view.White))
+}
@@ -0,0 +1,140 @@
+package chart
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/status"
+)
+
+var (
+	// costBasisLotColor is the color of the lines at the prices paid for each lot.
+	costBasisLotColor = view.Color{1, 0.5, 0, 0.4}
+
+	// costBasisAverageColor is the color of the line at the average price paid.
+	costBasisAverageColor = view.Color{1, 0.5, 0, 0.9}
+)
+
+// Position is the user's position in the symbol.
+type Position struct {
+	// PL is the unrealized profit or loss in dollars.
+	PL float32
+
+	// PercentPL is the unrealized profit or loss as a fraction like 0.1 for 10%.
+	PercentPL float32
+
+	// AveragePrice is the average price paid per share.
+	AveragePrice float32
+
+	// LotPrices are the prices paid for each lot.
+	LotPrices []float32
+}
+
+// positionText returns the text and color to show the position's profit or loss. Empty if there is no position.
+func positionText(p *Position) (string, view.Color) {
+	if p == nil {
+		return "", view.White
+	}
+
+	txt := status.PL(p.PL, p.PercentPL)
+	switch {
+	case p.PL > 0:
+		return txt, view.Green
+	case p.PL < 0:
+		return txt, view.Red
+	default:
+		return txt, view.White
+	}
+}
+
+// costBasis draws horizontal lines across the prices at the prices paid for a position.
+type costBasis struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// lines is the VAO with the horizontal lines.
+	lines *gfx.VAO
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+type costBasisData struct {
+	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
+	Position             *Position
+}
+
+func (c *costBasis) SetData(data costBasisData) {
+	// Reset everything.
+	c.Close()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil || data.Position == nil {
+		return
+	}
+
+	// Use the same range as the prices, so the lines line up with the price levels.
+	r := priceRange(ts.TradingSessions)
+
+	var vertices []float32
+	var colors []float32
+	var indices []uint16
+
+	addLine := func(price float32, color view.Color) {
+		// Skip lines outside the prices, since they would be drawn outside the bounds.
+		if price < r[0] || price > r[1] {
+			return
+		}
+
+		y := 2*pricePercent(r, data.PriceScale, price) - 1
+		idx := uint16(len(vertices) / 3)
+		vertices = append(vertices, -1, y, 0, 1, y, 0)
+		colors = append(colors, color[0], color[1], color[2], color[3], color[0], color[1], color[2], color[3])
+		indices = append(indices, idx, idx+1)
+	}
+
+	for _, price := range data.Position.LotPrices {
+		addLine(price, costBasisLotColor)
+	}
+	addLine(data.Position.AveragePrice, costBasisAverageColor)
+
+	if len(vertices) == 0 {
+		return
+	}
+
+	c.lines = gfx.NewVAO(
+		&gfx.VAOVertexData{
+			Mode:     gfx.Lines,
+			Vertices: vertices,
+			Colors:   colors,
+			Indices:  indices,
+		},
+	)
+
+	c.renderable = true
+}
+
+func (c *costBasis) SetBounds(bounds image.Rectangle) {
+	c.bounds = bounds
+}
+
+func (c *costBasis) Render(float32) {
+	if !c.renderable {
+		return
+	}
+
+	gfx.SetModelMatrixRect(c.bounds)
+	c.lines.Render()
+}
+
+func (c *costBasis) Close() {
+	c.renderable = false
+	if c.lines != nil {
+		c.lines.Delete()
+		c.lines = nil
+	}
+}
@@ -7,9 +7,13 @@ import (
 	"github.com/btmura/ponzi2/internal/app/gfx"
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
 	"github.com/btmura/ponzi2/internal/logger"
 )
 
+// priceAreaAlpha is the alpha of the fill under the closing prices at the top of the area.
+const priceAreaAlpha = 0.4
+
 // price shows the candlesticks and price labels for a single stock.
 type price struct {
 	// renderable is whether the prices can be rendered.
@@ -18,10 +22,10 @@ type price struct {
 	// priceRange represents the inclusive range from min to max price.
 	priceRange [2]float32
 
-	// priceStyle is the price style whether bars or candlesticks.
+	// priceStyle is the price style whether bars, candlesticks, Heikin-Ashi candlesticks, lines, or areas.
 	priceStyle PriceStyle
 
-	// faders has the faders needed to fade in and out the bars and candlesticks.
+	// faders has the faders needed to fade in and out each price style.
 	faders map[PriceStyle]*view.Fader
 
 	// barLines is the VAO with the price bar lines.
@@ -33,6 +37,18 @@ type price struct {
 	// stickRects is the VAO with the volume bars.
 	stickRects *gfx.VAO
 
+	// heikinAshiLines is the VAO with the Heikin-Ashi candlestick lines.
+	heikinAshiLines *gfx.VAO
+
+	// heikinAshiRects is the VAO with the Heikin-Ashi candlestick bodies.
+	heikinAshiRects *gfx.VAO
+
+	// closeLine is the VAO with the line of closing prices for the line and area styles.
+	closeLine *gfx.VAO
+
+	// closeArea is the VAO with the fill under the closing prices for the area style.
+	closeArea *gfx.VAO
+
 	// bounds is the rectangle with global coords that should be drawn within.
 	bounds image.Rectangle
 }
@@ -48,11 +64,14 @@ func newPrice(priceStyle PriceStyle) *price {
 		faders: map[PriceStyle]*view.Fader{
 			Bar:         view.NewStoppedFader(1 * view.FPS),
 			Candlestick: view.NewStoppedFader(1 * view.FPS),
+			HeikinAshi:  view.NewStoppedFader(1 * view.FPS),
+			Line:        view.NewStoppedFader(1 * view.FPS),
+			Area:        view.NewStoppedFader(1 * view.FPS),
 		},
 	}
 }
 
-// SetPriceStyle sets the style whether bars or candlesticks.
+// SetPriceStyle sets the style whether bars, candlesticks, Heikin-Ashi candlesticks, lines, or areas.
 func (p *price) SetStyle(newStyle PriceStyle) {
 	if newStyle == PriceStyleUnspecified {
 		logger.Error("unspecified price style")
@@ -68,6 +87,7 @@ func (p *price) SetStyle(newStyle PriceStyle) {
 
 type priceData struct {
 	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
 }
 
 func (p *price) SetData(data priceData) {
@@ -82,9 +102,13 @@ func (p *price) SetData(data priceData) {
 
 	p.priceRange = priceRange(ts.TradingSessions)
 
-	p.barLines = priceBarVAO(ts.TradingSessions, p.priceRange)
+	p.barLines = priceBarVAO(ts.TradingSessions, p.priceRange, data.PriceScale)
 
-	p.stickLines, p.stickRects = priceCandlestickVAOs(ts.TradingSessions, p.priceRange)
+	p.stickLines, p.stickRects = priceCandlestickVAOs(ts.TradingSessions, p.priceRange, data.PriceScale)
+
+	p.heikinAshiLines, p.heikinAshiRects = priceCandlestickVAOs(heikinAshiTradingSessions(ts.TradingSessions), p.priceRange, data.PriceScale)
+
+	p.closeLine, p.closeArea = priceCloseVAOs(ts.TradingSessions, p.priceRange, data.PriceScale)
 
 	p.renderable = true
 }
@@ -126,6 +150,17 @@ func (p *price) Render(fudge float32) {
 			case Candlestick:
 				p.stickLines.Render()
 				p.stickRects.Render()
+
+			case HeikinAshi:
+				p.heikinAshiLines.Render()
+				p.heikinAshiRects.Render()
+
+			case Line:
+				p.closeLine.Render()
+
+			case Area:
+				p.closeArea.Render()
+				p.closeLine.Render()
 			}
 		})
 	}
@@ -142,6 +177,18 @@ func (p *price) Close() {
 	if p.stickRects != nil {
 		p.stickRects.Delete()
 	}
+	if p.heikinAshiLines != nil {
+		p.heikinAshiLines.Delete()
+	}
+	if p.heikinAshiRects != nil {
+		p.heikinAshiRects.Delete()
+	}
+	if p.closeLine != nil {
+		p.closeLine.Delete()
+	}
+	if p.closeArea != nil {
+		p.closeArea.Delete()
+	}
 }
 
 func priceRange(ts []*model.TradingSession) [2]float32 {
@@ -165,14 +212,29 @@ func priceRange(ts []*model.TradingSession) [2]float32 {
 	}
 
 	// Pad the high and low, so the candlesticks have space around them.
+	// Limit the low's padding for big ranges, so the low stays above zero for the log scale.
 	padding := (high - low) * .05
-	low -= padding
+	lowPadding := padding
+	if p := low * .05; p < lowPadding {
+		lowPadding = p
+	}
+	low -= lowPadding
 	high += padding
 
 	return [2]float32{low, high}
 }
 
-func pricePercent(priceRange [2]float32, value float32) (percent float32) {
+// pricePercent returns the percentage from the bottom of the range to the value on the price scale.
+// Any scale other than LinearScale uses the log scale, so equal percent changes span equal distances.
+func pricePercent(priceRange [2]float32, scale PriceScale, value float32) (percent float32) {
+	if scale == LinearScale {
+		percent = (value - priceRange[0]) / (priceRange[1] - priceRange[0])
+		if percent >= 0 {
+			return percent
+		}
+		return 0
+	}
+
 	log := func(value float32) float64 {
 		if value == 0 {
 			return 0
@@ -186,7 +248,12 @@ func pricePercent(priceRange [2]float32, value float32) (percent float32) {
 	return 0
 }
 
-func priceValue(priceRange [2]float32, percent float32) (value float32) {
+// priceValue returns the price at the percentage from the bottom of the range on the price scale.
+func priceValue(priceRange [2]float32, scale PriceScale, percent float32) (value float32) {
+	if scale == LinearScale {
+		return priceRange[0] + percent*(priceRange[1]-priceRange[0])
+	}
+
 	log := func(value float32) float64 {
 		if value == 0 {
 			return 0
@@ -201,7 +268,35 @@ func priceValue(priceRange [2]float32, percent float32) (value float32) {
 	)
 }
 
-func priceBarVAO(ts []*model.TradingSession, priceRange [2]float32) *gfx.VAO {
+// heikinAshiTradingSessions returns smoothed copies of the sessions where each close
+// is the average of the session's prices and each open is the midpoint of the previous smoothed session.
+func heikinAshiTradingSessions(ts []*model.TradingSession) []*model.TradingSession {
+	var has []*model.TradingSession
+	var prev *model.TradingSession
+	for _, s := range ts {
+		ha := *s
+
+		// Leave empty sessions alone, so they don't drag down the next session.
+		if s.Close == 0 {
+			has = append(has, &ha)
+			continue
+		}
+
+		ha.Close = (s.Open + s.High + s.Low + s.Close) / 4
+		ha.Open = (s.Open + s.Close) / 2
+		if prev != nil {
+			ha.Open = (prev.Open + prev.Close) / 2
+		}
+		ha.High = float32(math.Max(float64(s.High), math.Max(float64(ha.Open), float64(ha.Close))))
+		ha.Low = float32(math.Min(float64(s.Low), math.Min(float64(ha.Open), float64(ha.Close))))
+
+		has = append(has, &ha)
+		prev = &ha
+	}
+	return has
+}
+
+func priceBarVAO(ts []*model.TradingSession, priceRange [2]float32, scale PriceScale) *gfx.VAO {
 	var vertices []float32
 	var colors []float32
 	var lineIndices []uint16
@@ -219,7 +314,7 @@ func priceBarVAO(ts []*model.TradingSession, priceRange [2]float32) *gfx.VAO {
 	}
 
 	calcY := func(value float32) float32 {
-		return 2*pricePercent(priceRange, value) - 1
+		return 2*pricePercent(priceRange, scale, value) - 1
 	}
 
 	for _, s := range ts {
@@ -284,7 +379,24 @@ func priceBarVAO(ts []*model.TradingSession, priceRange [2]float32) *gfx.VAO {
 	)
 }
 
-func priceCandlestickVAOs(ts []*model.TradingSession, priceRange [2]float32) (stickLines, stickRects *gfx.VAO) {
+// priceCloseVAOs returns the line of closing prices and the translucent fill under it.
+func priceCloseVAOs(ts []*model.TradingSession, priceRange [2]float32, scale PriceScale) (closeLine, closeArea *gfx.VAO) {
+	var yPercentValues []float32
+	for _, s := range ts {
+		var v float32
+		if s.Close != 0 {
+			v = pricePercent(priceRange, scale, s.Close)
+		}
+		yPercentValues = append(yPercentValues, v)
+	}
+
+	fillColor := view.Blue
+	fillColor[3] = priceAreaAlpha
+
+	return vao.DataLine(yPercentValues, view.Blue), vao.DataArea(yPercentValues, fillColor)
+}
+
+func priceCandlestickVAOs(ts []*model.TradingSession, priceRange [2]float32, scale PriceScale) (stickLines, stickRects *gfx.VAO) {
 	var vertices []float32
 	var colors []float32
 	var lineIndices []uint16
@@ -303,7 +415,7 @@ func priceCandlestickVAOs(ts []*model.TradingSession, priceRange [2]float32) (st
 	}
 
 	calcY := func(value float32) float32 {
-		return 2*pricePercent(priceRange, value) - 1
+		return 2*pricePercent(priceRange, scale, value) - 1
 	}
 
 	for _, s := range ts {
@@ -19,6 +19,12 @@ type priceCursor struct {
 	// priceRange is the inclusive range from min to max price.
 	priceRange [2]float32
 
+	// priceScale is whether the prices are on a linear or log scale.
+	priceScale PriceScale
+
+	// percents is true if the label is a percent change from comparing symbols instead of a price.
+	percents bool
+
 	// priceRect is the rectangle where the price candlesticks are drawn.
 	priceRect image.Rectangle
 
@@ -31,6 +37,10 @@ type priceCursor struct {
 
 type priceCursorData struct {
 	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
+
+	// PercentRange is the range of percent changes to label instead of prices. Nil to label prices.
+	PercentRange *[2]float32
 }
 
 func (p *priceCursor) SetData(data priceCursorData) {
@@ -46,6 +56,14 @@ func (p *priceCursor) SetData(data priceCursorData) {
 	}
 
 	p.priceRange = priceRange(ts.TradingSessions)
+	p.priceScale = data.PriceScale
+	p.percents = false
+
+	if r := data.PercentRange; r != nil {
+		p.priceRange = *r
+		p.priceScale = LinearScale
+		p.percents = true
+	}
 
 	p.renderable = true
 }
@@ -71,7 +89,7 @@ func (p *priceCursor) Render(fudge float32) {
 	renderCursorLines(p.priceRect, p.mousePos)
 
 	if p.mousePos.In(p.priceRect) {
-		renderPriceLabel(fudge, p.priceRange, p.labelRect, p.mousePos.Point, true)
+		renderPriceLabel(fudge, p.priceRange, p.priceScale, p.percents, p.labelRect, p.mousePos.Point, true)
 	}
 }
 
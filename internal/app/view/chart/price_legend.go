@@ -1,8 +1,8 @@
 package chart
 
 import (
-	"fmt"
 	"image"
+	"math"
 
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view"
@@ -32,9 +32,12 @@ func newPriceLegend() *priceLegend {
 }
 
 type priceLegendData struct {
-	Interval               model.Interval
-	TradingSessionSeries   *model.TradingSessionSeries
-	MovingAverageSeriesSet []*model.AverageSeries
+	Interval             model.Interval
+	TradingSessionSeries *model.TradingSessionSeries
+	OverlaySeriesSet     []*model.IndicatorSeries
+	OverlayColors        []view.Color
+	RelativeStrength     *model.RelativeStrengthSeries
+	ComparisonSeriesSet  []*comparisonSeries
 }
 
 func (p *priceLegend) SetData(data priceLegendData) {
@@ -125,17 +128,55 @@ func (p *priceLegend) Update() (dirty bool) {
 		{empty, empty, legendText(formatPercentChange(curr.PercentChange))},
 	}
 
-	if len(p.data.MovingAverageSeriesSet) != 0 {
+	if len(p.data.OverlaySeriesSet) != 0 {
 		rows = append(rows, [3]legendCell{empty, empty, empty})
 	}
 
-	for _, series := range p.data.MovingAverageSeriesSet {
+	for j, series := range p.data.OverlaySeriesSet {
+		if len(series.Values) != len(tss) {
+			continue
+		}
 		value := series.Values[i].Value
 		rows = append(rows, [3]legendCell{
-			symbol(symbolLabel(curr.Close, value), movingAverageColors[p.data.Interval][series.Intervals]),
-			legendText(fmt.Sprintf("%s %d", typeLabel(series.Type), series.Intervals)),
+			symbol(symbolLabel(curr.Close, value), p.data.OverlayColors[j]),
+			legendText(series.Name),
 			legendText(formatFloat(value)),
 		})
+
+		// Show the lower edge of bands below the upper edge.
+		if len(series.LowerValues) == len(tss) {
+			rows = append(rows, [3]legendCell{empty, empty, legendText(formatFloat(series.LowerValues[i].Value))})
+		}
+	}
+
+	if rs := p.data.RelativeStrength; rs != nil && len(rs.Values) == len(tss) {
+		rows = append(rows,
+			[3]legendCell{empty, empty, empty},
+			[3]legendCell{
+				symbol("◼", relativeStrengthColor),
+				legendText("RS " + rs.Benchmark),
+				legendText(formatRelativeStrength(rs.Values[i].Value)),
+			},
+		)
+	}
+
+	if len(p.data.ComparisonSeriesSet) != 0 {
+		rows = append(rows, [3]legendCell{empty, empty, empty})
+	}
+
+	for _, s := range p.data.ComparisonSeriesSet {
+		if len(s.PercentChanges) != len(tss) {
+			continue
+		}
+		value := legendText("-")
+		if v := s.PercentChanges[i]; !math.IsNaN(float64(v)) {
+			value = legendText(formatPercentChange(v * 100))
+		}
+		rows = append(rows, [3]legendCell{
+			symbol("◼", s.Color),
+			legendText(s.Symbol),
+			value,
+		})
 	}
 
 	columns := [3]legendColumn{}
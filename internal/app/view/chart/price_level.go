@@ -21,6 +21,12 @@ type priceLevel struct {
 	// priceRange represents the inclusive range from min to max price.
 	priceRange [2]float32
 
+	// priceScale is whether the prices are on a linear or log scale.
+	priceScale PriceScale
+
+	// percents is true if the labels are percent changes from comparing symbols instead of prices.
+	percents bool
+
 	// MaxLabelSize is the maximum label size useful for rendering measurements.
 	MaxLabelSize image.Point
 
@@ -37,6 +43,10 @@ func newPriceLevel() *priceLevel {
 
 type priceLevelData struct {
 	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
+
+	// PercentRange is the range of percent changes to label instead of prices. Nil to label prices.
+	PercentRange *[2]float32
 }
 
 func (p *priceLevel) SetData(data priceLevelData) {
@@ -50,9 +60,20 @@ func (p *priceLevel) SetData(data priceLevelData) {
 	}
 
 	p.priceRange = priceRange(ts.TradingSessions)
+	p.priceScale = data.PriceScale
+	p.percents = false
 
-	// Measure the max label size by creating a label with the max value.
-	p.MaxLabelSize = makePriceLabel(p.priceRange[1]).size
+	if r := data.PercentRange; r != nil {
+		p.priceRange = *r
+		p.priceScale = LinearScale
+		p.percents = true
+	}
+
+	// Measure the max label size by creating labels with the min and max values.
+	p.MaxLabelSize = makePriceLabel(p.priceRange[1], p.percents).size
+	if s := makePriceLabel(p.priceRange[0], p.percents).size; s.X > p.MaxLabelSize.X {
+		p.MaxLabelSize = s
+	}
 
 	p.renderable = true
 }
@@ -75,7 +96,7 @@ func (p *priceLevel) Render(fudge float32) {
 
 	r = p.labelBounds
 	for _, y := range p.labelYPositions(r) {
-		renderPriceLabel(fudge, p.priceRange, r, image.Pt(0, y), false)
+		renderPriceLabel(fudge, p.priceRange, p.priceScale, p.percents, r, image.Pt(0, y), false)
 	}
 }
 
@@ -100,18 +121,22 @@ type priceLabel struct {
 	size image.Point
 }
 
-func makePriceLabel(v float32) priceLabel {
+// makePriceLabel makes a label with the price or the percent change if percent is true.
+func makePriceLabel(v float32, percent bool) priceLabel {
 	t := strconv.FormatFloat(float64(v), 'f', 2, 32)
+	if percent {
+		t = formatPercentChange(v * 100)
+	}
 	return priceLabel{
 		text: t,
 		size: axisLabelTextRenderer.Measure(t),
 	}
 }
 
-func renderPriceLabel(fudge float32, priceRange [2]float32, r image.Rectangle, pt image.Point, includeBubble bool) {
+func renderPriceLabel(fudge float32, priceRange [2]float32, scale PriceScale, percent bool, r image.Rectangle, pt image.Point, includeBubble bool) {
 	yPercent := float32(pt.Y-r.Min.Y) / float32(r.Dy())
-	value := priceValue(priceRange, yPercent)
-	label := makePriceLabel(value)
+	value := priceValue(priceRange, scale, yPercent)
+	label := makePriceLabel(value, percent)
 
 	textPt := image.Point{
 		X: r.Max.X - label.size.X,
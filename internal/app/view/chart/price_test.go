@@ -0,0 +1,46 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+)
+
+func TestPriceRange(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		input []*model.TradingSession
+		want  [2]float32
+	}{
+		{
+			desc: "no sessions",
+			want: [2]float32{0, 0},
+		},
+		{
+			desc: "small range",
+			input: []*model.TradingSession{
+				{Low: 100, High: 110},
+				{Low: 105, High: 120},
+			},
+			want: [2]float32{99, 121},
+		},
+		{
+			desc: "big winner",
+			input: []*model.TradingSession{
+				{Low: 1, High: 2},
+				{Low: 20, High: 41},
+			},
+			want: [2]float32{0.95, 43},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := priceRange(tt.input)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 0.001)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
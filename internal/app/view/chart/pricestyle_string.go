@@ -11,11 +11,14 @@ func _() {
 	_ = x[PriceStyleUnspecified-0]
 	_ = x[Bar-1]
 	_ = x[Candlestick-2]
+	_ = x[HeikinAshi-3]
+	_ = x[Line-4]
+	_ = x[Area-5]
 }
 
-const _PriceStyle_name = "PriceStyleUnspecifiedBarCandlestick"
+const _PriceStyle_name = "PriceStyleUnspecifiedBarCandlestickHeikinAshiLineArea"
 
-var _PriceStyle_index = [...]uint8{0, 21, 24, 35}
+var _PriceStyle_index = [...]uint8{0, 21, 24, 35, 45, 49, 53}
 
 func (i PriceStyle) String() string {
 	if i < 0 || i >= PriceStyle(len(_PriceStyle_index)-1) {
@@ -0,0 +1,128 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/vao"
+)
+
+// The relative strength line is scaled into the lower part of the price section below most prices.
+const (
+	relativeStrengthBottomPercent = 0.02
+	relativeStrengthHeightPercent = 0.25
+)
+
+// relativeStrengthColor is the color of the relative strength line and its new high markers.
+var relativeStrengthColor = view.Blue
+
+// relativeStrengthMarkerRenderer renders the dots that mark new relative strength highs.
+var relativeStrengthMarkerRenderer = gfx.NewTextRenderer(_escFSMustByte(false, "/data/DejaVuSans.ttf"), 10)
+
+// relativeStrength draws the relative strength line against a benchmark with dots at new highs.
+type relativeStrength struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// line is the VAO with the relative strength line.
+	line *gfx.VAO
+
+	// highs are the x and y percentages within the bounds of the new highs.
+	highs [][2]float32
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+type relativeStrengthData struct {
+	RelativeStrengthSeries *model.RelativeStrengthSeries
+}
+
+func (r *relativeStrength) SetData(data relativeStrengthData) {
+	// Reset everything.
+	r.Close()
+
+	// Bail out if there is no data yet.
+	rs := data.RelativeStrengthSeries
+	if rs == nil || len(rs.Values) == 0 {
+		return
+	}
+
+	valueRange := relativeStrengthRange(rs.Values)
+
+	var yPercentValues []float32
+	for i, v := range rs.Values {
+		y := relativeStrengthPercent(valueRange, v.Value)
+		yPercentValues = append(yPercentValues, y)
+		if v.NewHigh {
+			x := (float32(i) + 0.5) / float32(len(rs.Values))
+			r.highs = append(r.highs, [2]float32{x, y})
+		}
+	}
+	r.line = vao.DataLine(yPercentValues, relativeStrengthColor)
+
+	r.renderable = true
+}
+
+func (r *relativeStrength) SetBounds(bounds image.Rectangle) {
+	r.bounds = bounds
+}
+
+func (r *relativeStrength) Render(float32) {
+	if !r.renderable {
+		return
+	}
+
+	gfx.SetModelMatrixRect(r.bounds)
+	r.line.Render()
+
+	const marker = "●"
+	size := relativeStrengthMarkerRenderer.Measure(marker)
+	for _, h := range r.highs {
+		pt := image.Pt(
+			r.bounds.Min.X+int(float32(r.bounds.Dx())*h[0])-size.X/2,
+			r.bounds.Min.Y+int(float32(r.bounds.Dy())*h[1])-size.Y/2,
+		)
+		relativeStrengthMarkerRenderer.Render(marker, pt, gfx.TextColor(relativeStrengthColor))
+	}
+}
+
+func (r *relativeStrength) Close() {
+	r.renderable = false
+	if r.line != nil {
+		r.line.Delete()
+		r.line = nil
+	}
+	r.highs = nil
+}
+
+// relativeStrengthRange returns the range of the values ignoring zeros for missing benchmark closes.
+func relativeStrengthRange(vs []*model.RelativeStrengthValue) [2]float32 {
+	var r [2]float32
+	for _, v := range vs {
+		if v.Value <= 0 {
+			continue
+		}
+		if r[0] == 0 || v.Value < r[0] {
+			r[0] = v.Value
+		}
+		if v.Value > r[1] {
+			r[1] = v.Value
+		}
+	}
+	return r
+}
+
+func relativeStrengthPercent(valueRange [2]float32, value float32) (percent float32) {
+	if value <= 0 || valueRange[0] == valueRange[1] {
+		return 0
+	}
+	return relativeStrengthBottomPercent + relativeStrengthHeightPercent*(value-valueRange[0])/(valueRange[1]-valueRange[0])
+}
+
+func formatRelativeStrength(value float32) string {
+	return fmt.Sprintf("%.4f", value)
+}
@@ -39,12 +39,21 @@ type Thumb struct {
 	priceCursor   *priceCursor
 	priceTimeline *timeline
 
-	movingAverages []*movingAverage
+	overlays []*overlay
 
 	volume         *volume
 	volumeCursor   *volumeCursor
 	volumeTimeline *timeline
 
+	// positionText is the text with the profit or loss of the user's position. Empty if no position.
+	positionText string
+
+	// positionColor is the color to render the position text.
+	positionColor view.Color
+
+	// positionPoint is where to render the position text at the top left of the prices.
+	positionPoint image.Point
+
 	// loadingTextBox renders the loading text shown when loading from a fresh state.
 	loadingTextBox *text.Box
 
@@ -83,16 +92,22 @@ func NewThumb(priceStyle PriceStyle) *Thumb {
 		return nil
 	}
 
+	header := newHeader(&headerArgs{
+		SymbolQuoteTextRenderer: thumbSymbolQuoteTextRenderer,
+		QuotePrinter:            thumbQuotePrinter,
+		ShowRemoveButton:        true,
+		ShowPriceStyleButton:    true,
+		ShowRSRating:            true,
+		ShowAlertBadge:          true,
+		Rounding:                thumbRounding,
+		Padding:                 thumbSectionPadding,
+	})
+	header.SetPriceStyle(priceStyle)
+
 	return &Thumb{
 		frameBubble: rect.NewBubble(thumbRounding),
 
-		header: newHeader(&headerArgs{
-			SymbolQuoteTextRenderer: thumbSymbolQuoteTextRenderer,
-			QuotePrinter:            thumbQuotePrinter,
-			ShowRemoveButton:        true,
-			Rounding:                thumbRounding,
-			Padding:                 thumbSectionPadding,
-		}),
+		header: header,
 
 		price:         newPrice(priceStyle),
 		priceCursor:   new(priceCursor),
@@ -116,6 +131,7 @@ func (t *Thumb) SetPriceStyle(newPriceStyle PriceStyle) {
 		return
 	}
 
+	t.header.SetPriceStyle(newPriceStyle)
 	t.price.SetStyle(newPriceStyle)
 	t.volume.SetStyle(newPriceStyle)
 }
@@ -145,6 +161,8 @@ func (t *Thumb) SetData(data Data) {
 
 	t.header.SetData(data)
 
+	t.positionText, t.positionColor = positionText(data.Position)
+
 	dc := data.Chart
 	if dc == nil {
 		return
@@ -152,10 +170,7 @@ func (t *Thumb) SetData(data Data) {
 
 	ts := dc.TradingSessionSeries
 
-	var mas []*model.AverageSeries
-	for _, ma := range dc.MovingAverageSeriesSet {
-		mas = append(mas, ma)
-	}
+	oss, ocs := visibleOverlays(dc.OverlaySeriesSet, data.MovingAverages)
 
 	vs := dc.AverageVolumeSeries
 
@@ -169,9 +184,9 @@ func (t *Thumb) SetData(data Data) {
 		return
 	}
 
-	for _, ma := range mas {
-		if ml := len(ma.Values); ml != tl {
-			logger.Errorf("moving average has different length: %d vs %d", tl, ml)
+	for _, s := range oss {
+		if ol := len(s.Values); ol != tl {
+			logger.Errorf("overlay has different length: %d vs %d", tl, ol)
 			return
 		}
 	}
@@ -181,31 +196,27 @@ func (t *Thumb) SetData(data Data) {
 		ts = ts.DeepCopy()
 		ts.TradingSessions = ts.TradingSessions[l-days:]
 	}
-	for i, m := range mas {
-		if l := len(m.Values); l > days {
-			m = m.DeepCopy()
-			m.Values = m.Values[l-days:]
-			mas[i] = m
-		}
+	for i, s := range oss {
+		oss[i] = trimmedIndicatorSeries(s, days)
 	}
 	if l := len(vs.Values); l > days {
 		vs = vs.DeepCopy()
 		vs.Values = vs.Values[l-days:]
 	}
 
-	t.price.SetData(priceData{ts})
-	t.priceCursor.SetData(priceCursorData{ts})
+	t.price.SetData(priceData{ts, data.PriceScale})
+	t.priceCursor.SetData(priceCursorData{ts, data.PriceScale, nil})
 	t.priceTimeline.SetData(timelineData{dc.Interval, ts})
 
-	for _, ma := range t.movingAverages {
-		ma.Close()
+	for _, o := range t.overlays {
+		o.Close()
 	}
 
-	t.movingAverages = nil
-	for _, ma := range mas {
-		m := newMovingAverage(movingAverageColors[dc.Interval][ma.Intervals])
-		m.SetData(movingAverageData{ts, ma})
-		t.movingAverages = append(t.movingAverages, m)
+	t.overlays = nil
+	for i, s := range oss {
+		o := newOverlay(ocs[i])
+		o.SetData(overlayData{ts, s, data.PriceScale})
+		t.overlays = append(t.overlays, o)
 	}
 
 	t.volume.SetData(volumeData{ts, vs})
@@ -213,6 +224,22 @@ func (t *Thumb) SetData(data Data) {
 	t.volumeTimeline.SetData(timelineData{dc.Interval, ts})
 }
 
+// trimmedIndicatorSeries returns a copy of the series with only the last n values and lower values.
+// The series is returned as is if it has n values or less.
+func trimmedIndicatorSeries(s *model.IndicatorSeries, n int) *model.IndicatorSeries {
+	l := len(s.Values)
+	if l <= n {
+		return s
+	}
+
+	s = s.DeepCopy()
+	s.Values = s.Values[l-n:]
+	if len(s.LowerValues) == l {
+		s.LowerValues = s.LowerValues[l-n:]
+	}
+	return s
+}
+
 // SetBounds sets the bounds to draw within.
 func (t *Thumb) SetBounds(bounds image.Rectangle) {
 	t.bounds = bounds
@@ -244,12 +271,14 @@ func (t *Thumb) ProcessInput(input *view.Input) {
 	pr = pr.Inset(thumbSectionPadding)
 	vr = vr.Inset(thumbSectionPadding)
 
+	t.positionPoint = image.Pt(pr.Min.X+thumbSectionPadding, pr.Max.Y-thumbSymbolQuoteTextRenderer.LineHeight())
+
 	t.price.SetBounds(pr)
 	t.priceCursor.SetBounds(pr, pr)
 	t.priceTimeline.SetBounds(pr)
 
-	for _, ma := range t.movingAverages {
-		ma.SetBounds(pr)
+	for _, o := range t.overlays {
+		o.SetBounds(pr)
 	}
 
 	t.volume.SetBounds(vr)
@@ -313,11 +342,16 @@ func (t *Thumb) Render(fudge float32) {
 
 	t.priceTimeline.Render(fudge)
 	t.price.Render(fudge)
-	for _, ma := range t.movingAverages {
-		ma.Render(fudge)
+	for _, o := range t.overlays {
+		o.Render(fudge)
 	}
 	t.priceCursor.Render(fudge)
 
+	// Render the position over the prices, since the header has no room after the quote.
+	if t.positionText != "" {
+		thumbSymbolQuoteTextRenderer.Render(t.positionText, t.positionPoint, gfx.TextColor(t.positionColor))
+	}
+
 	t.volumeTimeline.Render(fudge)
 	t.volume.Render(fudge)
 	t.volumeCursor.Render(fudge)
@@ -328,6 +362,21 @@ func (t *Thumb) SetRemoveButtonClickCallback(cb func()) {
 	t.header.SetRemoveButtonClickCallback(cb)
 }
 
+// SetPriceStyleButtonClickCallback sets the callback for price style button clicks.
+func (t *Thumb) SetPriceStyleButtonClickCallback(cb func()) {
+	t.header.SetPriceStyleButtonClickCallback(cb)
+}
+
+// SetRSRatingClickCallback sets the callback for relative strength rating clicks.
+func (t *Thumb) SetRSRatingClickCallback(cb func()) {
+	t.header.SetRSRatingClickCallback(cb)
+}
+
+// SetAlertBadgeClickCallback sets the callback for alert badge clicks.
+func (t *Thumb) SetAlertBadgeClickCallback(cb func()) {
+	t.header.SetAlertBadgeClickCallback(cb)
+}
+
 // SetThumbClickCallback sets the callback for thumbnail clicks.
 func (t *Thumb) SetThumbClickCallback(cb func()) {
 	t.thumbClickCallback = cb
@@ -339,8 +388,8 @@ func (t *Thumb) Close() {
 	t.price.Close()
 	t.priceCursor.Close()
 	t.priceTimeline.Close()
-	for _, ma := range t.movingAverages {
-		ma.Close()
+	for _, o := range t.overlays {
+		o.Close()
 	}
 	t.volume.Close()
 	t.volumeCursor.Close()
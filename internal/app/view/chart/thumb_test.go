@@ -0,0 +1,57 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+)
+
+func TestTrimmedIndicatorSeries(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2020, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	values := func(vs ...float32) []*model.IndicatorValue {
+		var ivs []*model.IndicatorValue
+		for i, v := range vs {
+			ivs = append(ivs, &model.IndicatorValue{Date: day(i + 1), Value: v})
+		}
+		return ivs
+	}
+
+	for _, tt := range []struct {
+		desc  string
+		input *model.IndicatorSeries
+		n     int
+		want  *model.IndicatorSeries
+	}{
+		{
+			desc:  "short line",
+			input: &model.IndicatorSeries{Name: "SMA 2", Values: values(1, 2)},
+			n:     3,
+			want:  &model.IndicatorSeries{Name: "SMA 2", Values: values(1, 2)},
+		},
+		{
+			desc:  "long line",
+			input: &model.IndicatorSeries{Name: "SMA 2", Values: values(1, 2, 3, 4)},
+			n:     2,
+			want:  &model.IndicatorSeries{Name: "SMA 2", Values: values(1, 2, 3, 4)[2:]},
+		},
+		{
+			desc:  "long band",
+			input: &model.IndicatorSeries{Name: "BB 20", Values: values(5, 6, 7, 8), LowerValues: values(1, 2, 3, 4)},
+			n:     2,
+			want:  &model.IndicatorSeries{Name: "BB 20", Values: values(5, 6, 7, 8)[2:], LowerValues: values(1, 2, 3, 4)[2:]},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := trimmedIndicatorSeries(tt.input, tt.n)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
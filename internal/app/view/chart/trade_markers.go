@@ -0,0 +1,251 @@
+package chart
+
+import (
+	"image"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/rect"
+	"github.com/btmura/ponzi2/internal/app/view/status"
+)
+
+// tradeMarkerGap is the space between a bar and the arrow pointing at it.
+const tradeMarkerGap = 2
+
+// Trade is a trade the user recorded in the journal.
+type Trade struct {
+	// Sell is true if shares were sold and false if they were bought.
+	Sell bool
+
+	// Quantity is the number of shares.
+	Quantity float32
+
+	// Price is the price per share.
+	Price float32
+
+	// Time is when the trade happened.
+	Time time.Time
+
+	// Note is why the trade was made.
+	Note string
+
+	// PL is the profit or loss since entering the trade in dollars.
+	PL float32
+
+	// PercentPL is the profit or loss since entering the trade as a fraction like 0.1 for 10%.
+	PercentPL float32
+}
+
+// tradeMarker is an arrow pointing at the bar of a trade.
+type tradeMarker struct {
+	// trade is the trade that the marker points at.
+	trade *Trade
+
+	// x is the x percentage within the bounds of the bar's center.
+	x float32
+
+	// low is the y percentage within the bounds of the bar's low.
+	low float32
+
+	// high is the y percentage within the bounds of the bar's high.
+	high float32
+}
+
+// tradeMarkers draws arrows at the bars of the user's trades with a tooltip for the hovered one.
+type tradeMarkers struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// markers are the arrows to draw.
+	markers []*tradeMarker
+
+	// tooltip is the table with the details of the hovered trade.
+	tooltip legendTable
+
+	// hovered is true if the mouse is over a marker and the tooltip should be rendered.
+	hovered bool
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+type tradeMarkersData struct {
+	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
+	Trades               []*Trade
+}
+
+func (t *tradeMarkers) SetData(data tradeMarkersData) {
+	// Reset everything.
+	t.Close()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil || len(ts.TradingSessions) == 0 || len(data.Trades) == 0 {
+		return
+	}
+
+	tss := ts.TradingSessions
+	r := priceRange(tss)
+
+	for _, tr := range data.Trades {
+		// Find the last session starting at or before the trade, since sessions have their start dates.
+		i := len(tss) - 1
+		for i >= 0 && tss[i].Date.After(tr.Time) {
+			i--
+		}
+
+		// Skip trades before the shown sessions.
+		if i < 0 {
+			continue
+		}
+
+		s := tss[i]
+		t.markers = append(t.markers, &tradeMarker{
+			trade: tr,
+			x:     (float32(i) + 0.5) / float32(len(tss)),
+			low:   pricePercent(r, data.PriceScale, s.Low),
+			high:  pricePercent(r, data.PriceScale, s.High),
+		})
+	}
+
+	t.renderable = len(t.markers) != 0
+}
+
+func (t *tradeMarkers) SetBounds(bounds image.Rectangle) {
+	t.bounds = bounds
+}
+
+func (t *tradeMarkers) ProcessInput(input *view.Input) {
+	t.hovered = false
+
+	if !t.renderable || !input.MousePos.In(t.bounds) {
+		return
+	}
+
+	// Find the topmost marker under the mouse, since later markers are drawn over earlier ones.
+	var hovered *tradeMarker
+	for _, m := range t.markers {
+		if input.MousePos.In(t.markerBounds(m)) {
+			hovered = m
+		}
+	}
+	if hovered == nil {
+		return
+	}
+
+	tr := hovered.trade
+	side := "Buy"
+	if tr.Sell {
+		side = "Sell"
+	}
+
+	plColor := view.White
+	switch {
+	case tr.PL > 0:
+		plColor = view.Green
+	case tr.PL < 0:
+		plColor = view.Red
+	}
+
+	plText := status.PL(tr.PL, tr.PercentPL)
+
+	empty := legendCell{}
+	rows := [][3]legendCell{
+		{tradeMarkerArrow(tr.Sell), legendText(side), legendText(formatFloat(tr.Quantity) + " @ " + formatFloat(tr.Price))},
+		{empty, legendText("Time"), legendText(tr.Time.Format("1/2/06 3:04 PM"))},
+		{empty, legendText("Since"), legendCell{
+			renderer: legendTextRenderer,
+			text:     plText,
+			color:    plColor,
+			size:     legendTextRenderer.Measure(plText),
+		}},
+	}
+	if tr.Note != "" {
+		rows = append(rows, [3]legendCell{empty, legendText("Note"), legendText(tr.Note)})
+	}
+
+	var columns [3]legendColumn
+	for i := range rows {
+		for j := range columns {
+			if w := rows[i][j].size.X; w > columns[j].maxWidth {
+				columns[j].maxWidth = w
+			}
+		}
+	}
+
+	tableBounds := image.Rect(
+		0,
+		0,
+		legendTablePadding+columns[0].maxWidth+
+			legendTablePadding+columns[1].maxWidth+
+			legendTablePadding+columns[2].maxWidth+legendTablePadding,
+		legendTablePadding+len(rows)*legendTextRenderer.LineHeight()+legendTablePadding,
+	)
+
+	// Show the tooltip up and to the right of the mouse but keep it within the bounds.
+	pt := input.MousePos.Point.Add(image.Pt(legendBubbleMargin, legendBubbleMargin))
+	if pt.X+tableBounds.Dx() > t.bounds.Max.X {
+		pt.X = input.MousePos.X - legendBubbleMargin - tableBounds.Dx()
+	}
+	if pt.Y+tableBounds.Dy() > t.bounds.Max.Y {
+		pt.Y = input.MousePos.Y - legendBubbleMargin - tableBounds.Dy()
+	}
+
+	t.tooltip = legendTable{
+		bubble:  rect.NewBubble(legendBubbleRounding),
+		rows:    rows,
+		columns: columns,
+	}
+	t.tooltip.SetBounds(tableBounds.Add(pt))
+	t.hovered = true
+}
+
+// markerBounds returns the rectangle with global coords of the marker's arrow.
+// Buys point up from below the bar's low and sells point down from above the bar's high.
+func (t *tradeMarkers) markerBounds(m *tradeMarker) image.Rectangle {
+	size := legendGeometricShapeRenderer.Measure(tradeMarkerArrow(m.trade.Sell).text)
+
+	x := t.bounds.Min.X + int(float32(t.bounds.Dx())*m.x) - size.X/2
+	if m.trade.Sell {
+		y := t.bounds.Min.Y + int(float32(t.bounds.Dy())*m.high) + tradeMarkerGap
+		return image.Rect(x, y, x+size.X, y+size.Y)
+	}
+	y := t.bounds.Min.Y + int(float32(t.bounds.Dy())*m.low) - tradeMarkerGap - size.Y
+	return image.Rect(x, y, x+size.X, y+size.Y)
+}
+
+func (t *tradeMarkers) Render(float32) {
+	if !t.renderable {
+		return
+	}
+
+	for _, m := range t.markers {
+		a := tradeMarkerArrow(m.trade.Sell)
+		a.Render(t.markerBounds(m).Min)
+	}
+}
+
+// RenderTooltip renders the tooltip of the hovered trade over the legends.
+func (t *tradeMarkers) RenderTooltip(fudge float32) {
+	if !t.renderable || !t.hovered {
+		return
+	}
+
+	t.tooltip.Render(fudge)
+}
+
+func (t *tradeMarkers) Close() {
+	t.renderable = false
+	t.markers = nil
+	t.hovered = false
+}
+
+// tradeMarkerArrow returns a green up arrow for buys and a red down arrow for sells.
+func tradeMarkerArrow(sell bool) legendCell {
+	if sell {
+		return symbol("▼", view.Red)
+	}
+	return symbol("▲", view.Green)
+}
@@ -22,12 +22,15 @@ type volume struct {
 	// faders has the faders needed to fade in and out the bars and candlesticks.
 	faders map[PriceStyle]*view.Fader
 
-	// barLines are the volume bars colored to go with price bars.
+	// barLines are the volume bars colored to go with price bars, lines, and areas.
 	barLines *gfx.VAO
 
 	// stickLines are the volume bars colored to go with candlesticks.
 	stickLines *gfx.VAO
 
+	// heikinAshiLines are the volume bars colored to go with Heikin-Ashi candlesticks.
+	heikinAshiLines *gfx.VAO
+
 	// avgLine is the VAO with the average volume line.
 	avgLine *gfx.VAO
 
@@ -46,6 +49,9 @@ func newVolume(priceStyle PriceStyle) *volume {
 		faders: map[PriceStyle]*view.Fader{
 			Bar:         view.NewStoppedFader(1 * view.FPS),
 			Candlestick: view.NewStoppedFader(1 * view.FPS),
+			HeikinAshi:  view.NewStoppedFader(1 * view.FPS),
+			Line:        view.NewStoppedFader(1 * view.FPS),
+			Area:        view.NewStoppedFader(1 * view.FPS),
 		},
 	}
 }
@@ -84,6 +90,7 @@ func (v *volume) SetData(data volumeData) {
 
 	v.barLines = volumeLineVAO(ts.TradingSessions, yRange, Bar)
 	v.stickLines = volumeLineVAO(ts.TradingSessions, yRange, Candlestick)
+	v.heikinAshiLines = volumeLineVAO(heikinAshiTradingSessions(ts.TradingSessions), yRange, HeikinAshi)
 	v.avgLine = volumeDataLine(vs.Values, yRange)
 
 	v.renderable = true
@@ -120,11 +127,14 @@ func (v *volume) Render(fudge float32) {
 	for style, fader := range v.faders {
 		fader.Render(fudge, func() {
 			switch style {
-			case Bar:
+			case Bar, Line, Area:
 				v.barLines.Render()
 
 			case Candlestick:
 				v.stickLines.Render()
+
+			case HeikinAshi:
+				v.heikinAshiLines.Render()
 			}
 		})
 	}
@@ -140,6 +150,9 @@ func (v *volume) Close() {
 	if v.stickLines != nil {
 		v.stickLines.Delete()
 	}
+	if v.heikinAshiLines != nil {
+		v.heikinAshiLines.Delete()
+	}
 	if v.avgLine != nil {
 		v.avgLine.Delete()
 	}
@@ -234,7 +247,7 @@ func volumeLineVAO(ts []*model.TradingSession, volumeRange [2]int, priceStyle Pr
 				c = view.Red
 			}
 
-		case Candlestick:
+		case Candlestick, HeikinAshi:
 			switch {
 			case s.Source == model.RealTimePrice:
 				c = view.Yellow
@@ -7,6 +7,7 @@ import (
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view"
 	"github.com/btmura/ponzi2/internal/app/view/rect"
+	"github.com/btmura/ponzi2/internal/indicator"
 )
 
 // volumeLegend is a bubble that shows a trading session's stats where the mouse cursor is.
@@ -121,7 +122,7 @@ func (v *volumeLegend) Update() (dirty bool) {
 			[3]legendCell{empty, empty, empty},
 			[3]legendCell{
 				symbol(symbolLabel(float32(curr.Volume), value), view.Red),
-				legendText(fmt.Sprintf("%s %d", typeLabel(series.Type), series.Intervals)),
+				legendText(fmt.Sprintf("%s %d", indicator.AverageTypeLabel(series.Type), series.Intervals)),
 				legendText(volumeText(int(value))),
 			})
 	}
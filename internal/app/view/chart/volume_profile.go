@@ -0,0 +1,182 @@
+package chart
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+)
+
+// volumeProfileBucketCount is how many price levels to bucket the volume into.
+const volumeProfileBucketCount = 24
+
+// volumeProfileWidthPercent is how far the bucket with the most volume extends from the right side.
+const volumeProfileWidthPercent = 0.25
+
+var (
+	// volumeProfileColor is the color of the buckets outside the value area.
+	volumeProfileColor = view.Color{0.35, 0.35, 0.35, 0.4}
+
+	// volumeProfileValueAreaColor is the color of the buckets within the value area.
+	volumeProfileValueAreaColor = view.Color{0, 0.75, 1, 0.3}
+
+	// volumeProfilePointOfControlColor is the color of the bucket with the most volume and its line.
+	volumeProfilePointOfControlColor = view.Color{1, 1, 0, 0.5}
+)
+
+// volumeProfile draws horizontal bars of the volume traded at each price level on the right side of the prices.
+type volumeProfile struct {
+	// renderable is true if this should be rendered.
+	renderable bool
+
+	// bars is the VAO with the horizontal volume bars.
+	bars *gfx.VAO
+
+	// pointOfControlLine is the VAO with the line across the prices at the point of control.
+	pointOfControlLine *gfx.VAO
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+type volumeProfileData struct {
+	TradingSessionSeries *model.TradingSessionSeries
+	PriceScale           PriceScale
+}
+
+func (v *volumeProfile) SetData(data volumeProfileData) {
+	// Reset everything.
+	v.Close()
+
+	// Bail out if there is no data yet.
+	ts := data.TradingSessionSeries
+	if ts == nil {
+		return
+	}
+
+	vp := ts.VolumeProfile(volumeProfileBucketCount)
+	if vp == nil {
+		return
+	}
+
+	// Use the same range as the prices, so the buckets line up with the price levels.
+	v.bars, v.pointOfControlLine = volumeProfileVAOs(vp, priceRange(ts.TradingSessions), data.PriceScale)
+
+	v.renderable = true
+}
+
+func (v *volumeProfile) SetBounds(bounds image.Rectangle) {
+	v.bounds = bounds
+}
+
+func (v *volumeProfile) Render(float32) {
+	if !v.renderable {
+		return
+	}
+
+	gfx.SetModelMatrixRect(v.bounds)
+	v.bars.Render()
+	v.pointOfControlLine.Render()
+}
+
+func (v *volumeProfile) Close() {
+	v.renderable = false
+	if v.bars != nil {
+		v.bars.Delete()
+		v.bars = nil
+	}
+	if v.pointOfControlLine != nil {
+		v.pointOfControlLine.Delete()
+		v.pointOfControlLine = nil
+	}
+}
+
+func volumeProfileVAOs(vp *model.VolumeProfile, priceRange [2]float32, scale PriceScale) (bars, pointOfControlLine *gfx.VAO) {
+	var maxVolume float32
+	for _, b := range vp.Buckets {
+		if b.Volume > maxVolume {
+			maxVolume = b.Volume
+		}
+	}
+
+	calcY := func(value float32) float32 {
+		return 2*pricePercent(priceRange, scale, value) - 1
+	}
+
+	var vertices []float32
+	var colors []float32
+	var indices []uint16
+
+	for i, b := range vp.Buckets {
+		// Leave a gap between the bars like the candlesticks.
+		botY, topY := calcY(b.Low), calcY(b.High)
+		gap := (topY - botY) * 0.1
+		botY, topY = botY+gap, topY-gap
+
+		rightX := float32(1)
+		leftX := rightX
+		if maxVolume > 0 {
+			leftX -= 2 * volumeProfileWidthPercent * b.Volume / maxVolume
+		}
+
+		c := volumeProfileColor
+		switch {
+		case i == vp.PointOfControl:
+			c = volumeProfilePointOfControlColor
+		case i >= vp.ValueAreaLow && i <= vp.ValueAreaHigh:
+			c = volumeProfileValueAreaColor
+		}
+
+		idxOffset := len(vertices) / 3
+		vertices = append(vertices,
+			leftX, topY, 0, // 0 - Upper left
+			rightX, topY, 0, // 1 - Upper right
+			leftX, botY, 0, // 2 - Bottom left
+			rightX, botY, 0, // 3 - Bottom right
+		)
+
+		for j := 0; j < 4; j++ {
+			colors = append(colors, c[0], c[1], c[2], c[3])
+		}
+
+		idx := func(j uint16) uint16 {
+			return uint16(idxOffset) + j
+		}
+
+		indices = append(indices,
+			idx(0), idx(2), idx(1),
+			idx(1), idx(2), idx(3),
+		)
+	}
+
+	bars = gfx.NewVAO(
+		&gfx.VAOVertexData{
+			Mode:     gfx.Triangles,
+			Vertices: vertices,
+			Colors:   colors,
+			Indices:  indices,
+		},
+	)
+
+	poc := vp.Buckets[vp.PointOfControl]
+	pocY := calcY((poc.Low + poc.High) / 2)
+	c := volumeProfilePointOfControlColor
+
+	pointOfControlLine = gfx.NewVAO(
+		&gfx.VAOVertexData{
+			Mode: gfx.Lines,
+			Vertices: []float32{
+				-1, pocY, 0,
+				1, pocY, 0,
+			},
+			Colors: []float32{
+				c[0], c[1], c[2], c[3],
+				c[0], c[1], c[2], c[3],
+			},
+			Indices: []uint16{0, 1},
+		},
+	)
+
+	return bars, pointOfControlLine
+}
@@ -62,3 +62,8 @@ func SourceUpdate(q *model.Quote) string {
 
 	return fmt.Sprintf("%s %s", ds, q.LatestUpdate.Format(l))
 }
+
+// PL returns a status line with the unrealized profit or loss in dollars and as a fraction like 0.1 for 10%.
+func PL(dollars, percent float32) string {
+	return fmt.Sprintf("P/L %+.2f (%+.2f%%)", dollars, percent*100)
+}
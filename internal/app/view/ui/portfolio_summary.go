@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/rect"
+	"github.com/btmura/ponzi2/internal/app/view/status"
+)
+
+// portfolioSummaryTextRenderer renders the lines of the portfolio summary.
+var portfolioSummaryTextRenderer = gfx.NewTextRenderer(goregular.TTF, 12)
+
+// Constants for laying out the portfolio summary like the thumbnails.
+const (
+	portfolioSummaryRounding = 6
+	portfolioSummaryPadding  = 5
+)
+
+// portfolioSummaryHeight is the height of the portfolio summary with its two lines.
+var portfolioSummaryHeight = 2*portfolioSummaryTextRenderer.LineHeight() + 3*portfolioSummaryPadding
+
+// PortfolioSummary is the value and unrealized profit or loss of the user's positions.
+type PortfolioSummary struct {
+	// Value is what the positions are worth at the latest prices.
+	Value float32
+
+	// PL is the unrealized profit or loss in dollars.
+	PL float32
+
+	// PercentPL is the unrealized profit or loss as a fraction like 0.1 for 10%.
+	PercentPL float32
+}
+
+// portfolioSummary shows the value and profit or loss of the user's positions at the top of the sidebar.
+type portfolioSummary struct {
+	// valueText is the text with the value of the positions. Empty if there is no summary.
+	valueText string
+
+	// plText is the text with the profit or loss of the positions.
+	plText string
+
+	// plColor is the color to render the profit or loss text.
+	plColor view.Color
+
+	// bubble is the border around the summary.
+	bubble *rect.Bubble
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+}
+
+func newPortfolioSummary() *portfolioSummary {
+	return &portfolioSummary{
+		bubble: rect.NewBubble(portfolioSummaryRounding),
+	}
+}
+
+// SetData sets the summary to show. Nil hides the summary.
+func (p *portfolioSummary) SetData(s *PortfolioSummary) {
+	if s == nil {
+		p.valueText = ""
+		p.plText = ""
+		return
+	}
+
+	p.valueText = fmt.Sprintf("Portfolio %.2f", s.Value)
+	p.plText = status.PL(s.PL, s.PercentPL)
+
+	switch {
+	case s.PL > 0:
+		p.plColor = view.Green
+	case s.PL < 0:
+		p.plColor = view.Red
+	default:
+		p.plColor = view.White
+	}
+}
+
+// Visible returns true if there is a summary to show.
+func (p *portfolioSummary) Visible() bool {
+	return p.valueText != ""
+}
+
+func (p *portfolioSummary) SetBounds(bounds image.Rectangle) {
+	p.bounds = bounds
+	p.bubble.SetBounds(bounds)
+}
+
+func (p *portfolioSummary) Render(fudge float32) {
+	if !p.Visible() || p.bounds.Empty() {
+		return
+	}
+
+	p.bubble.Render(fudge)
+
+	w := p.bounds.Dx() - 2*portfolioSummaryPadding
+	pt := image.Pt(p.bounds.Min.X+portfolioSummaryPadding, p.bounds.Max.Y-portfolioSummaryPadding-portfolioSummaryTextRenderer.LineHeight())
+	portfolioSummaryTextRenderer.Render(p.valueText, pt, gfx.TextColor(view.White), gfx.TextRenderMaxWidth(w))
+
+	pt.Y -= portfolioSummaryPadding + portfolioSummaryTextRenderer.LineHeight()
+	portfolioSummaryTextRenderer.Render(p.plText, pt, gfx.TextColor(p.plColor), gfx.TextRenderMaxWidth(w))
+}
@@ -2,9 +2,11 @@ package ui
 
 import (
 	"image"
+	"sort"
 
 	"github.com/btmura/ponzi2/internal/app/model"
 	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/animation"
 	"github.com/btmura/ponzi2/internal/app/view/chart"
 	"github.com/btmura/ponzi2/internal/app/view/rect"
 	"github.com/btmura/ponzi2/internal/logger"
@@ -23,6 +25,9 @@ var (
 
 	// bumperScrollAmount is how much to scroll when dragging over the bumpers which are the edges of the sidebar.
 	bumperScrollAmount = image.Pt(0, (thumbSize.Y+viewPadding)/4)
+
+	// slotMoveFrames is how many frames it takes for a slot to move to its new position after sorting.
+	slotMoveFrames = int(view.FPS / 3)
 )
 
 // sidebar manages the sidebar to display and edit stock thumbnails.
@@ -30,9 +35,27 @@ type sidebar struct {
 	// priceStyle is the style to create thumbnails with.
 	priceStyle chart.PriceStyle
 
-	// slots are slots which can have thumbnails or be a drop site.
+	// sortMode is how the slots are sorted. Slots can only be swapped when sorted manually.
+	sortMode model.SortMode
+
+	// sortButton shows the sort mode at the top of the sidebar.
+	sortButton *sidebarSortButton
+
+	// watchlistTabs are the tabs to switch between watchlists above the portfolio summary and slots.
+	watchlistTabs *watchlistTabs
+
+	// portfolioSummary is the summary of the user's positions above the slots.
+	portfolioSummary *portfolioSummary
+
+	// slots are slots which can have thumbnails or a section's header or be a drop site.
 	slots []*sidebarSlot
 
+	// sections are the groups of thumbnails in order. Thumbnails not in any section go at the end.
+	sections []*SidebarSection
+
+	// symbolOrder is the order of the thumbnails within their sections.
+	symbolOrder []string
+
 	// draggedSlot is the slot being dragged if not nil.
 	draggedSlot *draggedSidebarSlot
 
@@ -50,6 +73,21 @@ type sidebar struct {
 
 	// thumbClickCallback is called when a thumb is clicked.
 	thumbClickCallback func(symbol string)
+
+	// thumbPriceStyleButtonClickCallback is called when a thumb's price style button is clicked.
+	thumbPriceStyleButtonClickCallback func()
+
+	// thumbRSRatingClickCallback is called when a thumb's relative strength rating is clicked.
+	thumbRSRatingClickCallback func(symbol string)
+
+	// thumbAlertBadgeClickCallback is called when a thumb's alert badge is clicked.
+	thumbAlertBadgeClickCallback func(symbol string)
+
+	// thumbDropCallback is called when a thumb is dragged and released outside of the sidebar.
+	thumbDropCallback func(symbol string, pos image.Point)
+
+	// headerClickCallback is called with the name of the section when a section's header is clicked.
+	headerClickCallback func(name string)
 }
 
 // sidebarSlot is a slot in the sidebar that can contain thumbnails or be a drop site.
@@ -60,6 +98,21 @@ type sidebarSlot struct {
 	// thumbs are the thumbnails in the slot.
 	thumbs []*sidebarThumb
 
+	// header is the section's header in the slot instead of thumbnails. Nil for thumbnail slots.
+	header *sidebarHeader
+
+	// hidden is true if the slot is filtered out and should not be shown.
+	hidden bool
+
+	// collapsed is true if the slot's section is collapsed and it should not be shown.
+	collapsed bool
+
+	// moveOffset is where the slot is moving from relative to its bounds after sorting.
+	moveOffset image.Point
+
+	// move animates the slot from its move offset to its bounds.
+	move *animation.Animation
+
 	// Fader fades out the slot.
 	*view.Fader
 }
@@ -89,7 +142,12 @@ type draggedSidebarSlot struct {
 }
 
 func newSidebar() *sidebar {
-	return new(sidebar)
+	return &sidebar{
+		sortMode:         model.ManualSort,
+		sortButton:       newSidebarSortButton(),
+		watchlistTabs:    newWatchlistTabs(),
+		portfolioSummary: newPortfolioSummary(),
+	}
 }
 
 func (s *sidebar) SetPriceStyle(newPriceStyle chart.PriceStyle) {
@@ -122,6 +180,21 @@ func (s *sidebar) AddChartThumb(symbol string) (changed bool) {
 			s.thumbClickCallback(symbol)
 		}
 	})
+	thumb.SetPriceStyleButtonClickCallback(func() {
+		if s.thumbPriceStyleButtonClickCallback != nil {
+			s.thumbPriceStyleButtonClickCallback()
+		}
+	})
+	thumb.SetRSRatingClickCallback(func() {
+		if s.thumbRSRatingClickCallback != nil {
+			s.thumbRSRatingClickCallback(symbol)
+		}
+	})
+	thumb.SetAlertBadgeClickCallback(func() {
+		if s.thumbAlertBadgeClickCallback != nil {
+			s.thumbAlertBadgeClickCallback(symbol)
+		}
+	})
 
 	s.slots = append(s.slots, newSidebarSlot(symbol, thumb))
 	return true
@@ -144,6 +217,191 @@ func (s *sidebar) RemoveChartThumb(symbol string) (changed bool) {
 	return changed
 }
 
+// FilterChartThumbs hides the slots without a thumbnail that matches.
+// A nil match function shows all the slots.
+func (s *sidebar) FilterChartThumbs(match func(symbol string) bool) (changed bool) {
+	for _, slot := range s.slots {
+		// Keep showing the headers, so the sections can still be expanded and dragged into.
+		if slot.header != nil {
+			continue
+		}
+
+		hidden := match != nil
+		for _, thumb := range slot.thumbs {
+			if match == nil || match(thumb.symbol) {
+				hidden = false
+			}
+		}
+
+		if slot.hidden != hidden {
+			slot.hidden = hidden
+			changed = true
+		}
+	}
+	return changed
+}
+
+// SortChartThumbs reorders the slots within their sections to match the order of the given symbols.
+// Slots with symbols not in the list keep their relative order at the end of their sections.
+func (s *sidebar) SortChartThumbs(symbols []string) (changed bool) {
+	s.symbolOrder = symbols
+	return s.arrangeSlots()
+}
+
+// SetSections sets the sections to group the thumbnails under headers and reorders the slots to match.
+func (s *sidebar) SetSections(sections []*SidebarSection) (changed bool) {
+	s.sections = sections
+
+	headers := map[string]*sidebarSlot{}
+	for _, slot := range s.slots {
+		if slot.header != nil {
+			headers[slot.header.name] = slot
+		}
+	}
+
+	collapsed := map[string]bool{}
+	for i, sec := range sections {
+		for _, symbol := range sec.Symbols {
+			collapsed[symbol] = sec.Collapsed
+		}
+
+		// The first section is untitled and has no header.
+		if i == 0 {
+			continue
+		}
+
+		slot := headers[sec.Name]
+		if slot == nil {
+			slot = newSidebarHeaderSlot(sec.Name)
+			slot.header.SetClickCallback(func(name string) {
+				if s.headerClickCallback != nil {
+					s.headerClickCallback(name)
+				}
+			})
+			s.slots = append(s.slots, slot)
+			changed = true
+		}
+		slot.header.SetData(sec)
+		delete(headers, sec.Name)
+	}
+
+	// Remove the headers of removed sections right away, so the slots match the model's slots for swapping.
+	for i := 0; i < len(s.slots); i++ {
+		slot := s.slots[i]
+		if slot.header != nil && headers[slot.header.name] == slot {
+			s.slots = append(s.slots[:i], s.slots[i+1:]...)
+			slot.Close()
+			i--
+			changed = true
+		}
+	}
+
+	for _, slot := range s.slots {
+		for _, thumb := range slot.thumbs {
+			if slot.collapsed != collapsed[thumb.symbol] {
+				slot.collapsed = collapsed[thumb.symbol]
+				changed = true
+			}
+		}
+	}
+
+	if s.arrangeSlots() {
+		changed = true
+	}
+	return changed
+}
+
+// arrangeSlots orders the slots by section with each header followed by its thumbnails in the symbol order.
+func (s *sidebar) arrangeSlots() (changed bool) {
+	// Don't reorder the slots out from under a dragged slot.
+	if s.draggedSlot != nil {
+		return false
+	}
+
+	sectionIndex := map[string]int{}
+	headerIndex := map[string]int{}
+	for i, sec := range s.sections {
+		headerIndex[sec.Name] = i
+		for _, symbol := range sec.Symbols {
+			sectionIndex[symbol] = i
+		}
+	}
+
+	order := map[string]int{}
+	for i, symbol := range s.symbolOrder {
+		order[symbol] = i
+	}
+
+	// rank returns the slot's section and its position within the section where headers go first.
+	rank := func(slot *sidebarSlot) (section, pos int) {
+		if slot.header != nil {
+			return headerIndex[slot.header.name], -1
+		}
+
+		section, pos = len(s.sections), len(s.symbolOrder)
+		for _, thumb := range slot.thumbs {
+			if i, ok := sectionIndex[thumb.symbol]; ok {
+				section = i
+			}
+			if i, ok := order[thumb.symbol]; ok {
+				pos = i
+			}
+		}
+		return section, pos
+	}
+
+	sorted := make([]*sidebarSlot, len(s.slots))
+	copy(sorted, s.slots)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		si, pi := rank(sorted[i])
+		sj, pj := rank(sorted[j])
+		if si != sj {
+			return si < sj
+		}
+		return pi < pj
+	})
+
+	for i := range sorted {
+		if sorted[i] != s.slots[i] {
+			changed = true
+		}
+	}
+
+	if changed {
+		// Animate the shown slots from their old positions to their new positions.
+		oldPositions := shownSlotPositions(s.slots)
+		for slot, pos := range shownSlotPositions(sorted) {
+			if d := pos - oldPositions[slot]; d != 0 {
+				slot.moveOffset = image.Pt(0, d)
+				slot.move = animation.New(slotMoveFrames, animation.Started())
+			}
+		}
+	}
+
+	s.slots = sorted
+	return changed
+}
+
+// shownSlotPositions returns the distance of each slot from the top ignoring hidden slots.
+func shownSlotPositions(slots []*sidebarSlot) map[*sidebarSlot]int {
+	positions := map[*sidebarSlot]int{}
+	var pos int
+	for _, slot := range slots {
+		if !slot.shown() {
+			continue
+		}
+		positions[slot] = pos
+		pos += slot.height() + viewPadding
+	}
+	return positions
+}
+
+// SetSortMode sets how the slots are sorted to show it and disable swapping when not manual.
+func (s *sidebar) SetSortMode(mode model.SortMode) {
+	s.sortMode = mode
+	s.sortButton.SetSortMode(mode)
+}
+
 func (s *sidebar) SetLoading(symbol string) (changed bool) {
 	for _, slot := range s.slots {
 		for _, thumb := range slot.thumbs {
@@ -186,22 +444,55 @@ func (s *sidebar) SetErrorMessage(symbol string, errorMessage string) (changed b
 // ContentSize returns the size of the sidebar's contents like thumbnails
 // which could be less than the sidebar's bounds if there are not that many thumbnails.
 func (s *sidebar) ContentSize() image.Point {
-	num := len(s.slots)
-	if num == 0 {
-		return image.Pt(0, 0)
+	var num, height int
+	for _, slot := range s.slots {
+		if slot.shown() {
+			num++
+			height += slot.height()
+		}
+	}
+
+	if num > 0 {
+		if num > 1 {
+			// Add padding between thumbnails.
+			height += (num - 1) * viewPadding
+
+			// Add padding on top and bottom.
+			height += 2 * viewPadding
+		}
+	}
+
+	// Add the portfolio summary and the padding below it.
+	if s.portfolioSummary.Visible() {
+		height += portfolioSummaryHeight + viewPadding
 	}
 
-	height := num * thumbSize.Y
-	if num > 1 {
-		// Add padding between thumbnails.
-		height += (num - 1) * viewPadding
+	// Add the watchlist tabs and the padding below them.
+	if s.watchlistTabs.Visible() {
+		height += s.watchlistTabs.Height(thumbSize.X) + viewPadding
+	}
 
-		// Add padding on top and bottom.
-		height += 2 * viewPadding
+	// Add the sort button and the padding below it.
+	if len(s.slots) > 0 {
+		height += sidebarSortButtonHeight + viewPadding
+	}
+
+	if height == 0 {
+		return image.Pt(0, 0)
 	}
 	return image.Pt(thumbSize.X, height)
 }
 
+// SetWatchlists sets the names of the watchlists to show as tabs and the current one.
+func (s *sidebar) SetWatchlists(names []string, current string) {
+	s.watchlistTabs.SetData(names, current)
+}
+
+// SetPortfolioSummary sets the summary of the user's positions above the thumbnails. Nil hides it.
+func (s *sidebar) SetPortfolioSummary(summary *PortfolioSummary) {
+	s.portfolioSummary.SetData(summary)
+}
+
 func (s *sidebar) SetBounds(bounds image.Rectangle) {
 	s.bounds = bounds
 }
@@ -218,10 +509,17 @@ func (s *sidebar) ProcessInput(input *view.Input) {
 	// Set each slot's bounds on the screen.
 	s.setSlotBounds()
 
+	// Cycle the sort mode if the sort button was clicked.
+	s.sortButton.ProcessInput(input)
+
+	// Switch watchlists if a tab was clicked.
+	s.watchlistTabs.ProcessInput(input)
+
 	// Find the slot being dragged and update its position.
 	wasDragging := s.draggedSlot != nil
 	s.setDraggedSlot(input)
 	swappedIndices := s.swapDraggedSlot(input)
+	s.fireDropCallback(input)
 	stillDragging := s.draggedSlot != nil
 
 	// Absorb mouse event if dragging was released or still dragging.
@@ -231,6 +529,9 @@ func (s *sidebar) ProcessInput(input *view.Input) {
 
 	// Forward the input to the individual slots.
 	for _, slot := range s.slots {
+		if !slot.shown() {
+			continue
+		}
 		slot.ProcessInput(input)
 	}
 
@@ -320,12 +621,39 @@ func (s *sidebar) setSlotBounds() {
 	)
 	slotBounds = slotBounds.Sub(image.Pt(0, s.scrollOffset))
 
+	// Put the sort button at the top if there are slots to sort.
+	s.sortButton.SetBounds(image.Rectangle{})
+	if len(s.slots) > 0 {
+		s.sortButton.SetBounds(image.Rect(slotBounds.Min.X, slotBounds.Max.Y-sidebarSortButtonHeight, slotBounds.Max.X, slotBounds.Max.Y))
+		slotBounds = slotBounds.Sub(image.Pt(0, sidebarSortButtonHeight+viewPadding))
+	}
+
+	// Put the watchlist tabs at the top, so they scroll with the slots.
+	if s.watchlistTabs.Visible() {
+		h := s.watchlistTabs.Height(slotBounds.Dx())
+		s.watchlistTabs.SetBounds(image.Rect(slotBounds.Min.X, slotBounds.Max.Y-h, slotBounds.Max.X, slotBounds.Max.Y))
+		slotBounds = slotBounds.Sub(image.Pt(0, h+viewPadding))
+	}
+
+	// Put the portfolio summary above the slots, so it scrolls with them.
+	if s.portfolioSummary.Visible() {
+		summaryBounds := image.Rect(slotBounds.Min.X, slotBounds.Max.Y-portfolioSummaryHeight, slotBounds.Max.X, slotBounds.Max.Y)
+		s.portfolioSummary.SetBounds(summaryBounds)
+		slotBounds = slotBounds.Sub(image.Pt(0, portfolioSummaryHeight+viewPadding))
+	}
+
 	for _, slot := range s.slots {
-		slot.bounds = slotBounds
-		for _, thumb := range slot.thumbs {
-			thumb.SetBounds(slotBounds)
+		// Give hidden slots empty bounds, so they cannot be clicked, dragged, or swapped with.
+		if !slot.shown() {
+			slot.setBounds(image.Rectangle{})
+			continue
 		}
-		slotBounds = slotBounds.Sub(image.Pt(0, thumbSize.Y+viewPadding))
+
+		// Shrink the bounds from the top to fit headers.
+		h := slot.height()
+		slotBounds.Min.Y = slotBounds.Max.Y - h
+		slot.setBounds(slotBounds)
+		slotBounds = slotBounds.Sub(image.Pt(0, h+viewPadding))
 	}
 }
 
@@ -341,11 +669,19 @@ func (s *sidebar) setDraggedSlot(input *view.Input) {
 	}
 
 	for _, slot := range s.slots {
+		// Only drag thumbnails, so the sections stay in order.
+		if slot.header != nil {
+			continue
+		}
+
 		if s.draggedSlot == nil && input.MouseLeftButtonDragging.PressedIn(slot.bounds) {
 			s.draggedSlot = &draggedSidebarSlot{
 				sidebarSlot:      slot,
 				mousePressOffset: input.MouseLeftButtonDragging.CurrentPos.Sub(rect.CenterPoint(slot.bounds)),
 			}
+
+			// Stop moving the slot from sorting, so it follows the mouse.
+			slot.move = nil
 		}
 	}
 }
@@ -395,6 +731,11 @@ func (s *sidebar) swapDraggedSlot(input *view.Input) (swappedIndexes [2]int) {
 		return noSwappedIndices
 	}
 
+	// Only swap when sorted manually, since the other modes decide the order.
+	if s.sortMode != model.ManualSort {
+		return noSwappedIndices
+	}
+
 	// Find the index of the dragged slot.
 	draggedSlotIndex := -1
 	for i := range s.slots {
@@ -445,6 +786,34 @@ func (s *sidebar) fireSwapCallback(input *view.Input, swappedIndices [2]int) {
 	})
 }
 
+// fireDropCallback schedules the drop callback if the dragged slot was released outside the sidebar.
+func (s *sidebar) fireDropCallback(input *view.Input) {
+	if input == nil {
+		logger.Error("input should not be nil")
+		return
+	}
+
+	if s.draggedSlot == nil || input.MouseLeftButtonDragging == nil {
+		return
+	}
+
+	pos := input.MouseLeftButtonDragging.ReleasedPos
+	if pos == nil || pos.In(s.bounds) {
+		return
+	}
+
+	if s.thumbDropCallback == nil {
+		return
+	}
+
+	for _, thumb := range s.draggedSlot.thumbs {
+		symbol, pt := thumb.symbol, pos.Point
+		input.AddFiredCallback(func() {
+			s.thumbDropCallback(symbol, pt)
+		})
+	}
+}
+
 // Update moves the animation one step forward.
 func (s *sidebar) Update() (dirty bool) {
 	for i := 0; i < len(s.slots); i++ {
@@ -463,9 +832,13 @@ func (s *sidebar) Update() (dirty bool) {
 
 // Render renders a frame.
 func (s *sidebar) Render(fudge float32) {
+	s.sortButton.Render(fudge)
+	s.watchlistTabs.Render(fudge)
+	s.portfolioSummary.Render(fudge)
+
 	// Draw the non-dragged thumbnails first, so they appear under the dragged thumbnail.
 	for _, slot := range s.slots {
-		if s.draggedSlot != nil && s.draggedSlot.sidebarSlot == slot {
+		if !slot.shown() || s.draggedSlot != nil && s.draggedSlot.sidebarSlot == slot {
 			continue
 		}
 		slot.Render(fudge)
@@ -488,10 +861,45 @@ func (s *sidebar) SetThumbClickCallback(cb func(symbol string)) {
 	s.thumbClickCallback = cb
 }
 
+func (s *sidebar) SetThumbPriceStyleButtonClickCallback(cb func()) {
+	s.thumbPriceStyleButtonClickCallback = cb
+}
+
+func (s *sidebar) SetThumbRSRatingClickCallback(cb func(symbol string)) {
+	s.thumbRSRatingClickCallback = cb
+}
+
+func (s *sidebar) SetThumbAlertBadgeClickCallback(cb func(symbol string)) {
+	s.thumbAlertBadgeClickCallback = cb
+}
+
+func (s *sidebar) SetThumbDropCallback(cb func(symbol string, pos image.Point)) {
+	s.thumbDropCallback = cb
+}
+
+func (s *sidebar) SetWatchlistTabClickCallback(cb func(name string)) {
+	s.watchlistTabs.SetClickCallback(cb)
+}
+
+func (s *sidebar) SetSortButtonClickCallback(cb func()) {
+	s.sortButton.SetClickCallback(cb)
+}
+
+func (s *sidebar) SetHeaderClickCallback(cb func(name string)) {
+	s.headerClickCallback = cb
+}
+
 func (s *sidebar) Close() {
 	s.slotSwapCallback = nil
 	s.thumbRemoveButtonClickCallback = nil
 	s.thumbClickCallback = nil
+	s.thumbPriceStyleButtonClickCallback = nil
+	s.thumbRSRatingClickCallback = nil
+	s.thumbAlertBadgeClickCallback = nil
+	s.thumbDropCallback = nil
+	s.headerClickCallback = nil
+	s.watchlistTabs.Close()
+	s.sortButton.Close()
 }
 
 func newSidebarSlot(symbol string, thumb *chart.Thumb) *sidebarSlot {
@@ -501,12 +909,47 @@ func newSidebarSlot(symbol string, thumb *chart.Thumb) *sidebarSlot {
 	}
 }
 
+func newSidebarHeaderSlot(name string) *sidebarSlot {
+	return &sidebarSlot{
+		header: newSidebarHeader(name),
+		Fader:  view.NewStartedFader(1 * view.FPS),
+	}
+}
+
+// shown returns true if the slot is neither filtered out nor in a collapsed section.
+func (s *sidebarSlot) shown() bool {
+	return !s.hidden && !s.collapsed
+}
+
+// height returns the height of the slot's header or thumbnails.
+func (s *sidebarSlot) height() int {
+	if s.header != nil {
+		return sidebarHeaderHeight
+	}
+	return thumbSize.Y
+}
+
+// setBounds sets the bounds of the slot and its header or thumbnails.
+func (s *sidebarSlot) setBounds(bounds image.Rectangle) {
+	s.bounds = bounds
+	if s.header != nil {
+		s.header.SetBounds(bounds)
+	}
+	for _, thumb := range s.thumbs {
+		thumb.SetBounds(bounds)
+	}
+}
+
 func (s *sidebarSlot) ProcessInput(input *view.Input) {
 	if input == nil {
 		logger.Error("input should not be nil")
 		return
 	}
 
+	if s.header != nil {
+		s.header.ProcessInput(input)
+	}
+
 	for _, thumb := range s.thumbs {
 		thumb.ProcessInput(input)
 	}
@@ -521,11 +964,29 @@ func (s *sidebarSlot) Update() (dirty bool) {
 	if s.Fader.Update() {
 		dirty = true
 	}
+	if s.move != nil && s.move.Update() {
+		dirty = true
+	}
 	return dirty
 }
 
 func (s *sidebarSlot) Render(fudge float32) {
+	// Draw the slot between its old and new positions if it is moving after sorting.
+	if s.move != nil && s.move.Animating() {
+		p := 1 - s.move.Value(fudge)
+		b := s.bounds.Add(image.Pt(int(float32(s.moveOffset.X)*p), int(float32(s.moveOffset.Y)*p)))
+		if s.header != nil {
+			s.header.SetBounds(b)
+		}
+		for _, thumb := range s.thumbs {
+			thumb.SetBounds(b)
+		}
+	}
+
 	s.Fader.Render(fudge, func() {
+		if s.header != nil {
+			s.header.Render(fudge)
+		}
 		for _, thumb := range s.thumbs {
 			thumb.Render(fudge)
 		}
@@ -533,6 +994,9 @@ func (s *sidebarSlot) Render(fudge float32) {
 }
 
 func (s *sidebarSlot) Close() {
+	if s.header != nil {
+		s.header.Close()
+	}
 	for _, thumb := range s.thumbs {
 		thumb.Close()
 	}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/view"
+)
+
+// sidebarHeaderHeight is the height of a section's header that is the same as a row of watchlist tabs.
+var sidebarHeaderHeight = watchlistTabHeight
+
+// SidebarSection is a titled group of thumbnails in the sidebar.
+type SidebarSection struct {
+	// Name is the name of the section. Empty for the untitled section above the others without a header.
+	Name string
+
+	// Collapsed is true if the section's thumbnails are hidden.
+	Collapsed bool
+
+	// Symbols are the symbols of the thumbnails in the section.
+	Symbols []string
+
+	// AverageChange is the average percent change of the section's stocks as a fraction like 0.01 for 1%.
+	AverageChange float32
+
+	// HasAverageChange is true if any of the section's stocks have quotes to average.
+	HasAverageChange bool
+}
+
+// sidebarHeader shows a section's name and average change and collapses or expands the section when clicked.
+type sidebarHeader struct {
+	// name is the name of the section.
+	name string
+
+	// text is the name with an arrow showing whether the section is collapsed.
+	text string
+
+	// changeText is the average percent change. Empty if unknown.
+	changeText string
+
+	// changeColor is the color to render the average change.
+	changeColor view.Color
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+
+	// clickCallback is called with the name of the section when the header is clicked.
+	clickCallback func(name string)
+}
+
+func newSidebarHeader(name string) *sidebarHeader {
+	return &sidebarHeader{name: name}
+}
+
+// SetData sets the section to show.
+func (h *sidebarHeader) SetData(s *SidebarSection) {
+	h.text = "▼ " + s.Name
+	if s.Collapsed {
+		h.text = "▶ " + s.Name
+	}
+
+	h.changeText = ""
+	if s.HasAverageChange {
+		h.changeText = fmt.Sprintf("%+.2f%%", s.AverageChange*100)
+	}
+
+	switch {
+	case s.AverageChange > 0:
+		h.changeColor = view.Green
+	case s.AverageChange < 0:
+		h.changeColor = view.Red
+	default:
+		h.changeColor = view.White
+	}
+}
+
+func (h *sidebarHeader) SetBounds(bounds image.Rectangle) {
+	h.bounds = bounds
+}
+
+func (h *sidebarHeader) ProcessInput(input *view.Input) {
+	if !input.MouseLeftButtonClicked.In(h.bounds) {
+		return
+	}
+
+	name := h.name
+	input.AddFiredCallback(func() {
+		if h.clickCallback != nil {
+			h.clickCallback(name)
+		}
+	})
+	input.ClearMouseInput()
+}
+
+func (h *sidebarHeader) Render(fudge float32) {
+	if h.bounds.Empty() {
+		return
+	}
+
+	pt := image.Pt(h.bounds.Min.X+watchlistTabPadding, h.bounds.Min.Y+watchlistTabPadding)
+	w := h.bounds.Dx() - 2*watchlistTabPadding
+
+	if h.changeText != "" {
+		cw := watchlistTabTextRenderer.Measure(h.changeText).X
+		watchlistTabTextRenderer.Render(h.changeText, image.Pt(h.bounds.Max.X-watchlistTabPadding-cw, pt.Y), gfx.TextColor(h.changeColor))
+		w -= cw + watchlistTabPadding
+	}
+
+	watchlistTabTextRenderer.Render(h.text, pt, gfx.TextColor(view.White), gfx.TextRenderMaxWidth(w))
+}
+
+// SetClickCallback sets the callback for when the header is clicked.
+func (h *sidebarHeader) SetClickCallback(cb func(name string)) {
+	h.clickCallback = cb
+}
+
+func (h *sidebarHeader) Close() {
+	h.clickCallback = nil
+}
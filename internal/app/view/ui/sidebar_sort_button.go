@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"image"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/app/view"
+)
+
+// sidebarSortButtonHeight is the height of the sort button that is the same as a row of watchlist tabs.
+var sidebarSortButtonHeight = watchlistTabHeight
+
+// sidebarSortButton shows how the sidebar is sorted and cycles through the sort modes when clicked.
+type sidebarSortButton struct {
+	// text is the label with the sort mode like "Sort: symbol".
+	text string
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+
+	// clickCallback is called when the button is clicked.
+	clickCallback func()
+}
+
+func newSidebarSortButton() *sidebarSortButton {
+	return &sidebarSortButton{
+		text: "Sort: " + model.ManualSort.String(),
+	}
+}
+
+// SetSortMode sets the sort mode to show.
+func (b *sidebarSortButton) SetSortMode(mode model.SortMode) {
+	b.text = "Sort: " + mode.String()
+}
+
+func (b *sidebarSortButton) SetBounds(bounds image.Rectangle) {
+	b.bounds = bounds
+}
+
+func (b *sidebarSortButton) ProcessInput(input *view.Input) {
+	if !input.MouseLeftButtonClicked.In(b.bounds) {
+		return
+	}
+
+	input.AddFiredCallback(func() {
+		if b.clickCallback != nil {
+			b.clickCallback()
+		}
+	})
+	input.ClearMouseInput()
+}
+
+func (b *sidebarSortButton) Render(fudge float32) {
+	if b.bounds.Empty() {
+		return
+	}
+
+	pt := image.Pt(b.bounds.Min.X+watchlistTabPadding, b.bounds.Min.Y+watchlistTabPadding)
+	watchlistTabTextRenderer.Render(b.text, pt, gfx.TextColor(view.LightGray), gfx.TextRenderMaxWidth(b.bounds.Dx()-2*watchlistTabPadding))
+}
+
+// SetClickCallback sets the callback for when the button is clicked.
+func (b *sidebarSortButton) SetClickCallback(cb func()) {
+	b.clickCallback = cb
+}
+
+func (b *sidebarSortButton) Close() {
+	b.clickCallback = nil
+}
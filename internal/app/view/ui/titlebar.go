@@ -33,6 +33,11 @@ func (t *titleBar) SetData(data chart.Data) {
 	t.text = status.Join(data.Symbol, status.Paren(q.CompanyName), status.PriceChange(q), status.SourceUpdate(q), "-", appName)
 }
 
+// Clear resets the title bar to show only the app name.
+func (t *titleBar) Clear() {
+	t.text = appName
+}
+
 // Render renders the title bar.
 func (t *titleBar) Render(float32) {
 	t.win.SetTitle(t.text)
@@ -4,9 +4,11 @@ package ui
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"image"
 	"image/png"
 	"runtime"
+	"time"
 	"unicode"
 
 	"github.com/go-gl/gl/v4.5-core/gl"
@@ -46,6 +48,46 @@ var acceptedChars = map[rune]bool{
 	'Y': true, 'Z': true,
 }
 
+// acceptedPrefixChars are the chars the user can enter before a symbol
+// to add or remove it as a comparison on the main chart.
+// The filter prefix starts a filter expression for the sidebar instead of a symbol.
+// The alert prefix starts an alert spec for the main chart's symbol.
+// The position prefix starts a lot like "10 @ 150.25" for the main chart's symbol.
+// The journal prefix starts a trade like "buy 10 @ 150.25" for the main chart's symbol.
+// The watchlist prefix starts the name of a watchlist to show in the sidebar.
+// The layout prefix starts a layout like "2x2" for the charts in the main area.
+var acceptedPrefixChars = map[rune]bool{
+	'+': true, '-': true, filterPrefix: true, alertPrefix: true, positionPrefix: true, journalPrefix: true, watchlistPrefix: true, layoutPrefix: true, drawPrefix: true,
+}
+
+// freeTextPrefixChars are the prefix chars that start free text instead of a symbol.
+var freeTextPrefixChars = map[rune]bool{
+	filterPrefix: true, alertPrefix: true, positionPrefix: true, journalPrefix: true, watchlistPrefix: true, layoutPrefix: true, drawPrefix: true,
+}
+
+// filterPrefix is the char the user enters before a filter expression for the sidebar.
+const filterPrefix = '?'
+
+// alertPrefix is the char the user enters before an alert spec like "above 150".
+const alertPrefix = '!'
+
+// positionPrefix is the char the user enters before a lot like "10 @ 150.25" or a command like "import lots.csv".
+const positionPrefix = '$'
+
+// journalPrefix is the char the user enters before a trade like "buy 10 @ 150.25 note" or a command like "export trades.csv".
+const journalPrefix = '#'
+
+// watchlistPrefix is the char the user enters before a watchlist name like "Leaders" or a command like "rename Holdings".
+const watchlistPrefix = '@'
+
+// layoutPrefix is the char the user enters before a chart layout like "1", "2", "2v", "2x2", or "3x3"
+// or "link" to toggle showing the current stock's daily and weekly charts together.
+const layoutPrefix = '='
+
+// drawPrefix is the char the user enters before a drawing tool like "line", "ray", or "level"
+// or a command like "off" or "clear".
+const drawPrefix = '/'
+
 // Constants used by Run for the "game loop".
 const (
 	updateSec  = 1.0 / view.FPS
@@ -65,6 +107,9 @@ const inputSymbolBubbleRounding = 10
 
 var inputSymbolTextRenderer = gfx.NewTextRenderer(goregular.TTF, 48)
 
+// alertBannerTextRenderer renders the message of the last triggered alert.
+var alertBannerTextRenderer = gfx.NewTextRenderer(goregular.TTF, 24)
+
 func init() {
 	// This is needed to arrange that main() runs on main thread for GLFW.
 	// See documentation for functions that are only allowed to be called
@@ -74,14 +119,23 @@ func init() {
 
 // The UI renders the UI to view and edit the model's stocks that it observes.
 type UI struct {
-	// symbolToChartMap maps symbol to chart. Only one entry right now.
-	symbolToChartMap map[string]*chart.Chart
-
 	// titleBar renders the window titleBar.
 	titleBar *titleBar
 
-	// charts renders the charts in the main area.
-	charts []*uiChart
+	// chartLayout is how the charts in the main area are arranged.
+	chartLayout model.ChartLayout
+
+	// chartCells are the charts in the main area from the top left.
+	chartCells []*chartCell
+
+	// focusedChartCell is the index of the chart cell that the entered symbols and commands apply to.
+	focusedChartCell int
+
+	// focusBubble highlights the focused chart when there is more than one chart.
+	focusBubble *rect.Bubble
+
+	// drawingTool is the kind of drawing created by dragging over empty space on a chart's prices.
+	drawingTool chart.DrawingKind
 
 	// sidebar is the sidebar of chart thumbnails on the side.
 	sidebar *sidebar
@@ -92,18 +146,39 @@ type UI struct {
 	// inputSymbolTextBox stores and renders the symbol being entered by the user.
 	inputSymbolTextBox *text.Box
 
+	// alertBannerTextBox renders the message of the last triggered alert over the main chart.
+	alertBannerTextBox *text.Box
+
 	// inputSymbolSubmittedCallback is called when a new symbol is entered.
 	inputSymbolSubmittedCallback func(symbol string)
 
 	// sidebarSlotSwapCallback is called when the sidebar changes.
 	sidebarSlotSwapCallback func(i, j int)
 
-	// chartZoomChangeCallback is called when the chart is zoomed in or out.
-	chartZoomChangeCallback func(zoomChange chart.ZoomChange)
+	// chartZoomChangeCallback is called with the index of the chart cell when a chart is zoomed in or out.
+	chartZoomChangeCallback func(i int, zoomChange chart.ZoomChange)
+
+	// chartCellClickCallback is called with the index of the chart cell when an unfocused chart is clicked.
+	chartCellClickCallback func(i int)
 
-	// chartPriceStyleButtonClickCallback is called when the bar or candlestick buttons are clicked.
+	// chartDrawingsChangeCallback is called with a chart's symbol and drawings when a drawing is created, moved, or deleted.
+	chartDrawingsChangeCallback func(symbol string, drawings []*chart.Drawing)
+
+	// chartPriceStyleButtonClickCallback is called when the main chart's price style buttons are clicked.
 	chartPriceStyleButtonClickCallback func(priceStyle chart.PriceStyle)
 
+	// chartMovingAverageToggleCallback is called when a main chart's moving average toggle is clicked.
+	chartMovingAverageToggleCallback func(i int)
+
+	// chartLogScaleButtonClickCallback is called when the main chart's log scale button is clicked.
+	chartLogScaleButtonClickCallback func()
+
+	// chartVolumeProfileButtonClickCallback is called when the main chart's volume profile button is clicked.
+	chartVolumeProfileButtonClickCallback func()
+
+	// chartMACDButtonClickCallback is called when the main chart's MACD button is clicked.
+	chartMACDButtonClickCallback func()
+
 	// chartRefreshButtonClickCallback is called when the main chart's refresh button is clicked.
 	chartRefreshButtonClickCallback func(symbol string)
 
@@ -116,6 +191,27 @@ type UI struct {
 	// thumbClickCallback is called when a thumb is clicked.
 	thumbClickCallback func(symbol string)
 
+	// thumbPriceStyleButtonClickCallback is called when a thumb's price style button is clicked.
+	thumbPriceStyleButtonClickCallback func()
+
+	// thumbRSRatingClickCallback is called when a thumb's relative strength rating is clicked.
+	thumbRSRatingClickCallback func(symbol string)
+
+	// chartThumbDropCallback is called when a thumb is dragged from the sidebar onto the main chart.
+	chartThumbDropCallback func(symbol string)
+
+	// thumbAlertBadgeClickCallback is called when a thumb's alert badge is clicked.
+	thumbAlertBadgeClickCallback func(symbol string)
+
+	// watchlistTabClickCallback is called when a watchlist tab above the sidebar is clicked.
+	watchlistTabClickCallback func(name string)
+
+	// sidebarSortButtonClickCallback is called when the sort button at the top of the sidebar is clicked.
+	sidebarSortButtonClickCallback func()
+
+	// sidebarHeaderClickCallback is called with the name of the section when a sidebar section's header is clicked.
+	sidebarHeaderClickCallback func(name string)
+
 	// win is the handle to the GLFW window.
 	win *glfw.Window
 
@@ -176,15 +272,63 @@ func (u *uiChart) Render(fudge float32) {
 	})
 }
 
+// chartCell is one of the charts in the main area's layout.
+type chartCell struct {
+	// symbol is the symbol of the stock shown in the cell. Empty if the cell is empty.
+	symbol string
+
+	// chart is the cell's current chart. Nil if the cell is empty.
+	chart *chart.Chart
+
+	// data is the current chart's data to show in the title bar when the cell is focused.
+	data chart.Data
+
+	// charts are the current chart and the old charts that are fading out.
+	charts []*uiChart
+}
+
+// Close closes the cell's charts.
+func (c *chartCell) Close() {
+	for _, ch := range c.charts {
+		ch.Close()
+	}
+	c.charts = nil
+	c.chart = nil
+}
+
+// chartCellBounds divides the main area into the layout's rows and columns of charts
+// from the top left with padding between them.
+func chartCellBounds(bounds image.Rectangle, layout model.ChartLayout) []image.Rectangle {
+	rows, cols := layout.Rows(), layout.Columns()
+	w := (bounds.Dx() - (cols-1)*viewPadding) / cols
+	h := (bounds.Dy() - (rows-1)*viewPadding) / rows
+
+	var rs []image.Rectangle
+	for r := 0; r < rows; r++ {
+		maxY := bounds.Max.Y - r*(h+viewPadding)
+		for c := 0; c < cols; c++ {
+			minX := bounds.Min.X + c*(w+viewPadding)
+			rs = append(rs, image.Rect(minX, maxY-h, minX+w, maxY))
+		}
+	}
+	return rs
+}
+
 // New creates a new UI.
 func New() *UI {
 	return &UI{
-		symbolToChartMap:    map[string]*chart.Chart{},
+		chartLayout:         model.SingleChart,
+		chartCells:          []*chartCell{{}},
+		focusBubble:         rect.NewBubble(inputSymbolBubbleRounding),
 		sidebar:             newSidebar(),
 		instructionsTextBox: text.NewBox(gfx.NewTextRenderer(goregular.TTF, 24), "Type in symbol and press ENTER..."),
 		inputSymbolTextBox: text.NewBox(inputSymbolTextRenderer, "",
 			text.Bubble(rect.NewBubble(inputSymbolBubbleRounding)),
 			text.Padding(viewPadding)),
+		alertBannerTextBox: text.NewBox(alertBannerTextRenderer, "",
+			text.Color(view.Yellow),
+			text.Bubble(rect.NewBubble(inputSymbolBubbleRounding)),
+			text.Padding(viewPadding)),
 	}
 }
 
@@ -279,6 +423,51 @@ func (u *UI) Init(_ context.Context) (cleanup func(), err error) {
 		}
 	})
 
+	u.sidebar.SetThumbPriceStyleButtonClickCallback(func() {
+		if u.thumbPriceStyleButtonClickCallback != nil {
+			u.thumbPriceStyleButtonClickCallback()
+		}
+	})
+
+	u.sidebar.SetThumbRSRatingClickCallback(func(symbol string) {
+		if u.thumbRSRatingClickCallback != nil {
+			u.thumbRSRatingClickCallback(symbol)
+		}
+	})
+
+	u.sidebar.SetThumbAlertBadgeClickCallback(func(symbol string) {
+		if u.thumbAlertBadgeClickCallback != nil {
+			u.thumbAlertBadgeClickCallback(symbol)
+		}
+	})
+
+	u.sidebar.SetWatchlistTabClickCallback(func(name string) {
+		if u.watchlistTabClickCallback != nil {
+			u.watchlistTabClickCallback(name)
+		}
+	})
+
+	u.sidebar.SetSortButtonClickCallback(func() {
+		if u.sidebarSortButtonClickCallback != nil {
+			u.sidebarSortButtonClickCallback()
+		}
+	})
+
+	u.sidebar.SetHeaderClickCallback(func(name string) {
+		if u.sidebarHeaderClickCallback != nil {
+			u.sidebarHeaderClickCallback(name)
+		}
+	})
+
+	u.sidebar.SetThumbDropCallback(func(symbol string, pos image.Point) {
+		if u.chartCells[u.focusedChartCell].chart == nil || !pos.In(u.metrics().chartCellBounds[u.focusedChartCell]) {
+			return
+		}
+		if u.chartThumbDropCallback != nil {
+			u.chartThumbDropCallback(symbol)
+		}
+	})
+
 	return func() { glfw.Terminate() }, nil
 }
 
@@ -371,14 +560,14 @@ func (u *UI) handleScrollEvent(yoff float64) {
 	}
 }
 
-func (u *UI) handleChartZoomChangeEvent(zoomChange chart.ZoomChange) {
+func (u *UI) handleChartZoomChangeEvent(i int, zoomChange chart.ZoomChange) {
 	if zoomChange == chart.ZoomChangeUnspecified {
 		logger.Error("unspecified chart zoom change")
 		return
 	}
 
 	if u.chartZoomChangeCallback != nil {
-		u.chartZoomChangeCallback(zoomChange)
+		u.chartZoomChangeCallback(i, zoomChange)
 	}
 }
 
@@ -504,20 +693,36 @@ func (u *UI) prepareInput() *view.Input {
 func (u *UI) processInput(input *view.Input) (dirty bool) {
 	m := u.metrics()
 
-	u.instructionsTextBox.SetBounds(m.chartBounds)
+	u.instructionsTextBox.SetBounds(m.chartCellBounds[u.focusedChartCell])
 	u.inputSymbolTextBox.SetBounds(m.winBounds)
 
 	u.updateInputSymbolTextBox(input)
+	u.updateAlertBannerTextBox(input, m)
 
 	u.sidebar.SetBounds(m.sidebarBounds)
 	u.sidebar.ProcessInput(input)
 
-	for i := 0; i < len(u.charts); i++ {
-		c := u.charts[i]
-		c.SetBounds(m.chartBounds)
-		c.ProcessInput(input)
+	for i, cell := range u.chartCells {
+		bounds := m.chartCellBounds[i]
+
+		// Focus the clicked chart without consuming the click, so its buttons still work.
+		if i != u.focusedChartCell && input.MouseLeftButtonClicked.In(bounds) {
+			i := i
+			input.AddFiredCallback(func() {
+				if u.chartCellClickCallback != nil {
+					u.chartCellClickCallback(i)
+				}
+			})
+		}
+
+		for _, c := range cell.charts {
+			c.SetBounds(bounds)
+			c.ProcessInput(input)
+		}
 	}
 
+	u.linkChartCursors()
+
 	for _, cb := range input.FiredCallbacks() {
 		cb()
 	}
@@ -525,12 +730,54 @@ func (u *UI) processInput(input *view.Input) (dirty bool) {
 	return len(input.FiredCallbacks()) != 0
 }
 
+// linkChartCursors marks the sessions in the charts that overlap the session hovered in another chart
+// of the same symbol, like the weekly bar that contains a hovered daily bar.
+func (u *UI) linkChartCursors() {
+	var hovered *chartCell
+	var start, end time.Time
+	for _, cell := range u.chartCells {
+		if cell.chart == nil {
+			continue
+		}
+		if s, e, ok := cell.chart.HoveredSpan(); ok {
+			hovered, start, end = cell, s, e
+			break
+		}
+	}
+
+	for _, cell := range u.chartCells {
+		if cell.chart == nil {
+			continue
+		}
+		if hovered == nil || cell == hovered || cell.symbol != hovered.symbol {
+			cell.chart.SetLinkedSpan(time.Time{}, time.Time{})
+			continue
+		}
+		cell.chart.SetLinkedSpan(start, end)
+	}
+}
+
 func (u *UI) updateInputSymbolTextBox(input *view.Input) {
 	b := u.inputSymbolTextBox
 
 	if char := input.KeyReleased.GetChar(); char != 0 {
-		char = unicode.ToUpper(char)
-		if _, ok := acceptedChars[char]; !ok {
+		// Accept any printable char in free text like filter expressions and do not uppercase it.
+		var freeText bool
+		if t := b.Text(); t != "" {
+			freeText = freeTextPrefixChars[rune(t[0])]
+		}
+		if !freeText {
+			char = unicode.ToUpper(char)
+		}
+
+		_, ok := acceptedChars[char]
+		if !ok && b.Text() == "" {
+			_, ok = acceptedPrefixChars[char]
+		}
+		if !ok && freeText {
+			ok = unicode.IsPrint(char)
+		}
+		if !ok {
 			return
 		}
 
@@ -561,16 +808,32 @@ func (u *UI) updateInputSymbolTextBox(input *view.Input) {
 	}
 }
 
+// updateAlertBannerTextBox lays out the alert banner along the bottom of the main chart and dismisses it when clicked.
+func (u *UI) updateAlertBannerTextBox(input *view.Input, m viewMetrics) {
+	b := u.alertBannerTextBox
+
+	cb := m.chartBounds
+	bounds := image.Rect(cb.Min.X, cb.Min.Y, cb.Max.X, cb.Min.Y+alertBannerTextRenderer.LineHeight()+viewPadding*4)
+	b.SetBounds(bounds)
+
+	if b.Text() != "" && input.MouseLeftButtonClicked.In(bounds) {
+		b.SetText("")
+		input.ClearMouseInput()
+	}
+}
+
 func (u *UI) update() (dirty bool) {
-	for i := 0; i < len(u.charts); i++ {
-		c := u.charts[i]
-		if c.Update() {
-			dirty = true
-		}
-		if c.DoneFadingOut() {
-			u.charts = append(u.charts[:i], u.charts[i+1:]...)
-			c.Close()
-			i--
+	for _, cell := range u.chartCells {
+		for i := 0; i < len(cell.charts); i++ {
+			c := cell.charts[i]
+			if c.Update() {
+				dirty = true
+			}
+			if c.DoneFadingOut() {
+				cell.charts = append(cell.charts[:i], cell.charts[i+1:]...)
+				c.Close()
+				i--
+			}
 		}
 	}
 
@@ -586,6 +849,10 @@ func (u *UI) update() (dirty bool) {
 		dirty = true
 	}
 
+	if u.alertBannerTextBox.Update() {
+		dirty = true
+	}
+
 	return dirty
 }
 
@@ -594,16 +861,27 @@ func (u *UI) render(fudge float32) {
 
 	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
 
-	// Render the main chart.
-	for _, c := range u.charts {
-		c.Render(fudge)
+	// Render a rim around the focused chart if there are other charts.
+	if len(u.chartCells) > 1 {
+		u.focusBubble.SetBounds(u.metrics().chartCellBounds[u.focusedChartCell].Inset(-viewPadding / 2))
+		u.focusBubble.Render(fudge)
+	}
+
+	// Render the main charts.
+	for _, cell := range u.chartCells {
+		for _, c := range cell.charts {
+			c.Render(fudge)
+		}
 	}
 
-	// Render instructions if there are no charts to show.
-	if len(u.charts) == 0 {
+	// Render instructions if the focused chart has nothing to show.
+	if len(u.chartCells[u.focusedChartCell].charts) == 0 {
 		u.instructionsTextBox.Render(fudge)
 	}
 
+	// Render the alert banner over the chart.
+	u.alertBannerTextBox.Render(fudge)
+
 	// Render the input symbol over the chart.
 	u.inputSymbolTextBox.Render(fudge)
 
@@ -616,9 +894,12 @@ type viewMetrics struct {
 	// winBounds is main window.
 	winBounds image.Rectangle
 
-	// chartBounds is where to draw the main chart.
+	// chartBounds is where to draw the main charts.
 	chartBounds image.Rectangle
 
+	// chartCellBounds are where to draw each chart of the layout within the chartBounds.
+	chartCellBounds []image.Rectangle
+
 	// sidebarBounds is where to draw the sidebar that can move up or down.
 	sidebarBounds image.Rectangle
 }
@@ -644,11 +925,13 @@ func (u *UI) metrics() viewMetrics {
 
 	if sidebarSize.Y == 0 {
 		m.chartBounds = m.winBounds.Inset(viewPadding)
+		m.chartCellBounds = chartCellBounds(m.chartBounds, u.chartLayout)
 		return m
 	}
 
 	m.chartBounds = image.Rect(viewPadding+sidebarSize.X, 0, u.winSize.X, u.winSize.Y)
 	m.chartBounds = m.chartBounds.Inset(viewPadding)
+	m.chartCellBounds = chartCellBounds(m.chartBounds, u.chartLayout)
 
 	// +---+---------+---+---------+---+
 	// |   |         |   | padding |   |
@@ -680,16 +963,46 @@ func (u *UI) SetSidebarSlotSwapCallback(cb func(i, j int)) {
 	u.sidebarSlotSwapCallback = cb
 }
 
-// SetChartZoomChangeCallback sets the callback for when the chart is zoomed in or out.
-func (u *UI) SetChartZoomChangeCallback(cb func(chart.ZoomChange)) {
+// SetChartZoomChangeCallback sets the callback for when a chart is zoomed in or out.
+func (u *UI) SetChartZoomChangeCallback(cb func(i int, zoomChange chart.ZoomChange)) {
 	u.chartZoomChangeCallback = cb
 }
 
-// SetChartPriceStyleButtonClickCallback sets the callback for when the bar or candle stick buttons are clicked.
+// SetChartCellClickCallback sets the callback for when an unfocused chart is clicked.
+func (u *UI) SetChartCellClickCallback(cb func(i int)) {
+	u.chartCellClickCallback = cb
+}
+
+// SetChartDrawingsChangeCallback sets the callback for when a chart's drawings are created, moved, or deleted.
+func (u *UI) SetChartDrawingsChangeCallback(cb func(symbol string, drawings []*chart.Drawing)) {
+	u.chartDrawingsChangeCallback = cb
+}
+
+// SetChartPriceStyleButtonClickCallback sets the callback for when the main chart's price style buttons are clicked.
 func (u *UI) SetChartPriceStyleButtonClickCallback(cb func(newPriceStyle chart.PriceStyle)) {
 	u.chartPriceStyleButtonClickCallback = cb
 }
 
+// SetChartMovingAverageToggleCallback sets the callback for when a main chart's moving average toggle is clicked.
+func (u *UI) SetChartMovingAverageToggleCallback(cb func(i int)) {
+	u.chartMovingAverageToggleCallback = cb
+}
+
+// SetChartLogScaleButtonClickCallback sets the callback for when the main chart's log scale button is clicked.
+func (u *UI) SetChartLogScaleButtonClickCallback(cb func()) {
+	u.chartLogScaleButtonClickCallback = cb
+}
+
+// SetChartVolumeProfileButtonClickCallback sets the callback for when the main chart's volume profile button is clicked.
+func (u *UI) SetChartVolumeProfileButtonClickCallback(cb func()) {
+	u.chartVolumeProfileButtonClickCallback = cb
+}
+
+// SetChartMACDButtonClickCallback sets the callback for when the main chart's MACD button is clicked.
+func (u *UI) SetChartMACDButtonClickCallback(cb func()) {
+	u.chartMACDButtonClickCallback = cb
+}
+
 // SetChartRefreshButtonClickCallback sets the callback for when the main chart's refresh button is clicked.
 func (u *UI) SetChartRefreshButtonClickCallback(cb func(symbol string)) {
 	u.chartRefreshButtonClickCallback = cb
@@ -710,22 +1023,117 @@ func (u *UI) SetThumbClickCallback(cb func(symbol string)) {
 	u.thumbClickCallback = cb
 }
 
-// SetChart sets the main chart to the given symbol and data.
-func (u *UI) SetChart(symbol string, data chart.Data, priceStyle chart.PriceStyle) bool {
+// SetThumbPriceStyleButtonClickCallback sets the callback for when a thumb's price style button is clicked.
+func (u *UI) SetThumbPriceStyleButtonClickCallback(cb func()) {
+	u.thumbPriceStyleButtonClickCallback = cb
+}
+
+// SetThumbRSRatingClickCallback sets the callback for when a thumb's relative strength rating is clicked.
+func (u *UI) SetThumbRSRatingClickCallback(cb func(symbol string)) {
+	u.thumbRSRatingClickCallback = cb
+}
+
+// SetThumbAlertBadgeClickCallback sets the callback for when a thumb's alert badge is clicked.
+func (u *UI) SetThumbAlertBadgeClickCallback(cb func(symbol string)) {
+	u.thumbAlertBadgeClickCallback = cb
+}
+
+// SetWatchlistTabClickCallback sets the callback for when a watchlist tab is clicked.
+func (u *UI) SetWatchlistTabClickCallback(cb func(name string)) {
+	u.watchlistTabClickCallback = cb
+}
+
+// SetSidebarSortButtonClickCallback sets the callback for when the sidebar's sort button is clicked.
+func (u *UI) SetSidebarSortButtonClickCallback(cb func()) {
+	u.sidebarSortButtonClickCallback = cb
+}
+
+// SetSidebarHeaderClickCallback sets the callback for when a sidebar section's header is clicked.
+func (u *UI) SetSidebarHeaderClickCallback(cb func(name string)) {
+	u.sidebarHeaderClickCallback = cb
+}
+
+// SetChartThumbDropCallback sets the callback for when a thumb is dragged onto the main chart.
+func (u *UI) SetChartThumbDropCallback(cb func(symbol string)) {
+	u.chartThumbDropCallback = cb
+}
+
+// SetChartLayout sets how many charts the main area shows. Charts beyond the layout's cells are removed.
+func (u *UI) SetChartLayout(layout model.ChartLayout) {
+	n := layout.Cells()
+
+	for len(u.chartCells) > n {
+		u.chartCells[len(u.chartCells)-1].Close()
+		u.chartCells = u.chartCells[:len(u.chartCells)-1]
+	}
+
+	for len(u.chartCells) < n {
+		u.chartCells = append(u.chartCells, &chartCell{})
+	}
+
+	if u.focusedChartCell >= n {
+		u.SetChartFocus(0)
+	}
+
+	u.chartLayout = layout
+	u.WakeLoop()
+}
+
+// SetChartFocus highlights the chart cell that the entered symbols and commands apply to
+// and shows its stock in the title bar.
+func (u *UI) SetChartFocus(i int) {
+	if i < 0 || i >= len(u.chartCells) {
+		logger.Errorf("chart cell index (%d) is out of bounds (%d)", i, len(u.chartCells))
+		return
+	}
+
+	u.focusedChartCell = i
+
+	if cell := u.chartCells[i]; cell.chart != nil {
+		u.titleBar.SetData(cell.data)
+	} else {
+		u.titleBar.Clear()
+	}
+
+	u.WakeLoop()
+}
+
+// SetDrawingTool sets the kind of drawing created by dragging over empty space on the charts' prices.
+// Unspecified only lets existing drawings be selected, dragged, and deleted.
+func (u *UI) SetDrawingTool(tool chart.DrawingKind) {
+	u.drawingTool = tool
+	for _, cell := range u.chartCells {
+		if cell.chart != nil {
+			cell.chart.SetDrawingTool(tool)
+		}
+	}
+}
+
+// SetChart sets the chart cell to the given symbol and data.
+func (u *UI) SetChart(i int, symbol string, data chart.Data, priceStyle chart.PriceStyle) bool {
 	if err := model.ValidateSymbol(symbol); err != nil {
 		logger.Errorf("invalid symbol: %v", err)
 		return false
 	}
 
-	for symbol, c := range u.symbolToChartMap {
-		delete(u.symbolToChartMap, symbol)
-		c.Close()
+	if i < 0 || i >= len(u.chartCells) {
+		logger.Errorf("chart cell index (%d) is out of bounds (%d)", i, len(u.chartCells))
+		return false
+	}
+
+	cell := u.chartCells[i]
+	if cell.chart != nil {
+		cell.chart.Close()
 	}
 
 	c := chart.NewChart(priceStyle)
-	u.symbolToChartMap[symbol] = c
+	cell.symbol = symbol
+	cell.chart = c
+	cell.data = data
 
-	u.titleBar.SetData(data)
+	if i == u.focusedChartCell {
+		u.titleBar.SetData(data)
+	}
 	c.SetData(data)
 
 	c.SetBarButtonClickCallback(func() {
@@ -740,6 +1148,48 @@ func (u *UI) SetChart(symbol string, data chart.Data, priceStyle chart.PriceStyl
 		}
 	})
 
+	c.SetHeikinAshiButtonClickCallback(func() {
+		if u.chartPriceStyleButtonClickCallback != nil {
+			u.chartPriceStyleButtonClickCallback(chart.HeikinAshi)
+		}
+	})
+
+	c.SetLineButtonClickCallback(func() {
+		if u.chartPriceStyleButtonClickCallback != nil {
+			u.chartPriceStyleButtonClickCallback(chart.Line)
+		}
+	})
+
+	c.SetAreaButtonClickCallback(func() {
+		if u.chartPriceStyleButtonClickCallback != nil {
+			u.chartPriceStyleButtonClickCallback(chart.Area)
+		}
+	})
+
+	c.SetMovingAverageToggleCallback(func(i int) {
+		if u.chartMovingAverageToggleCallback != nil {
+			u.chartMovingAverageToggleCallback(i)
+		}
+	})
+
+	c.SetLogScaleButtonClickCallback(func() {
+		if u.chartLogScaleButtonClickCallback != nil {
+			u.chartLogScaleButtonClickCallback()
+		}
+	})
+
+	c.SetVolumeProfileButtonClickCallback(func() {
+		if u.chartVolumeProfileButtonClickCallback != nil {
+			u.chartVolumeProfileButtonClickCallback()
+		}
+	})
+
+	c.SetMACDButtonClickCallback(func() {
+		if u.chartMACDButtonClickCallback != nil {
+			u.chartMACDButtonClickCallback()
+		}
+	})
+
 	c.SetRefreshButtonClickCallback(func() {
 		if u.chartRefreshButtonClickCallback != nil {
 			u.chartRefreshButtonClickCallback(symbol)
@@ -753,26 +1203,72 @@ func (u *UI) SetChart(symbol string, data chart.Data, priceStyle chart.PriceStyl
 	})
 
 	c.SetZoomChangeCallback(func(zoomChange chart.ZoomChange) {
-		u.handleChartZoomChangeEvent(zoomChange)
+		u.handleChartZoomChangeEvent(i, zoomChange)
+	})
+
+	c.SetDrawingTool(u.drawingTool)
+	c.SetDrawingsChangeCallback(func(drawings []*chart.Drawing) {
+		if u.chartDrawingsChangeCallback != nil {
+			u.chartDrawingsChangeCallback(symbol, drawings)
+		}
 	})
 
 	defer u.WakeLoop()
-	for _, c := range u.charts {
+	for _, c := range cell.charts {
 		c.FadeOut()
 	}
-	u.charts = append([]*uiChart{newUIChart(c)}, u.charts...)
+	cell.charts = append([]*uiChart{newUIChart(c)}, cell.charts...)
+
+	return true
+}
+
+// SetChartData loads the data to the chart cell. Each cell can show a different interval of the same symbol,
+// so SetThumbData does not update the charts.
+func (u *UI) SetChartData(i int, data chart.Data) (changed bool) {
+	if i < 0 || i >= len(u.chartCells) {
+		logger.Errorf("chart cell index (%d) is out of bounds (%d)", i, len(u.chartCells))
+		return false
+	}
+
+	cell := u.chartCells[i]
+	if cell.chart == nil {
+		return false
+	}
+
+	defer u.WakeLoop()
+
+	cell.chart.SetLoading(false)
+	cell.chart.SetData(data)
+	cell.data = data
+
+	if i == u.focusedChartCell {
+		u.titleBar.SetData(data)
+	}
 
 	return true
 }
 
+// SetChartPriceStyle sets the price style of the main chart.
 func (u *UI) SetChartPriceStyle(newPriceStyle chart.PriceStyle) {
 	if newPriceStyle == chart.PriceStyleUnspecified {
 		logger.Error("unspecified price style")
 		return
 	}
 
-	for _, c := range u.symbolToChartMap {
-		c.SetPriceStyle(newPriceStyle)
+	for _, cell := range u.chartCells {
+		if cell.chart != nil {
+			cell.chart.SetPriceStyle(newPriceStyle)
+		}
+	}
+
+	u.WakeLoop()
+}
+
+// SetThumbPriceStyle sets the price style of the thumbnails which can differ from the main chart.
+func (u *UI) SetThumbPriceStyle(newPriceStyle chart.PriceStyle) {
+	if newPriceStyle == chart.PriceStyleUnspecified {
+		logger.Error("unspecified price style")
+		return
 	}
 
 	u.sidebar.SetPriceStyle(newPriceStyle)
@@ -810,6 +1306,62 @@ func (u *UI) RemoveChartThumb(symbol string) (changed bool) {
 	return false
 }
 
+// SetSidebarFilter shows only the thumbnails whose symbols match and shows the filter in the window title.
+// An empty filter and nil match function shows all the thumbnails.
+func (u *UI) SetSidebarFilter(filter string, match func(symbol string) bool) {
+	title := appName
+	if filter != "" {
+		title = fmt.Sprintf("%s - %s", appName, filter)
+	}
+	if u.win != nil {
+		u.win.SetTitle(title)
+	}
+
+	if u.sidebar.FilterChartThumbs(match) {
+		u.WakeLoop()
+	}
+}
+
+// ShowAlertBanner shows the message of a triggered alert over the main chart until it is clicked.
+func (u *UI) ShowAlertBanner(message string) {
+	u.alertBannerTextBox.SetText(message)
+	u.WakeLoop()
+}
+
+// SetWatchlists shows tabs with the names of the watchlists above the sidebar and highlights the current one.
+// The tabs are hidden if there is only one watchlist.
+func (u *UI) SetWatchlists(names []string, current string) {
+	u.sidebar.SetWatchlists(names, current)
+	u.WakeLoop()
+}
+
+// SetSidebarSections groups the sidebar's thumbnails into sections under headers.
+func (u *UI) SetSidebarSections(sections []*SidebarSection) {
+	u.sidebar.SetSections(sections)
+	u.WakeLoop()
+}
+
+// SetSidebarSortMode shows how the sidebar is sorted. Slots can only be dragged to reorder them when sorted manually.
+func (u *UI) SetSidebarSortMode(mode model.SortMode) {
+	u.sidebar.SetSortMode(mode)
+	u.WakeLoop()
+}
+
+// SetPortfolioSummary shows the summary of the user's positions at the top of the sidebar. Nil hides it.
+func (u *UI) SetPortfolioSummary(summary *PortfolioSummary) {
+	u.sidebar.SetPortfolioSummary(summary)
+	u.WakeLoop()
+}
+
+// SortChartThumbs reorders the thumbnails to match the order of the given symbols.
+func (u *UI) SortChartThumbs(symbols []string) (changed bool) {
+	if u.sidebar.SortChartThumbs(symbols) {
+		defer u.WakeLoop()
+		return true
+	}
+	return false
+}
+
 // SetLoading sets the charts and slots matching the symbol and interval to loading.
 func (u *UI) SetLoading(symbol string) (changed bool) {
 	if err := model.ValidateSymbol(symbol); err != nil {
@@ -817,10 +1369,12 @@ func (u *UI) SetLoading(symbol string) (changed bool) {
 		return false
 	}
 
-	if c, ok := u.symbolToChartMap[symbol]; ok {
-		changed = true
-		c.SetLoading(true)
-		c.SetErrorMessage("")
+	for _, cell := range u.chartCells {
+		if cell.chart != nil && cell.symbol == symbol {
+			changed = true
+			cell.chart.SetLoading(true)
+			cell.chart.SetErrorMessage("")
+		}
 	}
 
 	if u.sidebar.SetLoading(symbol) {
@@ -835,25 +1389,14 @@ func (u *UI) SetLoading(symbol string) (changed bool) {
 	return false
 }
 
-// SetData loads the data to charts and thumbnails matching the symbol and interval.
-func (u *UI) SetData(symbol string, data chart.Data) (changed bool) {
+// SetThumbData loads the data to the thumbnails matching the symbol. Use SetChartData for the main area's charts.
+func (u *UI) SetThumbData(symbol string, data chart.Data) (changed bool) {
 	if err := model.ValidateSymbol(symbol); err != nil {
 		logger.Errorf("invalid symbol: %v", err)
 		return false
 	}
 
-	if c, ok := u.symbolToChartMap[symbol]; ok {
-		changed = true
-		c.SetLoading(false)
-		u.titleBar.SetData(data)
-		c.SetData(data)
-	}
-
 	if u.sidebar.SetData(symbol, data) {
-		changed = true
-	}
-
-	if changed {
 		defer u.WakeLoop()
 		return true
 	}
@@ -869,10 +1412,12 @@ func (u *UI) SetErrorMessage(symbol string, errorMessage string) (changed bool)
 		return
 	}
 
-	if c, ok := u.symbolToChartMap[symbol]; ok {
-		changed = true
-		c.SetLoading(false)
-		c.SetErrorMessage(errorMessage)
+	for _, cell := range u.chartCells {
+		if cell.chart != nil && cell.symbol == symbol {
+			changed = true
+			cell.chart.SetLoading(false)
+			cell.chart.SetErrorMessage(errorMessage)
+		}
 	}
 
 	if u.sidebar.SetErrorMessage(symbol, errorMessage) {
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"image"
+
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/btmura/ponzi2/internal/app/gfx"
+	"github.com/btmura/ponzi2/internal/app/view"
+	"github.com/btmura/ponzi2/internal/app/view/rect"
+)
+
+// watchlistTabTextRenderer renders the names of the watchlists.
+var watchlistTabTextRenderer = gfx.NewTextRenderer(goregular.TTF, 12)
+
+// Constants for laying out the watchlist tabs like the portfolio summary.
+const (
+	watchlistTabRounding = 6
+	watchlistTabPadding  = 5
+)
+
+// watchlistTabHeight is the height of a single row of tabs.
+var watchlistTabHeight = watchlistTabTextRenderer.LineHeight() + 2*watchlistTabPadding
+
+// watchlistTabs shows the names of the watchlists at the top of the sidebar to switch between them.
+type watchlistTabs struct {
+	// names are the names of the watchlists in order.
+	names []string
+
+	// current is the name of the watchlist shown in the sidebar.
+	current string
+
+	// tabBounds are the bounds of each name's tab.
+	tabBounds []image.Rectangle
+
+	// currentBubble is the border around the current watchlist's tab.
+	currentBubble *rect.Bubble
+
+	// bounds is the rectangle with global coords that should be drawn within.
+	bounds image.Rectangle
+
+	// clickCallback is called with the name of the clicked tab.
+	clickCallback func(name string)
+}
+
+func newWatchlistTabs() *watchlistTabs {
+	return &watchlistTabs{
+		currentBubble: rect.NewBubble(watchlistTabRounding),
+	}
+}
+
+// SetData sets the names of the watchlists and the current one.
+func (w *watchlistTabs) SetData(names []string, current string) {
+	w.names = names
+	w.current = current
+}
+
+// Visible returns true if there is more than one watchlist to switch between.
+func (w *watchlistTabs) Visible() bool {
+	return len(w.names) > 1
+}
+
+// Height returns the height of the tabs wrapped into rows of the width.
+func (w *watchlistTabs) Height(width int) int {
+	if !w.Visible() {
+		return 0
+	}
+	_, rows := w.layout(image.Rect(0, 0, width, 0))
+	return rows*watchlistTabHeight + (rows-1)*watchlistTabPadding
+}
+
+// layout returns the bounds of each name's tab wrapped into rows from the top of the bounds and the number of rows.
+func (w *watchlistTabs) layout(bounds image.Rectangle) (tabBounds []image.Rectangle, rows int) {
+	pt := image.Pt(bounds.Min.X, bounds.Max.Y)
+	for _, name := range w.names {
+		width := watchlistTabTextRenderer.Measure(name).X + 2*watchlistTabPadding
+		if width > bounds.Dx() {
+			width = bounds.Dx()
+		}
+
+		// Wrap to the next row if the tab does not fit and is not the first in the row.
+		if pt.X != bounds.Min.X && pt.X+width > bounds.Max.X {
+			pt = image.Pt(bounds.Min.X, pt.Y-watchlistTabHeight-watchlistTabPadding)
+			rows++
+		}
+		if rows == 0 {
+			rows = 1
+		}
+
+		tabBounds = append(tabBounds, image.Rect(pt.X, pt.Y-watchlistTabHeight, pt.X+width, pt.Y))
+		pt.X += width + watchlistTabPadding
+	}
+	return tabBounds, rows
+}
+
+func (w *watchlistTabs) SetBounds(bounds image.Rectangle) {
+	w.bounds = bounds
+	w.tabBounds, _ = w.layout(bounds)
+}
+
+func (w *watchlistTabs) ProcessInput(input *view.Input) {
+	if !w.Visible() {
+		return
+	}
+
+	for i, b := range w.tabBounds {
+		if !input.MouseLeftButtonClicked.In(b) {
+			continue
+		}
+
+		name := w.names[i]
+		input.AddFiredCallback(func() {
+			if w.clickCallback != nil {
+				w.clickCallback(name)
+			}
+		})
+		input.ClearMouseInput()
+		return
+	}
+}
+
+func (w *watchlistTabs) Render(fudge float32) {
+	if !w.Visible() || w.bounds.Empty() {
+		return
+	}
+
+	for i, name := range w.names {
+		b := w.tabBounds[i]
+
+		color := view.LightGray
+		if name == w.current {
+			w.currentBubble.SetBounds(b)
+			w.currentBubble.Render(fudge)
+			color = view.White
+		}
+
+		pt := image.Pt(b.Min.X+watchlistTabPadding, b.Min.Y+watchlistTabPadding)
+		watchlistTabTextRenderer.Render(name, pt, gfx.TextColor(color), gfx.TextRenderMaxWidth(b.Dx()-2*watchlistTabPadding))
+	}
+}
+
+// SetClickCallback sets the callback for when a tab is clicked.
+func (w *watchlistTabs) SetClickCallback(cb func(name string)) {
+	w.clickCallback = cb
+}
+
+func (w *watchlistTabs) Close() {
+	w.clickCallback = nil
+}
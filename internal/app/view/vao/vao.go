@@ -46,6 +46,105 @@ func DataLine(yPercentValues []float32, color view.Color) *gfx.VAO {
 	return gfx.NewVAO(data)
 }
 
+// DataBand returns a VAO of triangles that fill the area between the upper and lower percentage values
+// on the Y-axis from -1 to 1 on the X-axis.
+func DataBand(upperYPercentValues, lowerYPercentValues []float32, color view.Color) *gfx.VAO {
+	if len(upperYPercentValues) < 2 || len(upperYPercentValues) != len(lowerYPercentValues) {
+		return gfx.EmptyVAO()
+	}
+
+	dx := 2.0 / float32(len(upperYPercentValues)) // (-1 to 1) on X-axis
+	xc := func(i int) float32 {
+		return -1.0 + dx*float32(i) + dx*0.5
+	}
+	yc := func(v float32) float32 {
+		return 2.0*v - 1.0
+	}
+	valid := func(v float32) bool {
+		return v > 0 && v < 1
+	}
+
+	data := &gfx.VAOVertexData{Mode: gfx.Triangles}
+
+	first := true
+	var v uint16 // vertex index
+	for i, upper := range upperYPercentValues {
+		lower := lowerYPercentValues[i]
+		if !valid(upper) || !valid(lower) {
+			first = true
+			continue
+		}
+		data.Vertices = append(data.Vertices,
+			xc(i), yc(upper), 0,
+			xc(i), yc(lower), 0,
+		)
+		data.Colors = append(data.Colors,
+			color[0], color[1], color[2], color[3],
+			color[0], color[1], color[2], color[3],
+		)
+		if !first {
+			// Fill the quad between the previous and current edges.
+			data.Indices = append(data.Indices,
+				v-2, v-1, v,
+				v-1, v+1, v,
+			)
+		}
+		v += 2
+		first = false
+	}
+
+	return gfx.NewVAO(data)
+}
+
+// DataArea returns a VAO of triangles that fill the area under the percentage values on the Y-axis
+// from -1 to 1 on the X-axis. The fill fades from the color at the values to transparent at the bottom.
+func DataArea(yPercentValues []float32, color view.Color) *gfx.VAO {
+	if len(yPercentValues) < 2 {
+		return gfx.EmptyVAO()
+	}
+
+	dx := 2.0 / float32(len(yPercentValues)) // (-1 to 1) on X-axis
+	xc := func(i int) float32 {
+		return -1.0 + dx*float32(i) + dx*0.5
+	}
+	yc := func(v float32) float32 {
+		return 2.0*v - 1.0
+	}
+
+	bottomColor := color
+	bottomColor[3] = 0
+
+	data := &gfx.VAOVertexData{Mode: gfx.Triangles}
+
+	first := true
+	var v uint16 // vertex index
+	for i, val := range yPercentValues {
+		if val <= 0 || val >= 1 {
+			first = true
+			continue
+		}
+		data.Vertices = append(data.Vertices,
+			xc(i), yc(val), 0,
+			xc(i), -1, 0,
+		)
+		data.Colors = append(data.Colors,
+			color[0], color[1], color[2], color[3],
+			bottomColor[0], bottomColor[1], bottomColor[2], bottomColor[3],
+		)
+		if !first {
+			// Fill the quad between the previous and current edges.
+			data.Indices = append(data.Indices,
+				v-2, v-1, v,
+				v-1, v+1, v,
+			)
+		}
+		v += 2
+		first = false
+	}
+
+	return gfx.NewVAO(data)
+}
+
 // VertRuleSet returns a set of vertical lines at different x values.
 func VertRuleSet(xValues []float32, xRange [2]float32, color1, color2 view.Color) *gfx.VAO {
 	if len(xValues) < 2 {
@@ -0,0 +1,249 @@
+// Package backtest simulates rules-based strategies over the trading sessions of a chart.
+//
+// Strategies enter and exit with rules written in Go or as screener expressions evaluated at each session's close.
+// For example, buying on a close above the 21 EMA after a pullback to it with a 7% stop:
+//
+//	entry  close > ema21 and low <= ema21
+//	exit   close < sma50
+//	stop   0.07
+//
+// Signals fill at the next session's open adjusted by the slippage. Stops fill at the stop price or the open if it gaps below.
+package backtest
+
+import (
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/screener"
+)
+
+// Rule returns true if the strategy should act at the close of the last session.
+type Rule func(sessions []*model.TradingSession) bool
+
+// FilterRule returns a rule that matches the screener filter against the sessions as a daily chart.
+func FilterRule(f *screener.Filter) Rule {
+	return func(sessions []*model.TradingSession) bool {
+		return f.Match(&model.Chart{
+			Interval:             model.Daily,
+			TradingSessionSeries: &model.TradingSessionSeries{TradingSessions: sessions},
+		})
+	}
+}
+
+// Strategy decides when to enter and exit long positions.
+type Strategy struct {
+	// Entry is the rule to buy at the next session's open.
+	Entry Rule
+
+	// Exit is the rule to sell at the next session's open. Nil to only exit at the stop.
+	Exit Rule
+
+	// StopLoss is how far the price can fall below the entry price as a fraction like 0.07 for 7% before selling.
+	// Zero for no stop.
+	StopLoss float32
+}
+
+// Options are the settings of the simulation.
+type Options struct {
+	// Slippage is how much worse than the price each fill is as a fraction like 0.001 for 0.1%.
+	Slippage float32
+}
+
+// ExitReason is why a trade was closed.
+type ExitReason int
+
+// ExitReason values.
+const (
+	ExitReasonUnspecified ExitReason = iota
+	ExitSignal
+	ExitStop
+	ExitEnd
+)
+
+// String returns the lowercase name of the reason like "stop".
+func (r ExitReason) String() string {
+	switch r {
+	case ExitSignal:
+		return "signal"
+	case ExitStop:
+		return "stop"
+	case ExitEnd:
+		return "end"
+	default:
+		return "?"
+	}
+}
+
+// Trade is a simulated round trip from entry to exit.
+type Trade struct {
+	// EntryDate is the date of the session that the entry filled in.
+	EntryDate time.Time
+
+	// EntryPrice is the fill price of the entry including slippage.
+	EntryPrice float32
+
+	// ExitDate is the date of the session that the exit filled in.
+	ExitDate time.Time
+
+	// ExitPrice is the fill price of the exit including slippage.
+	ExitPrice float32
+
+	// ExitReason is why the trade was closed.
+	ExitReason ExitReason
+
+	// Return is the profit or loss as a fraction like 0.1 for 10%.
+	Return float32
+}
+
+// EquityPoint is the value of the account at a session's close.
+type EquityPoint struct {
+	// Date is the date of the session.
+	Date time.Time
+
+	// Equity is the value of the account relative to the starting value of 1.
+	Equity float32
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	// Trades are the simulated trades from oldest to newest.
+	Trades []*Trade
+
+	// WinRate is the fraction of trades with positive returns.
+	WinRate float32
+
+	// Expectancy is the average return per trade as a fraction.
+	Expectancy float32
+
+	// MaxDrawdown is the largest fall in equity from a peak as a fraction.
+	MaxDrawdown float32
+
+	// EquityCurve is the equity at each session's close with all of it in each trade.
+	EquityCurve []*EquityPoint
+}
+
+// Run simulates the strategy over the trading sessions. Trades still open at the end are closed at the last close.
+func Run(ts *model.TradingSessionSeries, s *Strategy, opts Options) (*Result, error) {
+	if ts == nil {
+		return nil, errs.Errorf("missing trading sessions")
+	}
+
+	if s == nil || s.Entry == nil {
+		return nil, errs.Errorf("missing entry rule")
+	}
+
+	if s.StopLoss < 0 || s.StopLoss >= 1 {
+		return nil, errs.Errorf("stop loss must be in [0, 1): %v", s.StopLoss)
+	}
+
+	if opts.Slippage < 0 || opts.Slippage >= 1 {
+		return nil, errs.Errorf("slippage must be in [0, 1): %v", opts.Slippage)
+	}
+
+	buyFill := func(price float32) float32 { return price * (1 + opts.Slippage) }
+	sellFill := func(price float32) float32 { return price * (1 - opts.Slippage) }
+
+	r := &Result{}
+
+	var (
+		// equity is the account value at the last exit or the start.
+		equity float32 = 1
+
+		// trade is the open trade. Nil when not in a position.
+		trade *Trade
+
+		// stop is the open trade's stop price. Zero for no stop.
+		stop float32
+
+		pendingEntry bool
+		pendingExit  bool
+	)
+
+	exit := func(date time.Time, price float32, reason ExitReason) {
+		trade.ExitDate = date
+		trade.ExitPrice = price
+		trade.ExitReason = reason
+		trade.Return = (price - trade.EntryPrice) / trade.EntryPrice
+		equity *= 1 + trade.Return
+
+		r.Trades = append(r.Trades, trade)
+		trade = nil
+	}
+
+	tss := ts.TradingSessions
+	for i, sess := range tss {
+		switch {
+		case pendingEntry:
+			trade = &Trade{
+				EntryDate:  sess.Date,
+				EntryPrice: buyFill(sess.Open),
+			}
+			stop = 0
+			if s.StopLoss > 0 {
+				stop = trade.EntryPrice * (1 - s.StopLoss)
+			}
+
+		case pendingExit:
+			exit(sess.Date, sellFill(sess.Open), ExitSignal)
+		}
+		pendingEntry, pendingExit = false, false
+
+		// Stop out at the stop price or at the open if the price gapped below it.
+		if trade != nil && stop > 0 && sess.Low <= stop {
+			price := stop
+			if sess.Open < price {
+				price = sess.Open
+			}
+			exit(sess.Date, sellFill(price), ExitStop)
+		}
+
+		// Mark the open trade to the close.
+		value := equity
+		if trade != nil {
+			value = equity * sess.Close / trade.EntryPrice
+		}
+		r.EquityCurve = append(r.EquityCurve, &EquityPoint{Date: sess.Date, Equity: value})
+
+		// Check the rules at the close to act at the next open.
+		switch {
+		case trade == nil:
+			pendingEntry = s.Entry(tss[:i+1])
+		case s.Exit != nil:
+			pendingExit = s.Exit(tss[:i+1])
+		}
+	}
+
+	if trade != nil {
+		last := tss[len(tss)-1]
+		exit(last.Date, sellFill(last.Close), ExitEnd)
+
+		// Mark the last point with the exit's slippage.
+		r.EquityCurve[len(r.EquityCurve)-1].Equity = equity
+	}
+
+	var wins int
+	var sum float32
+	for _, t := range r.Trades {
+		if t.Return > 0 {
+			wins++
+		}
+		sum += t.Return
+	}
+	if n := len(r.Trades); n != 0 {
+		r.WinRate = float32(wins) / float32(n)
+		r.Expectancy = sum / float32(n)
+	}
+
+	var peak float32
+	for _, p := range r.EquityCurve {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if dd := (peak - p.Equity) / peak; dd > r.MaxDrawdown {
+			r.MaxDrawdown = dd
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,177 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/screener"
+)
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		sessions []*model.TradingSession
+		strategy *Strategy
+		opts     Options
+		want     *Result
+		wantErr  bool
+	}{
+		{
+			desc: "signal entry and exit with slippage",
+			sessions: []*model.TradingSession{
+				session(1, 10, 10, 10, 10),
+				session(2, 10, 10, 10, 10),
+				session(3, 10, 12, 10, 12),
+				session(4, 12, 14, 12, 14),
+				session(5, 20, 20, 20, 20),
+			},
+			strategy: &Strategy{Entry: onDay(2), Exit: onDay(4)},
+			opts:     Options{Slippage: 0.1},
+			want: &Result{
+				Trades: []*Trade{
+					{EntryDate: date(3), EntryPrice: 11, ExitDate: date(5), ExitPrice: 18, ExitReason: ExitSignal, Return: 7.0 / 11},
+				},
+				WinRate:    1,
+				Expectancy: 7.0 / 11,
+				EquityCurve: []*EquityPoint{
+					{date(1), 1},
+					{date(2), 1},
+					{date(3), 12.0 / 11},
+					{date(4), 14.0 / 11},
+					{date(5), 18.0 / 11},
+				},
+			},
+		},
+		{
+			desc: "stop fills at the open after a gap down",
+			sessions: []*model.TradingSession{
+				session(1, 10, 10, 10, 10),
+				session(2, 10, 10, 10, 10),
+				session(3, 10, 10, 9.5, 9.5),
+				session(4, 8, 8, 7, 7),
+				session(5, 7, 7, 7, 7),
+			},
+			strategy: &Strategy{Entry: onDay(1), StopLoss: 0.1},
+			want: &Result{
+				Trades: []*Trade{
+					{EntryDate: date(2), EntryPrice: 10, ExitDate: date(4), ExitPrice: 8, ExitReason: ExitStop, Return: -0.2},
+				},
+				Expectancy:  -0.2,
+				MaxDrawdown: 0.2,
+				EquityCurve: []*EquityPoint{
+					{date(1), 1},
+					{date(2), 1},
+					{date(3), 0.95},
+					{date(4), 0.8},
+					{date(5), 0.8},
+				},
+			},
+		},
+		{
+			desc: "open trade closes at the end",
+			sessions: []*model.TradingSession{
+				session(1, 10, 10, 10, 10),
+				session(2, 10, 12, 10, 12),
+				session(3, 12, 15, 12, 15),
+			},
+			strategy: &Strategy{Entry: onDay(1)},
+			want: &Result{
+				Trades: []*Trade{
+					{EntryDate: date(2), EntryPrice: 10, ExitDate: date(3), ExitPrice: 15, ExitReason: ExitEnd, Return: 0.5},
+				},
+				WinRate:    1,
+				Expectancy: 0.5,
+				EquityCurve: []*EquityPoint{
+					{date(1), 1},
+					{date(2), 1.2},
+					{date(3), 1.5},
+				},
+			},
+		},
+		{
+			desc: "no signals",
+			sessions: []*model.TradingSession{
+				session(1, 10, 10, 10, 10),
+			},
+			strategy: &Strategy{Entry: onDay(5)},
+			want: &Result{
+				EquityCurve: []*EquityPoint{
+					{date(1), 1},
+				},
+			},
+		},
+		{
+			desc:     "missing entry",
+			sessions: []*model.TradingSession{session(1, 10, 10, 10, 10)},
+			strategy: &Strategy{},
+			wantErr:  true,
+		},
+		{
+			desc:     "bad stop loss",
+			sessions: []*model.TradingSession{session(1, 10, 10, 10, 10)},
+			strategy: &Strategy{Entry: onDay(1), StopLoss: 1},
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := Run(&model.TradingSessionSeries{TradingSessions: tt.sessions}, tt.strategy, tt.opts)
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 0.0001)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterRule(t *testing.T) {
+	f, err := screener.Parse("close > sma2")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	sessions := []*model.TradingSession{
+		session(1, 1, 1, 1, 1),
+		session(2, 2, 2, 2, 2),
+		session(3, 1, 1, 1, 1),
+	}
+
+	var got []bool
+	for i := range sessions {
+		got = append(got, FilterRule(f)(sessions[:i+1]))
+	}
+
+	// The first session does not have enough sessions for the average.
+	want := []bool{false, true, false}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
+
+// onDay returns a rule that matches when the last session is on the day of January 2020.
+func onDay(day int) Rule {
+	return func(sessions []*model.TradingSession) bool {
+		return sessions[len(sessions)-1].Date.Equal(date(day))
+	}
+}
+
+func session(day int, open, high, low, close float32) *model.TradingSession {
+	return &model.TradingSession{
+		Date:  date(day),
+		Open:  open,
+		High:  high,
+		Low:   low,
+		Close: close,
+	}
+}
+
+func date(day int) time.Time {
+	return time.Date(2020, time.January, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,180 @@
+package indicator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// Default Bollinger Band parameters that are commonly used.
+const (
+	DefaultBollingerPeriods    = 20
+	DefaultBollingerDeviations = 2
+)
+
+// Default Keltner Channel parameters that are commonly used.
+const (
+	DefaultKeltnerPeriods    = 20
+	DefaultKeltnerATRPeriods = 10
+	DefaultKeltnerMultiplier = 2
+)
+
+// BollingerBands is a band of standard deviations around a simple moving average of the closes.
+type BollingerBands struct {
+	// Periods is how many days or weeks the moving average and standard deviation span.
+	Periods int
+
+	// Deviations is how many standard deviations the edges are from the moving average.
+	Deviations int
+}
+
+// NewBollingerBands returns Bollinger Bands with the given periods and standard deviations.
+func NewBollingerBands(periods, deviations int) *BollingerBands {
+	return &BollingerBands{Periods: periods, Deviations: deviations}
+}
+
+func bollingerBandsFactory(params ...int) (Indicator, error) {
+	if len(params) == 0 {
+		return NewBollingerBands(DefaultBollingerPeriods, DefaultBollingerDeviations), nil
+	}
+	if len(params) != 2 {
+		return nil, errs.Errorf("bad params: got %v, want periods and deviations", params)
+	}
+	if err := checkPositiveParams(params); err != nil {
+		return nil, err
+	}
+	return NewBollingerBands(params[0], params[1]), nil
+}
+
+// Compute implements the Indicator interface.
+func (b *BollingerBands) Compute(ts *model.TradingSessionSeries) []*model.IndicatorSeries {
+	tss := ts.TradingSessions
+	n := b.Periods
+
+	averages := simpleMovingAverages(tss, n)
+
+	var upper, lower []*model.IndicatorValue
+	for i, avg := range averages {
+		var u, l float32
+		if i+1-n >= 0 {
+			var sum float64
+			for j := 0; j < n; j++ {
+				d := float64(tss[i-j].Close - avg.Value)
+				sum += d * d
+			}
+			width := float32(b.Deviations) * float32(math.Sqrt(sum/float64(n)))
+			u, l = avg.Value+width, avg.Value-width
+		}
+		upper = append(upper, &model.IndicatorValue{Date: avg.Date, Value: u})
+		lower = append(lower, &model.IndicatorValue{Date: avg.Date, Value: l})
+	}
+
+	return []*model.IndicatorSeries{
+		{
+			Name:        fmt.Sprintf("BB %d,%d", b.Periods, b.Deviations),
+			Values:      upper,
+			LowerValues: lower,
+		},
+	}
+}
+
+// KeltnerChannel is a band of average true ranges around an exponential moving average of the closes.
+type KeltnerChannel struct {
+	// Periods is how many days or weeks the exponential moving average spans.
+	Periods int
+
+	// ATRPeriods is how many days or weeks the average true range spans.
+	ATRPeriods int
+
+	// Multiplier is how many average true ranges the edges are from the moving average.
+	Multiplier int
+}
+
+// NewKeltnerChannel returns a Keltner Channel with the given periods, ATR periods, and ATR multiplier.
+func NewKeltnerChannel(periods, atrPeriods, multiplier int) *KeltnerChannel {
+	return &KeltnerChannel{Periods: periods, ATRPeriods: atrPeriods, Multiplier: multiplier}
+}
+
+func keltnerChannelFactory(params ...int) (Indicator, error) {
+	if len(params) == 0 {
+		return NewKeltnerChannel(DefaultKeltnerPeriods, DefaultKeltnerATRPeriods, DefaultKeltnerMultiplier), nil
+	}
+	if len(params) != 3 {
+		return nil, errs.Errorf("bad params: got %v, want periods, ATR periods, and multiplier", params)
+	}
+	if err := checkPositiveParams(params); err != nil {
+		return nil, err
+	}
+	return NewKeltnerChannel(params[0], params[1], params[2]), nil
+}
+
+// Compute implements the Indicator interface.
+func (k *KeltnerChannel) Compute(ts *model.TradingSessionSeries) []*model.IndicatorSeries {
+	averages := exponentialMovingAverages(ts.TradingSessions, k.Periods)
+	ranges := averageTrueRanges(ts.TradingSessions, k.ATRPeriods)
+
+	var upper, lower []*model.IndicatorValue
+	for i, avg := range averages {
+		var u, l float32
+		if i >= k.Periods && i >= k.ATRPeriods {
+			width := float32(k.Multiplier) * ranges[i]
+			u, l = avg.Value+width, avg.Value-width
+		}
+		upper = append(upper, &model.IndicatorValue{Date: avg.Date, Value: u})
+		lower = append(lower, &model.IndicatorValue{Date: avg.Date, Value: l})
+	}
+
+	return []*model.IndicatorSeries{
+		{
+			Name:        fmt.Sprintf("KC %d,%d", k.Periods, k.Multiplier),
+			Values:      upper,
+			LowerValues: lower,
+		},
+	}
+}
+
+// averageTrueRanges uses Wilder's smoothing to average the true ranges.
+// The first range is available once there are n true ranges after the first session.
+func averageTrueRanges(ts []*model.TradingSession, n int) []float32 {
+	atrs := make([]float32, len(ts))
+
+	var sum float32
+	for i := 1; i < len(ts); i++ {
+		tr := trueRange(ts[i], ts[i-1])
+		switch {
+		case i < n:
+			sum += tr
+
+		case i == n:
+			atrs[i] = (sum + tr) / float32(n)
+
+		default:
+			atrs[i] = (atrs[i-1]*float32(n-1) + tr) / float32(n)
+		}
+	}
+
+	return atrs
+}
+
+// trueRange is the greatest of the session's range and the distances from the previous close.
+func trueRange(curr, prev *model.TradingSession) float32 {
+	tr := curr.High - curr.Low
+	if d := float32(math.Abs(float64(curr.High - prev.Close))); d > tr {
+		tr = d
+	}
+	if d := float32(math.Abs(float64(curr.Low - prev.Close))); d > tr {
+		tr = d
+	}
+	return tr
+}
+
+func checkPositiveParams(params []int) error {
+	for _, p := range params {
+		if p <= 0 {
+			return errs.Errorf("bad param: got %d, want > 0", p)
+		}
+	}
+	return nil
+}
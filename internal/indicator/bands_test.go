@@ -0,0 +1,68 @@
+package indicator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestBandsCompute(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2018, time.September, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	values := func(vs ...float32) []*model.IndicatorValue {
+		var ivs []*model.IndicatorValue
+		for i, v := range vs {
+			ivs = append(ivs, &model.IndicatorValue{Date: day(i + 1), Value: v})
+		}
+		return ivs
+	}
+
+	for _, tt := range []struct {
+		desc      string
+		indicator Indicator
+		sessions  [][3]float32 // high, low, close
+		want      []*model.IndicatorSeries
+	}{
+		{
+			desc:      "bollinger bands",
+			indicator: NewBollingerBands(2, 2),
+			sessions:  [][3]float32{{10, 10, 10}, {12, 12, 12}, {12, 12, 12}},
+			// Standard deviation of 10 and 12 is 1 and of 12 and 12 is 0.
+			want: []*model.IndicatorSeries{
+				{Name: "BB 2,2", Values: values(0, 13, 12), LowerValues: values(0, 9, 12)},
+			},
+		},
+		{
+			desc:      "keltner channel",
+			indicator: NewKeltnerChannel(1, 1, 2),
+			sessions:  [][3]float32{{11, 9, 10}, {13, 11, 12}, {12, 10, 11}},
+			// True ranges are 3 from the prior close and then 2 from the session's range.
+			want: []*model.IndicatorSeries{
+				{Name: "KC 1,2", Values: values(0, 18, 15), LowerValues: values(0, 6, 7)},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ts := &model.TradingSessionSeries{}
+			for i, s := range tt.sessions {
+				ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{
+					Date:  day(i + 1),
+					High:  s[0],
+					Low:   s[1],
+					Close: s[2],
+				})
+			}
+
+			got := tt.indicator.Compute(ts)
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 0.001)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
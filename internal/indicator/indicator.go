@@ -0,0 +1,31 @@
+// Package indicator computes technical indicators like moving averages from trading sessions.
+package indicator
+
+import (
+	"github.com/btmura/ponzi2/internal/app/model"
+)
+
+// Indicator computes one or more series from a series of trading sessions.
+type Indicator interface {
+	// Compute returns the indicator's series with a value for every trading session.
+	// Series without a pane are drawn on top of the prices.
+	Compute(ts *model.TradingSessionSeries) []*model.IndicatorSeries
+}
+
+// ComputeAll computes the indicators and splits their series into overlay and pane series.
+func ComputeAll(ts *model.TradingSessionSeries, indicators []Indicator) (overlays, panes []*model.IndicatorSeries) {
+	if ts == nil {
+		return nil, nil
+	}
+
+	for _, ind := range indicators {
+		for _, s := range ind.Compute(ts) {
+			if s.Pane == "" {
+				overlays = append(overlays, s)
+			} else {
+				panes = append(panes, s)
+			}
+		}
+	}
+	return overlays, panes
+}
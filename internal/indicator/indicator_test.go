@@ -0,0 +1,33 @@
+package indicator
+
+import (
+	"testing"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeIndicator []*model.IndicatorSeries
+
+func (f fakeIndicator) Compute(*model.TradingSessionSeries) []*model.IndicatorSeries {
+	return f
+}
+
+func TestComputeAll(t *testing.T) {
+	overlay := &model.IndicatorSeries{Name: "overlay"}
+	pane1 := &model.IndicatorSeries{Name: "pane1", Pane: "pane"}
+	pane2 := &model.IndicatorSeries{Name: "pane2", Pane: "pane"}
+
+	gotOverlays, gotPanes := ComputeAll(&model.TradingSessionSeries{}, []Indicator{
+		fakeIndicator{pane1},
+		fakeIndicator{overlay, pane2},
+	})
+
+	if diff := cmp.Diff([]*model.IndicatorSeries{overlay}, gotOverlays); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]*model.IndicatorSeries{pane1, pane2}, gotPanes); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,153 @@
+package indicator
+
+import (
+	"fmt"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// MovingAverage is an indicator that averages closing prices over a number of periods.
+type MovingAverage struct {
+	// Type is the average type like simple or exponential.
+	Type model.AverageType
+
+	// Periods is how many days or weeks each value spans.
+	Periods int
+}
+
+// NewMovingAverage returns a moving average of the given type and periods.
+func NewMovingAverage(avgType model.AverageType, periods int) *MovingAverage {
+	return &MovingAverage{Type: avgType, Periods: periods}
+}
+
+// movingAverageFactory returns a factory that creates moving averages of the given type.
+func movingAverageFactory(avgType model.AverageType) Factory {
+	return func(params ...int) (Indicator, error) {
+		if len(params) != 1 {
+			return nil, errs.Errorf("bad params: got %v, want periods", params)
+		}
+		if params[0] <= 0 {
+			return nil, errs.Errorf("bad periods: got %d, want > 0", params[0])
+		}
+		return NewMovingAverage(avgType, params[0]), nil
+	}
+}
+
+// Name returns the moving average's legend name like "SMA 50".
+func (m *MovingAverage) Name() string {
+	return fmt.Sprintf("%s %d", AverageTypeLabel(m.Type), m.Periods)
+}
+
+// Compute implements the Indicator interface.
+func (m *MovingAverage) Compute(ts *model.TradingSessionSeries) []*model.IndicatorSeries {
+	var values []*model.IndicatorValue
+	switch m.Type {
+	case model.Simple:
+		values = simpleMovingAverages(ts.TradingSessions, m.Periods)
+	case model.Exponential:
+		values = exponentialMovingAverages(ts.TradingSessions, m.Periods)
+	case model.Weighted:
+		values = weightedMovingAverages(ts.TradingSessions, m.Periods)
+	default:
+		return nil
+	}
+	return []*model.IndicatorSeries{{Name: m.Name(), Values: values}}
+}
+
+// AverageTypeLabel returns the abbreviation of the average type like "SMA" used in series names.
+func AverageTypeLabel(avgType model.AverageType) string {
+	switch avgType {
+	case model.Simple:
+		return "SMA"
+	case model.Exponential:
+		return "EMA"
+	case model.Weighted:
+		return "WMA"
+	default:
+		return "?"
+	}
+}
+
+func exponentialMovingAverages(ts []*model.TradingSession, n int) []*model.IndicatorValue {
+	var values []*model.IndicatorValue
+
+	smoothing := 2.0 / (float32(n) + 1.0)
+
+	value := func(i int) (avg float32) {
+		var prevEMA float32
+		switch {
+		case i < n:
+			// Not enough points to calculate SMA.
+			return 0
+
+		case i == n:
+			// Use yesterday's SMA for today's previous EMA.
+			var sum float32
+			for j := 0; j < n; j++ {
+				sum += ts[i-1-j].Close
+			}
+			prevEMA = sum / float32(n)
+
+		default:
+			// Use prev EMA.
+			prevEMA = values[i-1].Value
+		}
+		return ts[i].Close*smoothing + prevEMA*(1-smoothing)
+	}
+
+	for i := range ts {
+		values = append(values, &model.IndicatorValue{
+			Date:  ts[i].Date,
+			Value: value(i),
+		})
+	}
+	return values
+}
+
+func simpleMovingAverages(ts []*model.TradingSession, n int) []*model.IndicatorValue {
+	average := func(i, n int) (avg float32) {
+		if i+1-n < 0 {
+			return 0 // Not enough data
+		}
+		var sum float32
+		for j := 0; j < n; j++ {
+			sum += ts[i-j].Close
+		}
+		return sum / float32(n)
+	}
+
+	var ms []*model.IndicatorValue
+	for i := range ts {
+		ms = append(ms, &model.IndicatorValue{
+			Date:  ts[i].Date,
+			Value: average(i, n),
+		})
+	}
+	return ms
+}
+
+// weightedMovingAverages weighs the most recent close by n, the one before by n-1, and so on.
+func weightedMovingAverages(ts []*model.TradingSession, n int) []*model.IndicatorValue {
+	denominator := float32(n*(n+1)) / 2
+
+	average := func(i, n int) (avg float32) {
+		if i+1-n < 0 {
+			return 0 // Not enough data
+		}
+		var sum float32
+		for j := 0; j < n; j++ {
+			sum += ts[i-j].Close * float32(n-j)
+		}
+		return sum / denominator
+	}
+
+	var ms []*model.IndicatorValue
+	for i := range ts {
+		ms = append(ms, &model.IndicatorValue{
+			Date:  ts[i].Date,
+			Value: average(i, n),
+		})
+	}
+	return ms
+}
@@ -0,0 +1,112 @@
+package indicator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMovingAverageCompute(t *testing.T) {
+	ts := &model.TradingSessionSeries{
+		TradingSessions: []*model.TradingSession{
+			{
+				Date:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+				Close: 100,
+			},
+			{
+				Date:  time.Date(2018, time.September, 2, 0, 0, 0, 0, time.UTC),
+				Close: 200,
+			},
+			{
+				Date:  time.Date(2018, time.September, 3, 0, 0, 0, 0, time.UTC),
+				Close: 300,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		desc  string
+		input *MovingAverage
+		want  []*model.IndicatorSeries
+	}{
+		{
+			desc:  "exponential",
+			input: NewMovingAverage(model.Exponential, 2),
+			want: []*model.IndicatorSeries{
+				{
+					Name: "EMA 2",
+					Values: []*model.IndicatorValue{
+						{
+							Date:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+							Value: 0,
+						},
+						{
+							Date:  time.Date(2018, time.September, 2, 0, 0, 0, 0, time.UTC),
+							Value: 0,
+						},
+						{
+							Date:  time.Date(2018, time.September, 3, 0, 0, 0, 0, time.UTC),
+							Value: 250,
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:  "simple",
+			input: NewMovingAverage(model.Simple, 2),
+			want: []*model.IndicatorSeries{
+				{
+					Name: "SMA 2",
+					Values: []*model.IndicatorValue{
+						{
+							Date:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+							Value: 0,
+						},
+						{
+							Date:  time.Date(2018, time.September, 2, 0, 0, 0, 0, time.UTC),
+							Value: 150,
+						},
+						{
+							Date:  time.Date(2018, time.September, 3, 0, 0, 0, 0, time.UTC),
+							Value: 250,
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:  "weighted",
+			input: NewMovingAverage(model.Weighted, 2),
+			want: []*model.IndicatorSeries{
+				{
+					Name: "WMA 2",
+					Values: []*model.IndicatorValue{
+						{
+							Date:  time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+							Value: 0,
+						},
+						{
+							Date:  time.Date(2018, time.September, 2, 0, 0, 0, 0, time.UTC),
+							Value: 500.0 / 3,
+						},
+						{
+							Date:  time.Date(2018, time.September, 3, 0, 0, 0, 0, time.UTC),
+							Value: 800.0 / 3,
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := tt.input.Compute(ts)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
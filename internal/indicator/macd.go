@@ -0,0 +1,131 @@
+package indicator
+
+import (
+	"fmt"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// Default MACD periods that are commonly used.
+const (
+	DefaultMACDFastPeriods   = 12
+	DefaultMACDSlowPeriods   = 26
+	DefaultMACDSignalPeriods = 9
+)
+
+// MACDPane is the name of the pane where the MACD is drawn.
+const MACDPane = "MACD"
+
+// MACD is the Moving Average Convergence Divergence that tracks momentum
+// by comparing a fast and a slow exponential moving average of the closes.
+type MACD struct {
+	// FastPeriods is how many periods the fast exponential moving average spans.
+	FastPeriods int
+
+	// SlowPeriods is how many periods the slow exponential moving average spans.
+	SlowPeriods int
+
+	// SignalPeriods is how many periods of the MACD line the signal line averages.
+	SignalPeriods int
+}
+
+// NewMACD returns a MACD with the given fast, slow, and signal periods.
+func NewMACD(fastPeriods, slowPeriods, signalPeriods int) *MACD {
+	return &MACD{
+		FastPeriods:   fastPeriods,
+		SlowPeriods:   slowPeriods,
+		SignalPeriods: signalPeriods,
+	}
+}
+
+func macdFactory(params ...int) (Indicator, error) {
+	if len(params) == 0 {
+		return NewMACD(DefaultMACDFastPeriods, DefaultMACDSlowPeriods, DefaultMACDSignalPeriods), nil
+	}
+	if len(params) != 3 {
+		return nil, errs.Errorf("bad params: got %v, want fast, slow, and signal periods", params)
+	}
+	for _, p := range params {
+		if p <= 0 {
+			return nil, errs.Errorf("bad periods: got %d, want > 0", p)
+		}
+	}
+	if params[0] >= params[1] {
+		return nil, errs.Errorf("bad periods: got fast %d and slow %d, want fast < slow", params[0], params[1])
+	}
+	return NewMACD(params[0], params[1], params[2]), nil
+}
+
+// Compute implements the Indicator interface.
+// It returns the MACD line, the signal line, and the histogram of their difference.
+func (m *MACD) Compute(ts *model.TradingSessionSeries) []*model.IndicatorSeries {
+	fast := exponentialMovingAverages(ts.TradingSessions, m.FastPeriods)
+	slow := exponentialMovingAverages(ts.TradingSessions, m.SlowPeriods)
+
+	// The MACD line starts once the slow moving average has a value.
+	macd := make([]float32, len(ts.TradingSessions))
+	for i := m.SlowPeriods; i < len(macd); i++ {
+		macd[i] = fast[i].Value - slow[i].Value
+	}
+
+	// The signal line starts once enough of the MACD line is available to average.
+	signal := exponentialAverages(macd, m.SlowPeriods, m.SignalPeriods)
+
+	var macdValues, signalValues, histogramValues []*model.IndicatorValue
+	for i, s := range ts.TradingSessions {
+		var histogram float32
+		if i >= m.SlowPeriods+m.SignalPeriods {
+			histogram = macd[i] - signal[i]
+		}
+
+		macdValues = append(macdValues, &model.IndicatorValue{Date: s.Date, Value: macd[i]})
+		signalValues = append(signalValues, &model.IndicatorValue{Date: s.Date, Value: signal[i]})
+		histogramValues = append(histogramValues, &model.IndicatorValue{Date: s.Date, Value: histogram})
+	}
+
+	return []*model.IndicatorSeries{
+		{
+			Name:   fmt.Sprintf("MACD %d,%d", m.FastPeriods, m.SlowPeriods),
+			Pane:   MACDPane,
+			Values: macdValues,
+		},
+		{
+			Name:   fmt.Sprintf("Signal %d", m.SignalPeriods),
+			Pane:   MACDPane,
+			Values: signalValues,
+		},
+		{
+			Name:      "Histogram",
+			Pane:      MACDPane,
+			Levels:    []float32{0},
+			Histogram: true,
+			Values:    histogramValues,
+		},
+	}
+}
+
+// exponentialAverages returns the exponential moving averages of the values from start onwards.
+// The first average is seeded with the simple average of the prior n values like exponentialMovingAverages.
+func exponentialAverages(vs []float32, start, n int) []float32 {
+	avgs := make([]float32, len(vs))
+
+	smoothing := 2.0 / (float32(n) + 1.0)
+
+	for i := start + n; i < len(vs); i++ {
+		var prevEMA float32
+		if i == start+n {
+			// Use the prior SMA for the previous EMA.
+			var sum float32
+			for j := 0; j < n; j++ {
+				sum += vs[i-1-j]
+			}
+			prevEMA = sum / float32(n)
+		} else {
+			prevEMA = avgs[i-1]
+		}
+		avgs[i] = vs[i]*smoothing + prevEMA*(1-smoothing)
+	}
+
+	return avgs
+}
@@ -0,0 +1,64 @@
+package indicator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMACDCompute(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2018, time.September, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	values := func(vs ...float32) []*model.IndicatorValue {
+		var ivs []*model.IndicatorValue
+		for i, v := range vs {
+			ivs = append(ivs, &model.IndicatorValue{Date: day(i + 1), Value: v})
+		}
+		return ivs
+	}
+
+	for _, tt := range []struct {
+		desc   string
+		closes []float32
+		want   []*model.IndicatorSeries
+	}{
+		{
+			desc:   "not enough data",
+			closes: []float32{10, 12},
+			want: []*model.IndicatorSeries{
+				{Name: "MACD 1,2", Pane: MACDPane, Values: values(0, 0)},
+				{Name: "Signal 2", Pane: MACDPane, Values: values(0, 0)},
+				{Name: "Histogram", Pane: MACDPane, Levels: []float32{0}, Histogram: true, Values: values(0, 0)},
+			},
+		},
+		{
+			desc:   "gains and losses",
+			closes: []float32{10, 12, 11, 14, 13, 16},
+			// Slow EMA: 0, 0, 11, 13, 13, 15
+			// Signal: seeded by (0+1)/2, then 0*2/3+0.5/3, then 1*2/3+(1/6)/3
+			want: []*model.IndicatorSeries{
+				{Name: "MACD 1,2", Pane: MACDPane, Values: values(0, 0, 0, 1, 0, 1)},
+				{Name: "Signal 2", Pane: MACDPane, Values: values(0, 0, 0, 0, 1.0/6, 13.0/18)},
+				{Name: "Histogram", Pane: MACDPane, Levels: []float32{0}, Histogram: true, Values: values(0, 0, 0, 0, -1.0/6, 5.0/18)},
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ts := &model.TradingSessionSeries{}
+			for i, c := range tt.closes {
+				ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{Date: day(i + 1), Close: c})
+			}
+
+			got := NewMACD(1, 2, 2).Compute(ts)
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 0.001)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
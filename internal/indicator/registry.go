@@ -0,0 +1,53 @@
+package indicator
+
+import (
+	"sort"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// Factory creates an indicator from parameters like the number of periods.
+type Factory func(params ...int) (Indicator, error)
+
+// registry maps names like "sma" to factories. Built-in indicators are registered here.
+var registry = map[string]Factory{
+	"bb":   bollingerBandsFactory,
+	"ema":  movingAverageFactory(model.Exponential),
+	"kc":   keltnerChannelFactory,
+	"macd": macdFactory,
+	"rsi":  rsiFactory,
+	"sma":  movingAverageFactory(model.Simple),
+	"wma":  movingAverageFactory(model.Weighted),
+}
+
+// Register registers a factory under a name. It panics if the name is already taken.
+// Register is not safe for concurrent use and should be called during program initialization.
+func Register(name string, f Factory) {
+	if f == nil {
+		panic("indicator: nil factory for " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic("indicator: duplicate factory for " + name)
+	}
+	registry[name] = f
+}
+
+// New creates an indicator using the factory registered under the name.
+func New(name string, params ...int) (Indicator, error) {
+	f := registry[name]
+	if f == nil {
+		return nil, errs.Errorf("unknown indicator: %s", name)
+	}
+	return f(params...)
+}
+
+// Names returns the sorted names of the registered indicators.
+func Names() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+package indicator
+
+import (
+	"testing"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		inputName   string
+		inputParams []int
+		want        Indicator
+		wantErr     bool
+	}{
+		{
+			desc:        "simple moving average",
+			inputName:   "sma",
+			inputParams: []int{50},
+			want:        NewMovingAverage(model.Simple, 50),
+		},
+		{
+			desc:        "exponential moving average",
+			inputName:   "ema",
+			inputParams: []int{21},
+			want:        NewMovingAverage(model.Exponential, 21),
+		},
+		{
+			desc:        "missing periods",
+			inputName:   "sma",
+			inputParams: nil,
+			wantErr:     true,
+		},
+		{
+			desc:        "negative periods",
+			inputName:   "ema",
+			inputParams: []int{-1},
+			wantErr:     true,
+		},
+		{
+			desc:      "default macd",
+			inputName: "macd",
+			want:      NewMACD(12, 26, 9),
+		},
+		{
+			desc:        "macd with fast period after slow period",
+			inputName:   "macd",
+			inputParams: []int{26, 12, 9},
+			wantErr:     true,
+		},
+		{
+			desc:      "unknown indicator",
+			inputName: "foo",
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := New(tt.inputName, tt.inputParams...)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, wanted err: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
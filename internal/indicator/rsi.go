@@ -0,0 +1,105 @@
+package indicator
+
+import (
+	"fmt"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// DefaultRSIPeriods is the number of periods commonly used for the RSI.
+const DefaultRSIPeriods = 14
+
+// RSIPane is the name of the pane where the RSI is drawn.
+const RSIPane = "RSI"
+
+// RSI is the Relative Strength Index that measures the speed of price changes from 0 to 100.
+type RSI struct {
+	// Periods is how many days or weeks of price changes are averaged.
+	Periods int
+}
+
+// NewRSI returns a RSI over the given periods.
+func NewRSI(periods int) *RSI {
+	return &RSI{Periods: periods}
+}
+
+func rsiFactory(params ...int) (Indicator, error) {
+	if len(params) != 1 {
+		return nil, errs.Errorf("bad params: got %v, want periods", params)
+	}
+	if params[0] <= 0 {
+		return nil, errs.Errorf("bad periods: got %d, want > 0", params[0])
+	}
+	return NewRSI(params[0]), nil
+}
+
+// Compute implements the Indicator interface.
+func (r *RSI) Compute(ts *model.TradingSessionSeries) []*model.IndicatorSeries {
+	return []*model.IndicatorSeries{
+		{
+			Name:   fmt.Sprintf("RSI %d", r.Periods),
+			Pane:   RSIPane,
+			Range:  [2]float32{0, 100},
+			Levels: []float32{30, 70},
+			Values: relativeStrengthIndexes(ts.TradingSessions, r.Periods),
+		},
+	}
+}
+
+// relativeStrengthIndexes uses Wilder's smoothing to average the gains and losses.
+func relativeStrengthIndexes(ts []*model.TradingSession, n int) []*model.IndicatorValue {
+	var values []*model.IndicatorValue
+
+	var avgGain, avgLoss float32
+	for i := range ts {
+		var gain, loss float32
+		if i > 0 {
+			switch change := ts[i].Close - ts[i-1].Close; {
+			case change > 0:
+				gain = change
+			case change < 0:
+				loss = -change
+			}
+		}
+
+		var value float32
+		switch {
+		case i == 0:
+			// No change for the first session.
+
+		case i < n:
+			// Not enough changes yet, so just sum them up.
+			avgGain += gain
+			avgLoss += loss
+
+		case i == n:
+			// Use the simple average of the first changes.
+			avgGain = (avgGain + gain) / float32(n)
+			avgLoss = (avgLoss + loss) / float32(n)
+			value = rsiValue(avgGain, avgLoss)
+
+		default:
+			avgGain = (avgGain*float32(n-1) + gain) / float32(n)
+			avgLoss = (avgLoss*float32(n-1) + loss) / float32(n)
+			value = rsiValue(avgGain, avgLoss)
+		}
+
+		values = append(values, &model.IndicatorValue{
+			Date:  ts[i].Date,
+			Value: value,
+		})
+	}
+	return values
+}
+
+func rsiValue(avgGain, avgLoss float32) float32 {
+	// A flat series has neither gains nor losses, so it's neutral rather than overbought.
+	if avgGain == 0 && avgLoss == 0 {
+		return 50
+	}
+	if avgLoss == 0 {
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
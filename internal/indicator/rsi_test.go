@@ -0,0 +1,70 @@
+package indicator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRSICompute(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2018, time.September, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, tt := range []struct {
+		desc   string
+		closes []float32
+		want   []float32
+	}{
+		{
+			desc:   "not enough data",
+			closes: []float32{10, 11},
+			want:   []float32{0, 0},
+		},
+		{
+			desc:   "only gains",
+			closes: []float32{10, 11, 12, 13},
+			want:   []float32{0, 0, 100, 100},
+		},
+		{
+			desc:   "no change",
+			closes: []float32{10, 10, 10, 10},
+			want:   []float32{0, 0, 50, 50},
+		},
+		{
+			desc:   "gains and losses",
+			closes: []float32{10, 12, 11, 14},
+			// Avg gain: (2+0)/2 = 1, avg loss: (0+1)/2 = 0.5 -> 100-100/3
+			// Avg gain: (1+3)/2 = 2, avg loss: (0.5+0)/2 = 0.25 -> 100-100/9
+			want: []float32{0, 0, 100 - 100.0/3, 100 - 100.0/9},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ts := &model.TradingSessionSeries{}
+			var wantValues []*model.IndicatorValue
+			for i, c := range tt.closes {
+				ts.TradingSessions = append(ts.TradingSessions, &model.TradingSession{Date: day(i + 1), Close: c})
+				wantValues = append(wantValues, &model.IndicatorValue{Date: day(i + 1), Value: tt.want[i]})
+			}
+
+			want := []*model.IndicatorSeries{
+				{
+					Name:   "RSI 2",
+					Pane:   RSIPane,
+					Range:  [2]float32{0, 100},
+					Levels: []float32{30, 70},
+					Values: wantValues,
+				},
+			}
+
+			got := NewRSI(2).Compute(ts)
+
+			if diff := cmp.Diff(want, got, cmpopts.EquateApprox(0, 0.001)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
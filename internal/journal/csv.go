@@ -0,0 +1,179 @@
+package journal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/logger"
+)
+
+// csvHeader is the header row of exported CSV files. Imported files must have these columns in any order.
+var csvHeader = []string{"Symbol", "Side", "Quantity", "Price", "Time", "Note"}
+
+// csvTimeLayouts are the layouts of the Time column. Exports use the first one.
+var csvTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ImportCSV reads trades from a CSV file with the columns of the header in any order.
+func ImportCSV(r io.Reader) ([]*Trade, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errs.Errorf("missing header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	column2Index := map[string]int{}
+	for i, name := range header {
+		column2Index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, c := range csvHeader {
+		// Notes are optional.
+		if c == "Note" {
+			continue
+		}
+		if _, ok := column2Index[strings.ToLower(c)]; !ok {
+			return nil, errs.Errorf("missing %s column in header: %v", c, header)
+		}
+	}
+
+	var trades []*Trade
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(column string) string {
+			i, ok := column2Index[strings.ToLower(column)]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		t, err := csvTrade(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		trades = append(trades, t)
+	}
+
+	return trades, nil
+}
+
+// csvTrade returns the trade with the values of a CSV record's columns.
+func csvTrade(value func(column string) string) (*Trade, error) {
+	side, err := parseSide(value("Side"))
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := strconv.ParseFloat(value("Quantity"), 32)
+	if err != nil {
+		return nil, errs.Errorf("bad quantity: %q", value("Quantity"))
+	}
+
+	price, err := strconv.ParseFloat(strings.TrimPrefix(value("Price"), "$"), 32)
+	if err != nil {
+		return nil, errs.Errorf("bad price: %q", value("Price"))
+	}
+
+	var tm time.Time
+	for _, layout := range csvTimeLayouts {
+		if tm, err = time.Parse(layout, value("Time")); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, errs.Errorf("bad time: %q", value("Time"))
+	}
+
+	t := &Trade{
+		Symbol:   strings.ToUpper(value("Symbol")),
+		Side:     side,
+		Quantity: float32(q),
+		Price:    float32(price),
+		Time:     tm,
+		Note:     value("Note"),
+	}
+
+	if err := validateTrade(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ExportCSV writes the trades to a CSV file that ImportCSV can read.
+func ExportCSV(w io.Writer, trades []*Trade) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, t := range trades {
+		if err := cw.Write([]string{
+			t.Symbol,
+			t.Side.String(),
+			strconv.FormatFloat(float64(t.Quantity), 'f', -1, 32),
+			strconv.FormatFloat(float64(t.Price), 'f', -1, 32),
+			t.Time.Format(csvTimeLayouts[0]),
+			t.Note,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSVFile reads trades from a CSV file at the path. See ImportCSV for the format.
+func ImportCSVFile(path string) ([]*Trade, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing %s failed: %v", path, err)
+		}
+	}()
+
+	return ImportCSV(file)
+}
+
+// ExportCSVFile writes the trades to a CSV file at the path. See ExportCSV for the format.
+func ExportCSVFile(path string, trades []*Trade) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing %s failed: %v", path, err)
+		}
+	}()
+
+	return ExportCSV(file, trades)
+}
@@ -0,0 +1,97 @@
+package journal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestImportCSV(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		input   string
+		want    []*Trade
+		wantErr bool
+	}{
+		{
+			desc: "simple",
+			input: "Symbol,Side,Quantity,Price,Time,Note\n" +
+				"AAPL,buy,10,150.25,2020-01-02T09:30:00Z,breakout\n" +
+				"aapl,Sell,10,$160,2020-01-10,\n",
+			want: []*Trade{
+				{Symbol: "AAPL", Side: Buy, Quantity: 10, Price: 150.25, Time: time.Date(2020, time.January, 2, 9, 30, 0, 0, time.UTC), Note: "breakout"},
+				{Symbol: "AAPL", Side: Sell, Quantity: 10, Price: 160, Time: time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc: "columns in any order without notes",
+			input: "Time,Price,Quantity,Side,Symbol\n" +
+				"2020-01-02 09:30,150.25,10,b,AAPL\n",
+			want: []*Trade{
+				{Symbol: "AAPL", Side: Buy, Quantity: 10, Price: 150.25, Time: time.Date(2020, time.January, 2, 9, 30, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc:    "empty",
+			wantErr: true,
+		},
+		{
+			desc:    "missing side column",
+			input:   "Symbol,Quantity,Price,Time\nAAPL,10,150,2020-01-02\n",
+			wantErr: true,
+		},
+		{
+			desc:    "bad side",
+			input:   "Symbol,Side,Quantity,Price,Time\nAAPL,hold,10,150,2020-01-02\n",
+			wantErr: true,
+		},
+		{
+			desc:    "bad time",
+			input:   "Symbol,Side,Quantity,Price,Time\nAAPL,buy,10,150,yesterday\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := ImportCSV(strings.NewReader(tt.input))
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	trades := []*Trade{
+		{Symbol: "AAPL", Side: Buy, Quantity: 10, Price: 150.25, Time: time.Date(2020, time.January, 2, 9, 30, 0, 0, time.UTC), Note: "breakout, volume"},
+		{Symbol: "AAPL", Side: Sell, Quantity: 2.5, Price: 160, Time: time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, trades); err != nil {
+		t.Fatalf("ExportCSV returned error: %v", err)
+	}
+
+	want := "Symbol,Side,Quantity,Price,Time,Note\n" +
+		"AAPL,buy,10,150.25,2020-01-02T09:30:00Z,\"breakout, volume\"\n" +
+		"AAPL,sell,2.5,160,2020-01-10T00:00:00Z,\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	got, err := ImportCSV(&buf)
+	if err != nil {
+		t.Fatalf("ImportCSV of export returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(trades, got); diff != "" {
+		t.Errorf("round trip diff (-want, +got)\n%s", diff)
+	}
+}
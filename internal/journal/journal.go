@@ -0,0 +1,196 @@
+// Package journal records the user's trades to review them against charts.
+//
+// Trades are entered manually with specs like the following or imported from CSV files:
+//
+//	buy 10 @ 150.25                   bought 10 shares at 150.25 now
+//	sell 10 @ 160 took profits early  sold 10 shares at 160 now with a note
+//
+// The journal is stored in a gob file and can be exported to and imported from CSV files.
+package journal
+
+import (
+	"encoding/gob"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/logger"
+)
+
+// Side is whether a trade bought or sold shares.
+type Side int
+
+// Side values.
+const (
+	SideUnspecified Side = iota
+	Buy
+	Sell
+)
+
+// String returns the lowercase name of the side like "buy".
+func (s Side) String() string {
+	switch s {
+	case Buy:
+		return "buy"
+	case Sell:
+		return "sell"
+	default:
+		return "?"
+	}
+}
+
+// parseSide parses a case insensitive side like "buy" or "sell".
+func parseSide(s string) (Side, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "buy", "b":
+		return Buy, nil
+	case "sell", "s":
+		return Sell, nil
+	default:
+		return SideUnspecified, errs.Errorf("bad side: %q, want buy or sell", s)
+	}
+}
+
+// Trade is a single buy or sell of shares.
+// Fields are exported for gob encoding and decoding.
+type Trade struct {
+	// Symbol is the symbol of the shares.
+	Symbol string
+
+	// Side is whether the shares were bought or sold.
+	Side Side
+
+	// Quantity is the number of shares.
+	Quantity float32
+
+	// Price is the price per share.
+	Price float32
+
+	// Time is when the trade happened.
+	Time time.Time
+
+	// Note is why the trade was made or what was learned from it.
+	Note string
+}
+
+// PL returns the profit or loss since entering the trade in dollars and as a fraction like 0.1 for 10%
+// if the trade was closed at the price. Sells profit when the price falls.
+func (t *Trade) PL(price float32) (dollars, percent float32) {
+	change := price - t.Price
+	if t.Side == Sell {
+		change = -change
+	}
+
+	dollars = change * t.Quantity
+	if t.Price != 0 {
+		percent = change / t.Price
+	}
+	return dollars, percent
+}
+
+// validateTrade returns an error if the trade is missing fields or has non-positive values.
+func validateTrade(t *Trade) error {
+	if err := model.ValidateSymbol(t.Symbol); err != nil {
+		return err
+	}
+
+	if t.Side == SideUnspecified {
+		return errs.Errorf("unspecified side")
+	}
+
+	if t.Quantity <= 0 {
+		return errs.Errorf("quantity must be positive: %v", t.Quantity)
+	}
+
+	if t.Price <= 0 {
+		return errs.Errorf("price must be positive: %v", t.Price)
+	}
+
+	if t.Time.IsZero() {
+		return errs.Errorf("missing time")
+	}
+
+	return nil
+}
+
+// tradeSpecRegexp matches specs like "buy 10 @ 150.25" with an optional note after the price.
+var tradeSpecRegexp = regexp.MustCompile(`(?i)^(buy|sell)\s+([0-9.]+)\s*@\s*\$?([0-9.]+)(?:\s+(.*))?$`)
+
+// ParseTrade parses a spec like "buy 10 @ 150.25 breakout" into a trade of the symbol at the time.
+func ParseTrade(symbol, spec string, now time.Time) (*Trade, error) {
+	m := tradeSpecRegexp.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return nil, errs.Errorf("bad trade: %q, want like buy 10 @ 150.25 note", spec)
+	}
+
+	side, err := parseSide(m[1])
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := strconv.ParseFloat(m[2], 32)
+	if err != nil {
+		return nil, errs.Errorf("bad quantity: %q", m[2])
+	}
+
+	price, err := strconv.ParseFloat(m[3], 32)
+	if err != nil {
+		return nil, errs.Errorf("bad price: %q", m[3])
+	}
+
+	t := &Trade{
+		Symbol:   symbol,
+		Side:     side,
+		Quantity: float32(q),
+		Price:    float32(price),
+		Time:     now,
+		Note:     strings.TrimSpace(m[4]),
+	}
+
+	if err := validateTrade(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Load loads the trades from the journal file at the path. No trades if the file does not exist.
+func Load(path string) ([]*Trade, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing in load failed: %v", err)
+		}
+	}()
+
+	var trades []*Trade
+	if err := gob.NewDecoder(file).Decode(&trades); err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+// Save saves the trades to the journal file at the path.
+func Save(path string, trades []*Trade) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing in save failed: %v", err)
+		}
+	}()
+
+	return gob.NewEncoder(file).Encode(trades)
+}
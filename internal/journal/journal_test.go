@@ -0,0 +1,140 @@
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTrade(t *testing.T) {
+	now := time.Date(2020, time.July, 10, 9, 30, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		desc    string
+		symbol  string
+		spec    string
+		want    *Trade
+		wantErr bool
+	}{
+		{
+			desc:   "buy",
+			symbol: "AAPL",
+			spec:   "buy 10 @ 150.25",
+			want:   &Trade{Symbol: "AAPL", Side: Buy, Quantity: 10, Price: 150.25, Time: now},
+		},
+		{
+			desc:   "sell with note and dollar sign",
+			symbol: "AAPL",
+			spec:   "SELL 5@$160 took profits at resistance",
+			want:   &Trade{Symbol: "AAPL", Side: Sell, Quantity: 5, Price: 160, Time: now, Note: "took profits at resistance"},
+		},
+		{
+			desc:    "missing side",
+			symbol:  "AAPL",
+			spec:    "10 @ 150",
+			wantErr: true,
+		},
+		{
+			desc:    "zero quantity",
+			symbol:  "AAPL",
+			spec:    "buy 0 @ 150",
+			wantErr: true,
+		},
+		{
+			desc:    "bad symbol",
+			symbol:  "",
+			spec:    "buy 10 @ 150",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := ParseTrade(tt.symbol, tt.spec, now)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTradePL(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		trade       *Trade
+		price       float32
+		wantDollars float32
+		wantPercent float32
+	}{
+		{
+			desc:        "buy gain",
+			trade:       &Trade{Side: Buy, Quantity: 10, Price: 100},
+			price:       110,
+			wantDollars: 100,
+			wantPercent: 0.1,
+		},
+		{
+			desc:        "buy loss",
+			trade:       &Trade{Side: Buy, Quantity: 10, Price: 100},
+			price:       90,
+			wantDollars: -100,
+			wantPercent: -0.1,
+		},
+		{
+			desc:        "sell gain when price falls",
+			trade:       &Trade{Side: Sell, Quantity: 10, Price: 100},
+			price:       90,
+			wantDollars: 100,
+			wantPercent: 0.1,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotDollars, gotPercent := tt.trade.PL(tt.price)
+			if gotDollars != tt.wantDollars || gotPercent != tt.wantPercent {
+				t.Errorf("got (%v, %v), want (%v, %v)", gotDollars, gotPercent, tt.wantDollars, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatalf("TempDir returned error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "journal.gob")
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(%q) of missing file returned error: %v", path, err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Load(%q) of missing file = %v, want no trades", path, got)
+	}
+
+	want := []*Trade{
+		{Symbol: "AAPL", Side: Buy, Quantity: 10, Price: 150.25, Time: time.Date(2020, time.July, 10, 9, 30, 0, 0, time.UTC), Note: "breakout"},
+	}
+
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save(%q) returned error: %v", path, err)
+	}
+
+	got, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load(%q) returned error: %v", path, err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
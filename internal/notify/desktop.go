@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// appName is the name of the app shown with desktop notifications.
+const appName = "ponzi2"
+
+// desktopTimeout is how long desktop notifications are shown.
+const desktopTimeout = 10 * time.Second
+
+// desktopBus sends notifications to the freedesktop notification server.
+type desktopBus interface {
+	notify(ctx context.Context, summary, body string, timeout time.Duration) error
+}
+
+// commandRunner runs a command with the arguments. Replaced in tests.
+type commandRunner func(ctx context.Context, name string, args ...string) error
+
+// DesktopNotifier shows events as freedesktop notifications.
+type DesktopNotifier struct {
+	bus desktopBus
+}
+
+// NewDesktopNotifier returns a DesktopNotifier that calls the notification server over D-Bus with gdbus,
+// or runs notify-send if gdbus is not installed.
+func NewDesktopNotifier() (*DesktopNotifier, error) {
+	if _, err := exec.LookPath("gdbus"); err == nil {
+		return &DesktopNotifier{bus: &dbusBus{run: runCommand}}, nil
+	}
+
+	if _, err := exec.LookPath("notify-send"); err == nil {
+		return &DesktopNotifier{bus: &notifySendBus{run: runCommand}}, nil
+	}
+
+	return nil, errs.Errorf("desktop notifications need gdbus or notify-send")
+}
+
+// Notify implements the Notifier interface.
+func (d *DesktopNotifier) Notify(ctx context.Context, e *Event) error {
+	if err := validateEvent(e); err != nil {
+		return err
+	}
+	return d.bus.notify(ctx, e.Title, e.Message, desktopTimeout)
+}
+
+// dbusBus calls the Notify method of the org.freedesktop.Notifications service with gdbus.
+type dbusBus struct {
+	run commandRunner
+}
+
+func (b *dbusBus) notify(ctx context.Context, summary, body string, timeout time.Duration) error {
+	return b.run(ctx, "gdbus", "call", "--session",
+		"--dest", "org.freedesktop.Notifications",
+		"--object-path", "/org/freedesktop/Notifications",
+		"--method", "org.freedesktop.Notifications.Notify",
+		gvariantString(appName), // app_name
+		"0",                     // replaces_id
+		gvariantString(""),      // app_icon
+		gvariantString(summary), // summary
+		gvariantString(body),    // body
+		"[]",                    // actions
+		"{}",                    // hints
+		strconv.Itoa(int(timeout/time.Millisecond))) // expire_timeout
+}
+
+// notifySendBus runs notify-send which calls the notification server itself.
+type notifySendBus struct {
+	run commandRunner
+}
+
+func (b *notifySendBus) notify(ctx context.Context, summary, body string, timeout time.Duration) error {
+	return b.run(ctx, "notify-send",
+		"--app-name", appName,
+		"--expire-time", strconv.Itoa(int(timeout/time.Millisecond)),
+		"--", summary, body)
+}
+
+// gvariantString returns the string in the GVariant text format that gdbus parses its arguments in.
+func gvariantString(s string) string {
+	return strconv.Quote(s)
+}
+
+func runCommand(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return errs.Errorf("%s failed: %v: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
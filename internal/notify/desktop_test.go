@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeDesktopBus is a fake D-Bus sink that records the notifications sent to it.
+type fakeDesktopBus struct {
+	notifications []fakeNotification
+	err           error
+}
+
+type fakeNotification struct {
+	Summary string
+	Body    string
+	Timeout time.Duration
+}
+
+func (f *fakeDesktopBus) notify(ctx context.Context, summary, body string, timeout time.Duration) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.notifications = append(f.notifications, fakeNotification{summary, body, timeout})
+	return nil
+}
+
+func TestDesktopNotifier(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		input   *Event
+		busErr  error
+		want    []fakeNotification
+		wantErr bool
+	}{
+		{
+			desc: "alert",
+			input: &Event{
+				Kind:    AlertTriggered,
+				Symbol:  "AAPL",
+				Title:   "AAPL alert",
+				Message: "AAPL crossed above 150.00",
+			},
+			want: []fakeNotification{
+				{"AAPL alert", "AAPL crossed above 150.00", desktopTimeout},
+			},
+		},
+		{
+			desc:    "missing event",
+			wantErr: true,
+		},
+		{
+			desc: "unspecified kind",
+			input: &Event{
+				Title: "AAPL alert",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "bus error",
+			input: &Event{
+				Kind:  Test,
+				Title: "ponzi2 test",
+			},
+			busErr:  errors.New("no notification server"),
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			bus := &fakeDesktopBus{err: tt.busErr}
+			d := &DesktopNotifier{bus: bus}
+
+			gotErr := d.Notify(context.Background(), tt.input) != nil
+
+			if diff := cmp.Diff(tt.want, bus.notifications); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if gotErr != tt.wantErr {
+				t.Errorf("got error: %t, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDesktopBusCommands(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		bus  func(run commandRunner) desktopBus
+		want []string
+	}{
+		{
+			desc: "gdbus",
+			bus:  func(run commandRunner) desktopBus { return &dbusBus{run: run} },
+			want: []string{
+				"gdbus", "call", "--session",
+				"--dest", "org.freedesktop.Notifications",
+				"--object-path", "/org/freedesktop/Notifications",
+				"--method", "org.freedesktop.Notifications.Notify",
+				`"ponzi2"`, "0", `""`, `"AAPL alert"`, `"AAPL said \"hi\""`, "[]", "{}", "5000",
+			},
+		},
+		{
+			desc: "notify-send",
+			bus:  func(run commandRunner) desktopBus { return &notifySendBus{run: run} },
+			want: []string{
+				"notify-send", "--app-name", "ponzi2", "--expire-time", "5000",
+				"--", "AAPL alert", `AAPL said "hi"`,
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			run := func(ctx context.Context, name string, args ...string) error {
+				got = append([]string{name}, args...)
+				return nil
+			}
+
+			if err := tt.bus(run).notify(context.Background(), "AAPL alert", `AAPL said "hi"`, 5*time.Second); err != nil {
+				t.Fatalf("notify returned an error (%v), want success", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package notify sends noteworthy events like triggered alerts to the desktop or to webhooks.
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// EventKind is the kind of a noteworthy event.
+type EventKind int
+
+// EventKind values.
+const (
+	EventKindUnspecified EventKind = iota
+
+	// AlertTriggered is when an alert on a symbol triggers.
+	AlertTriggered
+
+	// NewHigh is when a symbol trades at a new 52-week high.
+	NewHigh
+
+	// RefreshFailed is when a refresh fails for every symbol.
+	RefreshFailed
+
+	// Test is a test event to check that a notifier works.
+	Test
+)
+
+// String returns a short name of the kind like "alert" that webhooks can switch on.
+func (k EventKind) String() string {
+	switch k {
+	case AlertTriggered:
+		return "alert"
+	case NewHigh:
+		return "new_high"
+	case RefreshFailed:
+		return "refresh_failed"
+	case Test:
+		return "test"
+	default:
+		return "unspecified"
+	}
+}
+
+// Event is a noteworthy event to notify the user about.
+type Event struct {
+	// Kind is the kind of event.
+	Kind EventKind
+
+	// Symbol is the symbol the event is about. Empty if not about a single symbol.
+	Symbol string
+
+	// Title is a short summary like "AAPL alert".
+	Title string
+
+	// Message describes the event like "AAPL crossed above 150.00".
+	Message string
+
+	// Time is when the event happened.
+	Time time.Time
+}
+
+// Notifier sends events to the user.
+type Notifier interface {
+	// Notify sends the event or returns an error if it could not be sent.
+	Notify(ctx context.Context, e *Event) error
+}
+
+// validateEvent returns an error if the event is missing fields needed by every notifier.
+func validateEvent(e *Event) error {
+	if e == nil {
+		return errs.Errorf("missing event")
+	}
+
+	if e.Kind == EventKindUnspecified {
+		return errs.Errorf("unspecified event kind")
+	}
+
+	if e.Title == "" {
+		return errs.Errorf("missing title")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when an event is dropped because too many were sent recently.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimiter wraps a notifier and drops events beyond a maximum number per window. It is thread-safe.
+type RateLimiter struct {
+	// notifier is the notifier that sends the events that are not dropped.
+	notifier Notifier
+
+	// max is the maximum number of events to send per window.
+	max int
+
+	// window is the duration of the sliding window.
+	window time.Duration
+
+	// sent are the times of the events sent within the last window from oldest to newest.
+	sent []time.Time
+
+	// now returns the current time. Replaced in tests.
+	now func() time.Time
+
+	// mutex guards sent.
+	mutex sync.Mutex
+}
+
+// NewRateLimiter returns a RateLimiter that sends at most max events per window through the notifier.
+func NewRateLimiter(notifier Notifier, max int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		notifier: notifier,
+		max:      max,
+		window:   window,
+		now:      time.Now,
+	}
+}
+
+// Notify implements the Notifier interface. It returns ErrRateLimited if the event was dropped.
+func (r *RateLimiter) Notify(ctx context.Context, e *Event) error {
+	if !r.allow() {
+		return ErrRateLimited
+	}
+	return r.notifier.Notify(ctx, e)
+}
+
+// allow returns true and records the send if fewer than the maximum events were sent within the window.
+func (r *RateLimiter) allow() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+
+	// Forget the sends that fell out of the window.
+	i := 0
+	for i < len(r.sent) && now.Sub(r.sent[i]) >= r.window {
+		i++
+	}
+	r.sent = r.sent[i:]
+
+	if len(r.sent) >= r.max {
+		return false
+	}
+
+	r.sent = append(r.sent, now)
+	return true
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeNotifier records the titles of the events sent to it.
+type fakeNotifier struct {
+	titles []string
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, e *Event) error {
+	f.titles = append(f.titles, e.Title)
+	return nil
+}
+
+func TestRateLimiter(t *testing.T) {
+	start := time.Date(2020, time.July, 10, 16, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		desc        string
+		max         int
+		window      time.Duration
+		offsets     []time.Duration
+		want        []string
+		wantLimited []bool
+	}{
+		{
+			desc:        "under the limit",
+			max:         2,
+			window:      time.Minute,
+			offsets:     []time.Duration{0, 10 * time.Second},
+			want:        []string{"0", "1"},
+			wantLimited: []bool{false, false},
+		},
+		{
+			desc:        "over the limit",
+			max:         2,
+			window:      time.Minute,
+			offsets:     []time.Duration{0, 10 * time.Second, 20 * time.Second},
+			want:        []string{"0", "1"},
+			wantLimited: []bool{false, false, true},
+		},
+		{
+			desc:        "window slides",
+			max:         1,
+			window:      time.Minute,
+			offsets:     []time.Duration{0, 30 * time.Second, time.Minute, 90 * time.Second},
+			want:        []string{"0", "2"},
+			wantLimited: []bool{false, true, false, true},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &fakeNotifier{}
+			r := NewRateLimiter(f, tt.max, tt.window)
+
+			var gotLimited []bool
+			for i, off := range tt.offsets {
+				r.now = func() time.Time { return start.Add(off) }
+				err := r.Notify(context.Background(), &Event{Kind: Test, Title: string('0' + rune(i))})
+				gotLimited = append(gotLimited, err == ErrRateLimited)
+			}
+
+			if diff := cmp.Diff(tt.want, f.titles); diff != "" {
+				t.Errorf("sent diff (-want, +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantLimited, gotLimited); diff != "" {
+				t.Errorf("limited diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/logger"
+)
+
+// webhookTimeout is how long to wait for a webhook to respond.
+const webhookTimeout = 10 * time.Second
+
+// WebhookNotifier posts events as JSON to a URL.
+type WebhookNotifier struct {
+	// url is the URL to post events to.
+	url string
+
+	// client is the client to post with.
+	client *http.Client
+}
+
+// webhookPayload is the JSON posted to webhooks.
+// Text is a summary that chat relays like Slack's incoming webhooks can show as is.
+type webhookPayload struct {
+	Text    string    `json:"text"`
+	Kind    string    `json:"kind"`
+	Symbol  string    `json:"symbol,omitempty"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// NewWebhookNotifier returns a WebhookNotifier that posts to the URL.
+func NewWebhookNotifier(url string) (*WebhookNotifier, error) {
+	if url == "" {
+		return nil, errs.Errorf("missing webhook url")
+	}
+
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+// Notify implements the Notifier interface.
+func (w *WebhookNotifier) Notify(ctx context.Context, e *Event) error {
+	if err := validateEvent(e); err != nil {
+		return err
+	}
+
+	text := e.Title
+	if e.Message != "" {
+		text = fmt.Sprintf("%s: %s", e.Title, e.Message)
+	}
+
+	body, err := json.Marshal(&webhookPayload{
+		Text:    text,
+		Kind:    e.Kind.String(),
+		Symbol:  e.Symbol,
+		Title:   e.Title,
+		Message: e.Message,
+		Time:    e.Time,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Error(err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errs.Errorf("webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
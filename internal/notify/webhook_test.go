@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		input       *Event
+		status      int
+		wantPayload *webhookPayload
+		wantErr     bool
+	}{
+		{
+			desc: "alert",
+			input: &Event{
+				Kind:    AlertTriggered,
+				Symbol:  "AAPL",
+				Title:   "AAPL alert",
+				Message: "AAPL crossed above 150.00",
+				Time:    time.Date(2020, time.July, 10, 16, 0, 0, 0, time.UTC),
+			},
+			status: http.StatusOK,
+			wantPayload: &webhookPayload{
+				Text:    "AAPL alert: AAPL crossed above 150.00",
+				Kind:    "alert",
+				Symbol:  "AAPL",
+				Title:   "AAPL alert",
+				Message: "AAPL crossed above 150.00",
+				Time:    time.Date(2020, time.July, 10, 16, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			desc: "no symbol or message",
+			input: &Event{
+				Kind:  Test,
+				Title: "ponzi2 test",
+			},
+			status: http.StatusNoContent,
+			wantPayload: &webhookPayload{
+				Text:  "ponzi2 test",
+				Kind:  "test",
+				Title: "ponzi2 test",
+			},
+		},
+		{
+			desc: "server error",
+			input: &Event{
+				Kind:  Test,
+				Title: "ponzi2 test",
+			},
+			status: http.StatusInternalServerError,
+			wantPayload: &webhookPayload{
+				Text:  "ponzi2 test",
+				Kind:  "test",
+				Title: "ponzi2 test",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "missing title",
+			input: &Event{
+				Kind: Test,
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var gotPayload *webhookPayload
+			var gotContentType string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotContentType = r.Header.Get("Content-Type")
+				gotPayload = &webhookPayload{}
+				if err := json.NewDecoder(r.Body).Decode(gotPayload); err != nil {
+					t.Errorf("decoding payload failed: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			w, err := NewWebhookNotifier(srv.URL)
+			if err != nil {
+				t.Fatalf("NewWebhookNotifier returned an error (%v), want success", err)
+			}
+
+			gotErr := w.Notify(context.Background(), tt.input) != nil
+
+			if diff := cmp.Diff(tt.wantPayload, gotPayload); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if gotPayload != nil && gotContentType != "application/json" {
+				t.Errorf("got content type %q, want application/json", gotContentType)
+			}
+
+			if gotErr != tt.wantErr {
+				t.Errorf("got error: %t, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWebhookNotifier_MissingURL(t *testing.T) {
+	if _, err := NewWebhookNotifier(""); err == nil {
+		t.Error("NewWebhookNotifier should return an error for a missing url")
+	}
+}
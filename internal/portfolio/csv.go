@@ -0,0 +1,136 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+	"github.com/btmura/ponzi2/internal/logger"
+)
+
+// csvColumns maps lowercase header names that brokers use to the lot field of the column.
+// Only per share prices are supported, since brokers use "cost basis" for both per share and total costs.
+var csvColumns = map[string]string{
+	"symbol":               "symbol",
+	"ticker":               "symbol",
+	"quantity":             "quantity",
+	"qty":                  "quantity",
+	"shares":               "quantity",
+	"price":                "price",
+	"cost/share":           "price",
+	"cost per share":       "price",
+	"cost basis per share": "price",
+	"purchase price":       "price",
+	"unit cost":            "price",
+	"date":                 "date",
+	"date acquired":        "date",
+	"acquired":             "date",
+	"open date":            "date",
+	"purchase date":        "date",
+	"trade date":           "date",
+}
+
+// ImportCSV reads lots from a CSV file with a header row like "Symbol,Quantity,Price,Date".
+// Columns are matched by common broker header names and other columns are ignored.
+// Lots without dates are dated today.
+func ImportCSV(r io.Reader, today time.Time) ([]*Lot, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errs.Errorf("missing header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	field2Index := map[string]int{}
+	for i, name := range header {
+		if f, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, dup := field2Index[f]; !dup {
+				field2Index[f] = i
+			}
+		}
+	}
+
+	for _, f := range []string{"symbol", "quantity", "price"} {
+		if _, ok := field2Index[f]; !ok {
+			return nil, errs.Errorf("missing %s column in header: %v", f, header)
+		}
+	}
+
+	var lots []*Lot
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(f string) string {
+			i, ok := field2Index[f]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		// Skip blank lines and the totals rows that brokers add at the end.
+		symbol := strings.ToUpper(value("symbol"))
+		if symbol == "" || strings.Contains(symbol, "TOTAL") {
+			continue
+		}
+
+		if err := model.ValidateSymbol(symbol); err != nil {
+			return nil, errs.Errorf("line %d: %v", line, err)
+		}
+
+		q, err := parseNumber(value("quantity"))
+		if err != nil {
+			return nil, errs.Errorf("line %d: %v", line, err)
+		}
+
+		price, err := parseNumber(value("price"))
+		if err != nil {
+			return nil, errs.Errorf("line %d: %v", line, err)
+		}
+
+		date := today
+		if d := value("date"); d != "" {
+			if date, err = parseDate(d); err != nil {
+				return nil, errs.Errorf("line %d: %v", line, err)
+			}
+		}
+
+		l, err := newLot(symbol, q, price, date)
+		if err != nil {
+			return nil, errs.Errorf("line %d: %v", line, err)
+		}
+		lots = append(lots, l)
+	}
+
+	return lots, nil
+}
+
+// ImportCSVFile reads lots from a CSV file at the path. See ImportCSV for the format.
+func ImportCSVFile(path string, today time.Time) ([]*Lot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing %s failed: %v", path, err)
+		}
+	}()
+
+	return ImportCSV(file, today)
+}
@@ -0,0 +1,72 @@
+package portfolio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestImportCSV(t *testing.T) {
+	today := time.Date(2020, time.July, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		desc    string
+		input   string
+		want    []*Lot
+		wantErr bool
+	}{
+		{
+			desc: "simple",
+			input: "Symbol,Quantity,Price,Date\n" +
+				"AAPL,10,150.25,2020-01-02\n" +
+				"MSFT,5,200,\n",
+			want: []*Lot{
+				{Symbol: "AAPL", Quantity: 10, Price: 150.25, Date: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+				{Symbol: "MSFT", Quantity: 5, Price: 200, Date: today},
+			},
+		},
+		{
+			desc: "broker export with extra columns, dollars, and totals",
+			input: "Account,Ticker,Description,Shares,Cost/Share,Date Acquired\n" +
+				`X123,aapl,Apple Inc,"1,000",$150.25,01/02/2020` + "\n" +
+				",,,,,\n" +
+				"X123,Total,,,,\n",
+			want: []*Lot{
+				{Symbol: "AAPL", Quantity: 1000, Price: 150.25, Date: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc:    "empty",
+			wantErr: true,
+		},
+		{
+			desc:    "missing price column",
+			input:   "Symbol,Quantity\nAAPL,10\n",
+			wantErr: true,
+		},
+		{
+			desc:    "bad quantity",
+			input:   "Symbol,Quantity,Price\nAAPL,ten,150\n",
+			wantErr: true,
+		},
+		{
+			desc:    "bad symbol",
+			input:   "Symbol,Quantity,Price\nBRK B,1,150\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := ImportCSV(strings.NewReader(tt.input), today)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
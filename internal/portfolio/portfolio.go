@@ -0,0 +1,222 @@
+// Package portfolio tracks lots of shares bought at a cost basis and their unrealized profit and loss.
+//
+// Lots are entered manually with specs like the following or imported from broker CSV files:
+//
+//	10 @ 150.25               10 shares at 150.25 bought today
+//	10 @ 150.25 2020-01-02    10 shares at 150.25 bought on January 2, 2020
+package portfolio
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// Lot is a number of shares of a symbol bought at a price on a date.
+// Fields are exported for gob encoding and decoding.
+type Lot struct {
+	// Symbol is the symbol of the shares.
+	Symbol string
+
+	// Quantity is the number of shares.
+	Quantity float32
+
+	// Price is the price paid per share.
+	Price float32
+
+	// Date is when the shares were bought.
+	Date time.Time
+}
+
+// Position is all the lots of a symbol.
+type Position struct {
+	// Symbol is the symbol of the lots.
+	Symbol string
+
+	// Lots are the lots of the symbol in the order they were added.
+	Lots []*Lot
+}
+
+// Quantity returns the total number of shares of the lots.
+func (p *Position) Quantity() float32 {
+	var q float32
+	for _, l := range p.Lots {
+		q += l.Quantity
+	}
+	return q
+}
+
+// CostBasis returns the total price paid for the lots.
+func (p *Position) CostBasis() float32 {
+	var c float32
+	for _, l := range p.Lots {
+		c += l.Quantity * l.Price
+	}
+	return c
+}
+
+// AveragePrice returns the average price paid per share. Zero if there are no shares.
+func (p *Position) AveragePrice() float32 {
+	q := p.Quantity()
+	if q == 0 {
+		return 0
+	}
+	return p.CostBasis() / q
+}
+
+// PL returns the unrealized profit or loss in dollars and as a fraction of the cost basis like 0.1 for 10%
+// if the shares were sold at the price.
+func (p *Position) PL(price float32) (dollars, percent float32) {
+	c := p.CostBasis()
+	dollars = p.Quantity()*price - c
+	if c != 0 {
+		percent = dollars / c
+	}
+	return dollars, percent
+}
+
+// Positions groups the lots by symbol in the order the symbols first appear.
+func Positions(lots []*Lot) []*Position {
+	var positions []*Position
+	symbol2Position := map[string]*Position{}
+	for _, l := range lots {
+		p := symbol2Position[l.Symbol]
+		if p == nil {
+			p = &Position{Symbol: l.Symbol}
+			symbol2Position[l.Symbol] = p
+			positions = append(positions, p)
+		}
+		p.Lots = append(p.Lots, l)
+	}
+	return positions
+}
+
+// Summary is the value and unrealized profit or loss of positions.
+type Summary struct {
+	// Value is what the positions are worth at the latest prices.
+	Value float32
+
+	// CostBasis is the total price paid for the positions.
+	CostBasis float32
+
+	// PL is the unrealized profit or loss in dollars.
+	PL float32
+
+	// PercentPL is the unrealized profit or loss as a fraction of the cost basis like 0.1 for 10%.
+	PercentPL float32
+}
+
+// Summarize returns the summary of the positions at the latest prices by symbol.
+// Positions without prices are skipped. Nil if no positions have prices.
+func Summarize(positions []*Position, prices map[string]float32) *Summary {
+	var s *Summary
+	for _, p := range positions {
+		price, ok := prices[p.Symbol]
+		if !ok {
+			continue
+		}
+
+		if s == nil {
+			s = &Summary{}
+		}
+		s.Value += p.Quantity() * price
+		s.CostBasis += p.CostBasis()
+	}
+
+	if s == nil {
+		return nil
+	}
+
+	s.PL = s.Value - s.CostBasis
+	if s.CostBasis != 0 {
+		s.PercentPL = s.PL / s.CostBasis
+	}
+	return s
+}
+
+// lotSpecRegexp matches specs like "10 @ 150.25" or "10 @ 150.25 2020-01-02".
+var lotSpecRegexp = regexp.MustCompile(`^([0-9.,]+)\s*@\s*\$?([0-9.,]+)(?:\s+(\S+))?$`)
+
+// ParseLot parses a spec like "10 @ 150.25 2020-01-02" into a lot of the symbol.
+// The lot is dated today if the spec has no date.
+func ParseLot(symbol, spec string, today time.Time) (*Lot, error) {
+	if err := model.ValidateSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	m := lotSpecRegexp.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return nil, errs.Errorf("bad lot: %q, want like 10 @ 150.25", spec)
+	}
+
+	q, err := parseNumber(m[1])
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := parseNumber(m[2])
+	if err != nil {
+		return nil, err
+	}
+
+	date := today
+	if m[3] != "" {
+		if date, err = parseDate(m[3]); err != nil {
+			return nil, err
+		}
+	}
+
+	return newLot(symbol, q, price, date)
+}
+
+// newLot returns a lot or an error if the quantity or price are not positive.
+func newLot(symbol string, quantity, price float32, date time.Time) (*Lot, error) {
+	if quantity <= 0 {
+		return nil, errs.Errorf("quantity must be positive: %v", quantity)
+	}
+
+	if price <= 0 {
+		return nil, errs.Errorf("price must be positive: %v", price)
+	}
+
+	return &Lot{
+		Symbol:   symbol,
+		Quantity: quantity,
+		Price:    price,
+		Date:     date,
+	}, nil
+}
+
+// parseNumber parses numbers that brokers format like "$1,234.50".
+func parseNumber(s string) (float32, error) {
+	s = strings.NewReplacer("$", "", ",", "").Replace(strings.TrimSpace(s))
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, errs.Errorf("bad number: %q", s)
+	}
+	return float32(v), nil
+}
+
+// dateLayouts are the date layouts that brokers use.
+var dateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/06",
+	"1/2/06",
+}
+
+// parseDate parses dates that brokers format like "2020-01-02" or "01/02/2020".
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errs.Errorf("bad date: %q", s)
+}
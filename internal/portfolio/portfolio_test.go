@@ -0,0 +1,202 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPositionPL(t *testing.T) {
+	for _, tt := range []struct {
+		desc             string
+		input            *Position
+		price            float32
+		wantQuantity     float32
+		wantCostBasis    float32
+		wantAveragePrice float32
+		wantDollars      float32
+		wantPercent      float32
+	}{
+		{
+			desc:  "no lots",
+			input: &Position{Symbol: "AAPL"},
+			price: 100,
+		},
+		{
+			desc: "gain over two lots",
+			input: &Position{
+				Symbol: "AAPL",
+				Lots: []*Lot{
+					{Symbol: "AAPL", Quantity: 10, Price: 100},
+					{Symbol: "AAPL", Quantity: 30, Price: 200},
+				},
+			},
+			price:            200,
+			wantQuantity:     40,
+			wantCostBasis:    7000,
+			wantAveragePrice: 175,
+			wantDollars:      1000,
+			wantPercent:      1000.0 / 7000,
+		},
+		{
+			desc: "loss",
+			input: &Position{
+				Symbol: "AAPL",
+				Lots: []*Lot{
+					{Symbol: "AAPL", Quantity: 10, Price: 100},
+				},
+			},
+			price:            80,
+			wantQuantity:     10,
+			wantCostBasis:    1000,
+			wantAveragePrice: 100,
+			wantDollars:      -200,
+			wantPercent:      -0.2,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := tt.input.Quantity(); got != tt.wantQuantity {
+				t.Errorf("got quantity %v, want %v", got, tt.wantQuantity)
+			}
+
+			if got := tt.input.CostBasis(); got != tt.wantCostBasis {
+				t.Errorf("got cost basis %v, want %v", got, tt.wantCostBasis)
+			}
+
+			if got := tt.input.AveragePrice(); got != tt.wantAveragePrice {
+				t.Errorf("got average price %v, want %v", got, tt.wantAveragePrice)
+			}
+
+			gotDollars, gotPercent := tt.input.PL(tt.price)
+			if gotDollars != tt.wantDollars || gotPercent != tt.wantPercent {
+				t.Errorf("got P/L %v %v, want %v %v", gotDollars, gotPercent, tt.wantDollars, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestPositions(t *testing.T) {
+	aapl1 := &Lot{Symbol: "AAPL", Quantity: 10, Price: 100}
+	msft := &Lot{Symbol: "MSFT", Quantity: 5, Price: 200}
+	aapl2 := &Lot{Symbol: "AAPL", Quantity: 20, Price: 120}
+
+	got := Positions([]*Lot{aapl1, msft, aapl2})
+	want := []*Position{
+		{Symbol: "AAPL", Lots: []*Lot{aapl1, aapl2}},
+		{Symbol: "MSFT", Lots: []*Lot{msft}},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	positions := Positions([]*Lot{
+		{Symbol: "AAPL", Quantity: 10, Price: 100},
+		{Symbol: "MSFT", Quantity: 5, Price: 200},
+	})
+
+	for _, tt := range []struct {
+		desc   string
+		prices map[string]float32
+		want   *Summary
+	}{
+		{
+			desc: "no prices",
+		},
+		{
+			desc:   "all prices",
+			prices: map[string]float32{"AAPL": 150, "MSFT": 100},
+			want: &Summary{
+				Value:     2000,
+				CostBasis: 2000,
+			},
+		},
+		{
+			desc:   "skips positions without prices",
+			prices: map[string]float32{"AAPL": 150},
+			want: &Summary{
+				Value:     1500,
+				CostBasis: 1000,
+				PL:        500,
+				PercentPL: 0.5,
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := Summarize(positions, tt.prices)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseLot(t *testing.T) {
+	today := time.Date(2020, time.July, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		desc    string
+		symbol  string
+		input   string
+		want    *Lot
+		wantErr bool
+	}{
+		{
+			desc:   "today",
+			symbol: "AAPL",
+			input:  "10 @ 150.25",
+			want:   &Lot{Symbol: "AAPL", Quantity: 10, Price: 150.25, Date: today},
+		},
+		{
+			desc:   "date and no spaces",
+			symbol: "AAPL",
+			input:  "10@$150.25 2020-01-02",
+			want:   &Lot{Symbol: "AAPL", Quantity: 10, Price: 150.25, Date: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			desc:   "slash date and thousands",
+			symbol: "AAPL",
+			input:  "1,000 @ 1,150.50 1/2/2020",
+			want:   &Lot{Symbol: "AAPL", Quantity: 1000, Price: 1150.5, Date: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			desc:    "missing price",
+			symbol:  "AAPL",
+			input:   "10",
+			wantErr: true,
+		},
+		{
+			desc:    "zero quantity",
+			symbol:  "AAPL",
+			input:   "0 @ 150",
+			wantErr: true,
+		},
+		{
+			desc:    "bad date",
+			symbol:  "AAPL",
+			input:   "10 @ 150 yesterday",
+			wantErr: true,
+		},
+		{
+			desc:    "bad symbol",
+			symbol:  "aapl",
+			input:   "10 @ 150",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := ParseLot(tt.symbol, tt.input, today)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package rating computes relative strength ratings that rank symbols by their price performance
+// like the ratings of Investor's Business Daily.
+package rating
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/logger"
+)
+
+// quarterSessions is the number of daily trading sessions in about three months.
+const quarterSessions = 63
+
+// Ratings are percentiles from MinRating to MaxRating.
+const (
+	MinRating = 1
+	MaxRating = 99
+)
+
+// Score returns the weighted performance of the last 3, 6, 9, and 12 months of daily trading sessions.
+// The last three months count twice as much as the other periods.
+// It returns false if there is not a year of sessions.
+func Score(ts []*model.TradingSession) (score float32, ok bool) {
+	if len(ts) <= 4*quarterSessions {
+		return 0, false
+	}
+
+	last := ts[len(ts)-1].Close
+
+	performance := func(quarters int) float32 {
+		prev := ts[len(ts)-1-quarters*quarterSessions].Close
+		if prev <= 0 {
+			return 0
+		}
+		return last/prev - 1
+	}
+
+	return 2*performance(1) + performance(2) + performance(3) + performance(4), true
+}
+
+// Rank returns the percentile rating of each symbol's score compared to the other scores.
+// Symbols with the same score have the same rating.
+func Rank(symbol2Score map[string]float32) map[string]int {
+	if len(symbol2Score) == 0 {
+		return nil
+	}
+
+	var scores []float32
+	for _, s := range symbol2Score {
+		scores = append(scores, s)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i] < scores[j]
+	})
+
+	symbol2Rating := map[string]int{}
+	for sym, s := range symbol2Score {
+		// Count the scores that are lower to find the percentile.
+		below := sort.Search(len(scores), func(i int) bool {
+			return scores[i] >= s
+		})
+
+		r := MaxRating
+		if n := len(scores); n > 1 {
+			r = MinRating + int(math.Round(float64(MaxRating-MinRating)*float64(below)/float64(n-1)))
+		}
+		symbol2Rating[sym] = r
+	}
+	return symbol2Rating
+}
+
+// LoadUniverse reads the symbols to rank from a file with one symbol per line.
+func LoadUniverse(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("closing in load failed: %v", err)
+		}
+	}()
+
+	return ReadUniverse(file)
+}
+
+// ReadUniverse reads symbols with one symbol per line. Blank lines and lines starting with # are skipped.
+func ReadUniverse(r io.Reader) ([]string, error) {
+	var symbols []string
+	seen := map[string]bool{}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.ToUpper(strings.TrimSpace(sc.Text()))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := model.ValidateSymbol(line); err != nil {
+			return nil, err
+		}
+
+		if !seen[line] {
+			seen[line] = true
+			symbols = append(symbols, line)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return symbols, nil
+}
@@ -0,0 +1,145 @@
+package rating
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestScore(t *testing.T) {
+	// sessions returns a year and a session of closes with the given closes at the quarter boundaries.
+	sessions := func(yearAgo, nineMonthsAgo, sixMonthsAgo, threeMonthsAgo, last float32) []*model.TradingSession {
+		var ts []*model.TradingSession
+		for i := 0; i <= 4*quarterSessions; i++ {
+			c := float32(1)
+			switch i {
+			case 0:
+				c = yearAgo
+			case quarterSessions:
+				c = nineMonthsAgo
+			case 2 * quarterSessions:
+				c = sixMonthsAgo
+			case 3 * quarterSessions:
+				c = threeMonthsAgo
+			case 4 * quarterSessions:
+				c = last
+			}
+			ts = append(ts, &model.TradingSession{Close: c})
+		}
+		return ts
+	}
+
+	for _, tt := range []struct {
+		desc      string
+		input     []*model.TradingSession
+		wantScore float32
+		wantOK    bool
+	}{
+		{
+			desc:  "not enough sessions",
+			input: sessions(1, 1, 1, 1, 1)[1:],
+		},
+		{
+			desc:      "flat",
+			input:     sessions(10, 10, 10, 10, 10),
+			wantScore: 0,
+			wantOK:    true,
+		},
+		{
+			desc:  "recent quarter counts twice",
+			input: sessions(10, 10, 10, 10, 20),
+			// 2*100% + 100% + 100% + 100%
+			wantScore: 5,
+			wantOK:    true,
+		},
+		{
+			desc:  "gains then losses",
+			input: sessions(5, 10, 10, 20, 10),
+			// 2*-50% + 0% + 0% + 100%
+			wantScore: 0,
+			wantOK:    true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotScore, gotOK := Score(tt.input)
+
+			if diff := cmp.Diff(tt.wantScore, gotScore, cmpopts.EquateApprox(0, 0.001)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if gotOK != tt.wantOK {
+				t.Errorf("got ok: %t, want: %t", gotOK, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		input map[string]float32
+		want  map[string]int
+	}{
+		{
+			desc: "no scores",
+		},
+		{
+			desc:  "single symbol",
+			input: map[string]float32{"AAPL": 1},
+			want:  map[string]int{"AAPL": 99},
+		},
+		{
+			desc:  "spread from lowest to highest",
+			input: map[string]float32{"AAPL": 3, "MSFT": -1, "SPY": 1},
+			want:  map[string]int{"AAPL": 99, "MSFT": 1, "SPY": 50},
+		},
+		{
+			desc:  "ties",
+			input: map[string]float32{"AAPL": 2, "MSFT": 2, "SPY": 1},
+			want:  map[string]int{"AAPL": 50, "MSFT": 50, "SPY": 1},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := Rank(tt.input)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadUniverse(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc:  "symbols with comments and blank lines",
+			input: "# Growth stocks\naapl\n\nMSFT\n  SPY  \nAAPL\n",
+			want:  []string{"AAPL", "MSFT", "SPY"},
+		},
+		{
+			desc:    "bad symbol",
+			input:   "AAPL\nNOT A SYMBOL\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := ReadUniverse(strings.NewReader(tt.input))
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, wanted err: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
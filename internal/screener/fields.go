@@ -0,0 +1,213 @@
+package screener
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/indicator"
+)
+
+// fieldKind is the kind of a computed field.
+type fieldKind int
+
+// fieldKind values.
+const (
+	fieldKindUnspecified fieldKind = iota
+	closeField
+	openField
+	highField
+	lowField
+	volumeField
+	changeField
+	changePercentField
+	smaField
+	emaField
+	wmaField
+	rsiField
+	averageVolumeField
+	periodHighField
+	periodLowField
+)
+
+// fieldNames maps the names of fields without periods to their kinds.
+var fieldNames = map[string]fieldKind{
+	"close":     closeField,
+	"open":      openField,
+	"high":      highField,
+	"low":       lowField,
+	"volume":    volumeField,
+	"change":    changeField,
+	"changepct": changePercentField,
+}
+
+// periodFieldNames maps the names of fields that end with a number of periods like sma50 to their kinds.
+var periodFieldNames = map[string]fieldKind{
+	"sma":    smaField,
+	"ema":    emaField,
+	"wma":    wmaField,
+	"rsi":    rsiField,
+	"avgvol": averageVolumeField,
+	"high":   periodHighField,
+	"low":    periodLowField,
+}
+
+// periodFieldRegexp splits field names like sma50 into the name and the number of periods.
+var periodFieldRegexp = regexp.MustCompile("^([a-z]+)([0-9]+)$")
+
+// field is a value computed from the latest trading sessions of a chart.
+type field struct {
+	kind fieldKind
+
+	// periods is how many sessions the field spans. Zero for fields of only the last session.
+	periods int
+}
+
+// parseField returns the field with the lowercase name like close or sma50.
+func parseField(name string) (field, bool) {
+	if k, ok := fieldNames[name]; ok {
+		return field{kind: k}, true
+	}
+
+	m := periodFieldRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return field{}, false
+	}
+
+	k, ok := periodFieldNames[m[1]]
+	if !ok {
+		return field{}, false
+	}
+
+	periods, err := strconv.Atoi(m[2])
+	if err != nil || periods <= 0 {
+		return field{}, false
+	}
+
+	return field{kind: k, periods: periods}, true
+}
+
+// fieldValues computes and caches the field values of a series of trading sessions.
+type fieldValues struct {
+	ts     *model.TradingSessionSeries
+	values map[field]float64
+}
+
+func newFieldValues(ts *model.TradingSessionSeries) *fieldValues {
+	return &fieldValues{ts: ts, values: map[field]float64{}}
+}
+
+// value returns the field's value as of the last session.
+// It returns false if there are not enough sessions to compute the value.
+func (f *fieldValues) value(fd field) (float64, bool) {
+	if v, ok := f.values[fd]; ok {
+		return v, true
+	}
+
+	v, ok := computeField(f.ts, fd)
+	if ok {
+		f.values[fd] = v
+	}
+	return v, ok
+}
+
+func computeField(ts *model.TradingSessionSeries, fd field) (float64, bool) {
+	if ts == nil || len(ts.TradingSessions) == 0 {
+		return 0, false
+	}
+
+	sessions := ts.TradingSessions
+	last := sessions[len(sessions)-1]
+
+	// Fields that span periods need at least that many sessions.
+	if len(sessions) < fd.periods {
+		return 0, false
+	}
+
+	switch fd.kind {
+	case closeField:
+		return float64(last.Close), true
+
+	case openField:
+		return float64(last.Open), true
+
+	case highField:
+		return float64(last.High), true
+
+	case lowField:
+		return float64(last.Low), true
+
+	case volumeField:
+		return float64(last.Volume), true
+
+	case changeField:
+		return float64(last.Change), true
+
+	case changePercentField:
+		return float64(last.PercentChange), true
+
+	case smaField:
+		return lastIndicatorValue(ts, "sma", fd.periods)
+
+	case emaField:
+		// The EMA starts from the SMA of the sessions before it, so it needs one more session.
+		if len(sessions) <= fd.periods {
+			return 0, false
+		}
+		return lastIndicatorValue(ts, "ema", fd.periods)
+
+	case wmaField:
+		return lastIndicatorValue(ts, "wma", fd.periods)
+
+	case rsiField:
+		// The RSI needs one more session for the first change.
+		if len(sessions) <= fd.periods {
+			return 0, false
+		}
+		return lastIndicatorValue(ts, "rsi", fd.periods)
+
+	case averageVolumeField:
+		var sum float64
+		for _, s := range sessions[len(sessions)-fd.periods:] {
+			sum += float64(s.Volume)
+		}
+		return sum / float64(fd.periods), true
+
+	case periodHighField:
+		var high float32
+		for _, s := range sessions[len(sessions)-fd.periods:] {
+			if s.High > high {
+				high = s.High
+			}
+		}
+		return float64(high), true
+
+	case periodLowField:
+		low := sessions[len(sessions)-fd.periods].Low
+		for _, s := range sessions[len(sessions)-fd.periods:] {
+			if s.Low < low {
+				low = s.Low
+			}
+		}
+		return float64(low), true
+
+	default:
+		return 0, false
+	}
+}
+
+// lastIndicatorValue returns the last value of the registered indicator's first series.
+func lastIndicatorValue(ts *model.TradingSessionSeries, name string, periods int) (float64, bool) {
+	ind, err := indicator.New(name, periods)
+	if err != nil {
+		return 0, false
+	}
+
+	series := ind.Compute(ts)
+	if len(series) == 0 || len(series[0].Values) == 0 {
+		return 0, false
+	}
+
+	values := series[0].Values
+	return float64(values[len(values)-1].Value), true
+}
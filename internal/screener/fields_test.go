@@ -0,0 +1,148 @@
+package screener
+
+import (
+	"testing"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseField(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		input  string
+		want   field
+		wantOK bool
+	}{
+		{
+			desc:   "last session field",
+			input:  "volume",
+			want:   field{kind: volumeField},
+			wantOK: true,
+		},
+		{
+			desc:   "period field",
+			input:  "avgvol50",
+			want:   field{kind: averageVolumeField, periods: 50},
+			wantOK: true,
+		},
+		{
+			desc:   "high with periods",
+			input:  "high20",
+			want:   field{kind: periodHighField, periods: 20},
+			wantOK: true,
+		},
+		{
+			desc:  "missing periods",
+			input: "sma",
+		},
+		{
+			desc:  "zero periods",
+			input: "sma0",
+		},
+		{
+			desc:  "periods on a last session field",
+			input: "close5",
+		},
+		{
+			desc:  "unknown field",
+			input: "price",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotOK := parseField(tt.input)
+
+			if gotOK != tt.wantOK {
+				t.Fatalf("got ok: %t, want ok: %t", gotOK, tt.wantOK)
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(field{})); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComputeField(t *testing.T) {
+	ts := &model.TradingSessionSeries{
+		TradingSessions: []*model.TradingSession{
+			{Open: 1, High: 3, Low: 1, Close: 2, Volume: 100},
+			{Open: 2, High: 5, Low: 2, Close: 4, Volume: 200},
+			{Open: 4, High: 7, Low: 3, Close: 6, Volume: 600, Change: 2, PercentChange: 50},
+		},
+	}
+
+	for _, tt := range []struct {
+		desc   string
+		input  field
+		want   float64
+		wantOK bool
+	}{
+		{
+			desc:   "close",
+			input:  field{kind: closeField},
+			want:   6,
+			wantOK: true,
+		},
+		{
+			desc:   "percent change",
+			input:  field{kind: changePercentField},
+			want:   50,
+			wantOK: true,
+		},
+		{
+			desc:   "simple moving average",
+			input:  field{kind: smaField, periods: 3},
+			want:   4,
+			wantOK: true,
+		},
+		{
+			desc:   "exponential moving average",
+			input:  field{kind: emaField, periods: 2},
+			want:   5,
+			wantOK: true,
+		},
+		{
+			desc:   "average volume",
+			input:  field{kind: averageVolumeField, periods: 2},
+			want:   400,
+			wantOK: true,
+		},
+		{
+			desc:   "period high",
+			input:  field{kind: periodHighField, periods: 2},
+			want:   7,
+			wantOK: true,
+		},
+		{
+			desc:   "period low",
+			input:  field{kind: periodLowField, periods: 3},
+			want:   1,
+			wantOK: true,
+		},
+		{
+			desc:  "not enough sessions",
+			input: field{kind: smaField, periods: 4},
+		},
+		{
+			desc:  "not enough sessions for the ema",
+			input: field{kind: emaField, periods: 3},
+		},
+		{
+			desc:  "not enough sessions for the rsi",
+			input: field{kind: rsiField, periods: 3},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotOK := computeField(ts, tt.input)
+
+			if gotOK != tt.wantOK {
+				t.Fatalf("got ok: %t, want ok: %t", gotOK, tt.wantOK)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
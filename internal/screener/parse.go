@@ -0,0 +1,336 @@
+package screener
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// tokenKind is the kind of a lexical token.
+type tokenKind int
+
+// tokenKind values.
+const (
+	eofToken tokenKind = iota
+	numberToken
+	identToken
+	leftParenToken
+	rightParenToken
+	plusToken
+	minusToken
+	timesToken
+	divideToken
+	lessToken
+	lessEqualToken
+	greaterToken
+	greaterEqualToken
+	equalToken
+	notEqualToken
+	andToken
+	orToken
+	notToken
+)
+
+// operators maps operator text to token kinds. Longer operators are matched first.
+var operators = []struct {
+	text string
+	kind tokenKind
+}{
+	{"<=", lessEqualToken},
+	{">=", greaterEqualToken},
+	{"==", equalToken},
+	{"!=", notEqualToken},
+	{"(", leftParenToken},
+	{")", rightParenToken},
+	{"+", plusToken},
+	{"-", minusToken},
+	{"*", timesToken},
+	{"/", divideToken},
+	{"<", lessToken},
+	{">", greaterToken},
+	{"=", equalToken},
+}
+
+// keywords maps keywords to token kinds.
+var keywords = map[string]tokenKind{
+	"and": andToken,
+	"or":  orToken,
+	"not": notToken,
+}
+
+// token is a lexical token in a filter expression.
+type token struct {
+	kind tokenKind
+
+	// text is the token's text with identifiers lowercased.
+	text string
+
+	// pos is the 1-based column of the token.
+	pos int
+}
+
+// lex splits the expression into tokens ending with an eofToken.
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{numberToken, string(runes[i:j]), pos})
+			i = j
+
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			text := strings.ToLower(string(runes[i:j]))
+			kind, ok := keywords[text]
+			if !ok {
+				kind = identToken
+			}
+			tokens = append(tokens, token{kind, text, pos})
+			i = j
+
+		default:
+			found := false
+			for _, op := range operators {
+				if strings.HasPrefix(string(runes[i:]), op.text) {
+					tokens = append(tokens, token{op.kind, op.text, pos})
+					i += len([]rune(op.text))
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, errs.Errorf("col %d: unexpected %q", pos, r)
+			}
+		}
+	}
+
+	return append(tokens, token{eofToken, "", len(runes) + 1}), nil
+}
+
+// node is a node of a parsed filter expression.
+type node interface {
+	// pos returns the 1-based column of the node for error messages.
+	pos() int
+}
+
+// numberNode is a number literal like 1.5.
+type numberNode struct {
+	p     int
+	value float64
+}
+
+func (n *numberNode) pos() int { return n.p }
+
+// fieldNode is a reference to a computed field like close or sma50.
+type fieldNode struct {
+	p     int
+	field field
+}
+
+func (n *fieldNode) pos() int { return n.p }
+
+// unaryNode is a negation like -change or not close > open.
+type unaryNode struct {
+	p  int
+	op tokenKind
+	x  node
+}
+
+func (n *unaryNode) pos() int { return n.p }
+
+// binaryNode is an arithmetic, comparison, or logical operation.
+type binaryNode struct {
+	p    int
+	op   tokenKind
+	x, y node
+}
+
+func (n *binaryNode) pos() int { return n.p }
+
+// parser is a recursive descent parser with the following grammar:
+//
+//	or      = and { "or" and }
+//	and     = not { "and" not }
+//	not     = "not" not | compare
+//	compare = sum [ ( "<" | "<=" | ">" | ">=" | "==" | "!=" ) sum ]
+//	sum     = product { ( "+" | "-" ) product }
+//	product = unary { ( "*" | "/" ) unary }
+//	unary   = "-" unary | primary
+//	primary = number | field | "(" or ")"
+type parser struct {
+	tokens []token
+	i      int
+}
+
+// parse parses the expression into a tree of nodes.
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != eofToken {
+		return nil, errs.Errorf("col %d: unexpected %q", t.pos, t.text)
+	}
+
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != eofToken {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, orToken)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseNot, andToken)
+}
+
+func (p *parser) parseNot() (node, error) {
+	if t := p.peek(); t.kind == notToken {
+		p.next()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{t.pos, t.kind, x}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := p.peek(); t.kind {
+	case lessToken, lessEqualToken, greaterToken, greaterEqualToken, equalToken, notEqualToken:
+		p.next()
+		y, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{t.pos, t.kind, x, y}, nil
+	}
+
+	return x, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.parseBinary(p.parseProduct, plusToken, minusToken)
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.parseBinary(p.parseUnary, timesToken, divideToken)
+}
+
+// parseBinary parses left-associative operations of the given operators.
+func (p *parser) parseBinary(operand func() (node, error), ops ...tokenKind) (node, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+
+		found := false
+		for _, op := range ops {
+			if t.kind == op {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return x, nil
+		}
+
+		p.next()
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{t.pos, t.kind, x, y}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); t.kind == minusToken {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{t.pos, t.kind, x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case numberToken:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errs.Errorf("col %d: bad number %q", t.pos, t.text)
+		}
+		return &numberNode{t.pos, v}, nil
+
+	case identToken:
+		f, ok := parseField(t.text)
+		if !ok {
+			return nil, errs.Errorf("col %d: unknown field %q", t.pos, t.text)
+		}
+		return &fieldNode{t.pos, f}, nil
+
+	case leftParenToken:
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if r := p.next(); r.kind != rightParenToken {
+			return nil, errs.Errorf("col %d: missing )", r.pos)
+		}
+		return x, nil
+
+	case eofToken:
+		return nil, errs.Errorf("col %d: unexpected end of expression", t.pos)
+
+	default:
+		return nil, errs.Errorf("col %d: unexpected %q", t.pos, t.text)
+	}
+}
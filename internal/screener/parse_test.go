@@ -0,0 +1,151 @@
+package screener
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// format returns the node with parentheses around every operation to show the precedence.
+func format(n node) string {
+	names := map[tokenKind]string{
+		plusToken:         "+",
+		minusToken:        "-",
+		timesToken:        "*",
+		divideToken:       "/",
+		lessToken:         "<",
+		lessEqualToken:    "<=",
+		greaterToken:      ">",
+		greaterEqualToken: ">=",
+		equalToken:        "==",
+		notEqualToken:     "!=",
+		andToken:          "and",
+		orToken:           "or",
+		notToken:          "not",
+	}
+
+	switch n := n.(type) {
+	case *numberNode:
+		return strconv.FormatFloat(n.value, 'f', -1, 64)
+	case *fieldNode:
+		return fmt.Sprintf("%d:%d", n.field.kind, n.field.periods)
+	case *unaryNode:
+		return fmt.Sprintf("(%s %s)", names[n.op], format(n.x))
+	case *binaryNode:
+		return fmt.Sprintf("(%s %s %s)", format(n.x), names[n.op], format(n.y))
+	}
+	return "?"
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:  "number",
+			input: "1.5",
+			want:  "1.5",
+		},
+		{
+			desc:  "field with periods",
+			input: "sma50",
+			want:  fmt.Sprintf("%d:50", smaField),
+		},
+		{
+			desc:  "uppercase field",
+			input: "CLOSE",
+			want:  fmt.Sprintf("%d:0", closeField),
+		},
+		{
+			desc:  "multiplication before addition",
+			input: "1 + 2 * 3",
+			want:  "(1 + (2 * 3))",
+		},
+		{
+			desc:  "left associative subtraction",
+			input: "1 - 2 - 3",
+			want:  "((1 - 2) - 3)",
+		},
+		{
+			desc:  "parentheses",
+			input: "(1 + 2) * 3",
+			want:  "((1 + 2) * 3)",
+		},
+		{
+			desc:  "negation",
+			input: "-1 * 2",
+			want:  "((- 1) * 2)",
+		},
+		{
+			desc:  "and before or",
+			input: "1 < 2 or 3 < 4 and 5 < 6",
+			want:  "((1 < 2) or ((3 < 4) and (5 < 6)))",
+		},
+		{
+			desc:  "not before and",
+			input: "not 1 < 2 and 3 >= 4",
+			want:  "((not (1 < 2)) and (3 >= 4))",
+		},
+		{
+			desc:  "single equals",
+			input: "1 = 2",
+			want:  "(1 == 2)",
+		},
+		{
+			desc:    "unknown field",
+			input:   "price > 1",
+			wantErr: true,
+		},
+		{
+			desc:    "unexpected character",
+			input:   "close > $1",
+			wantErr: true,
+		},
+		{
+			desc:    "bad number",
+			input:   "1.2.3",
+			wantErr: true,
+		},
+		{
+			desc:    "missing right parenthesis",
+			input:   "(1 + 2",
+			wantErr: true,
+		},
+		{
+			desc:    "missing operand",
+			input:   "close >",
+			wantErr: true,
+		},
+		{
+			desc:    "trailing tokens",
+			input:   "1 2",
+			wantErr: true,
+		},
+		{
+			desc:    "chained comparison",
+			input:   "1 < 2 < 3",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := parse(tt.input)
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Fatalf("got error: %v, wanted err: %t", gotErr, tt.wantErr)
+			}
+
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, format(got)); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
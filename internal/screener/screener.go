@@ -0,0 +1,237 @@
+// Package screener filters charts with expressions over fields computed from their trading sessions
+// like "close > sma50 and sma50 > sma200 and volume > 1.5 * avgvol50".
+//
+// Fields are computed as of the last trading session:
+//
+//	close, open, high, low, volume  the last session's values
+//	change, changepct               the last session's change and percent change
+//	smaN, emaN, wmaN                moving averages of the closes over N sessions
+//	rsiN                            relative strength index over N sessions
+//	avgvolN                         average volume over N sessions
+//	highN, lowN                     highest high and lowest low over N sessions
+//
+// Numbers combine with + - * / and compare with < <= > >= == !=.
+// Comparisons combine with and, or, and not. Names are case insensitive.
+package screener
+
+import (
+	"github.com/btmura/ponzi2/internal/app/model"
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// valueType is the type of an expression's value.
+type valueType int
+
+// valueType values.
+const (
+	valueTypeUnspecified valueType = iota
+	numberType
+	boolType
+)
+
+// Filter is a parsed and type-checked filter expression.
+type Filter struct {
+	// src is the expression the filter was parsed from.
+	src string
+
+	// root is the root of the parsed expression.
+	root node
+}
+
+// Parse parses the expression and checks that it is a comparison that evaluates to true or false.
+func Parse(src string) (*Filter, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := check(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if t != boolType {
+		return nil, errs.Errorf("col %d: expression is a number, want a comparison", root.pos())
+	}
+
+	return &Filter{src: src, root: root}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.src
+}
+
+// Match returns true if the chart's latest trading sessions satisfy the filter.
+// It returns false if the chart does not have enough sessions to compute a field.
+func (f *Filter) Match(ch *model.Chart) bool {
+	if ch == nil {
+		return false
+	}
+
+	match, ok := evalBool(f.root, newFieldValues(ch.TradingSessionSeries))
+	return ok && match
+}
+
+// check returns the type of the node or an error if an operator is applied to the wrong types.
+func check(n node) (valueType, error) {
+	switch n := n.(type) {
+	case *numberNode, *fieldNode:
+		return numberType, nil
+
+	case *unaryNode:
+		want := numberType
+		if n.op == notToken {
+			want = boolType
+		}
+		if err := checkOperand(n.x, want); err != nil {
+			return valueTypeUnspecified, err
+		}
+		return want, nil
+
+	case *binaryNode:
+		switch n.op {
+		case plusToken, minusToken, timesToken, divideToken:
+			if err := checkOperands(n, numberType); err != nil {
+				return valueTypeUnspecified, err
+			}
+			return numberType, nil
+
+		case lessToken, lessEqualToken, greaterToken, greaterEqualToken, equalToken, notEqualToken:
+			if err := checkOperands(n, numberType); err != nil {
+				return valueTypeUnspecified, err
+			}
+			return boolType, nil
+
+		case andToken, orToken:
+			if err := checkOperands(n, boolType); err != nil {
+				return valueTypeUnspecified, err
+			}
+			return boolType, nil
+		}
+	}
+
+	return valueTypeUnspecified, errs.Errorf("col %d: unsupported expression", n.pos())
+}
+
+func checkOperands(n *binaryNode, want valueType) error {
+	if err := checkOperand(n.x, want); err != nil {
+		return err
+	}
+	return checkOperand(n.y, want)
+}
+
+func checkOperand(n node, want valueType) error {
+	got, err := check(n)
+	if err != nil {
+		return err
+	}
+
+	if got != want {
+		if want == boolType {
+			return errs.Errorf("col %d: got a number, want a comparison", n.pos())
+		}
+		return errs.Errorf("col %d: got a comparison, want a number", n.pos())
+	}
+
+	return nil
+}
+
+// evalNumber evaluates a number node. It returns false if a field or division cannot be computed.
+func evalNumber(n node, fv *fieldValues) (float64, bool) {
+	switch n := n.(type) {
+	case *numberNode:
+		return n.value, true
+
+	case *fieldNode:
+		return fv.value(n.field)
+
+	case *unaryNode:
+		x, ok := evalNumber(n.x, fv)
+		return -x, ok
+
+	case *binaryNode:
+		x, ok := evalNumber(n.x, fv)
+		if !ok {
+			return 0, false
+		}
+
+		y, ok := evalNumber(n.y, fv)
+		if !ok {
+			return 0, false
+		}
+
+		switch n.op {
+		case plusToken:
+			return x + y, true
+		case minusToken:
+			return x - y, true
+		case timesToken:
+			return x * y, true
+		case divideToken:
+			if y == 0 {
+				return 0, false
+			}
+			return x / y, true
+		}
+	}
+
+	return 0, false
+}
+
+// evalBool evaluates a comparison node. It returns false if a field or division cannot be computed.
+func evalBool(n node, fv *fieldValues) (bool, bool) {
+	switch n := n.(type) {
+	case *unaryNode:
+		x, ok := evalBool(n.x, fv)
+		return !x, ok
+
+	case *binaryNode:
+		switch n.op {
+		case andToken:
+			x, ok := evalBool(n.x, fv)
+			if !ok || !x {
+				return false, ok
+			}
+			return evalBool(n.y, fv)
+
+		case orToken:
+			x, ok := evalBool(n.x, fv)
+			if ok && x {
+				return true, true
+			}
+			y, yok := evalBool(n.y, fv)
+			if yok && y {
+				return true, true
+			}
+			return false, ok && yok
+		}
+
+		x, ok := evalNumber(n.x, fv)
+		if !ok {
+			return false, false
+		}
+
+		y, ok := evalNumber(n.y, fv)
+		if !ok {
+			return false, false
+		}
+
+		switch n.op {
+		case lessToken:
+			return x < y, true
+		case lessEqualToken:
+			return x <= y, true
+		case greaterToken:
+			return x > y, true
+		case greaterEqualToken:
+			return x >= y, true
+		case equalToken:
+			return x == y, true
+		case notEqualToken:
+			return x != y, true
+		}
+	}
+
+	return false, false
+}
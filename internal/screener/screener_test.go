@@ -0,0 +1,121 @@
+package screener
+
+import (
+	"testing"
+
+	"github.com/btmura/ponzi2/internal/app/model"
+)
+
+func TestParseTypes(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		input   string
+		wantErr bool
+	}{
+		{
+			desc:  "comparison",
+			input: "close > sma50 and sma50 > sma200 and volume > 1.5 * avgvol50",
+		},
+		{
+			desc:    "number",
+			input:   "close + 1",
+			wantErr: true,
+		},
+		{
+			desc:    "and of numbers",
+			input:   "close and open",
+			wantErr: true,
+		},
+		{
+			desc:    "arithmetic on a comparison",
+			input:   "(close > open) * 2 > 1",
+			wantErr: true,
+		},
+		{
+			desc:    "not of a number",
+			input:   "not close",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, gotErr := Parse(tt.input)
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, wanted err: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	chart := &model.Chart{
+		TradingSessionSeries: &model.TradingSessionSeries{
+			TradingSessions: []*model.TradingSession{
+				{Close: 10, Volume: 100},
+				{Close: 11, Volume: 100},
+				{Close: 15, Volume: 400},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		desc  string
+		input string
+		chart *model.Chart
+		want  bool
+	}{
+		{
+			desc:  "breakout on volume",
+			input: "close > sma3 and volume > 1.5 * avgvol3",
+			chart: chart,
+			want:  true,
+		},
+		{
+			desc:  "failed comparison",
+			input: "close < sma3",
+			chart: chart,
+		},
+		{
+			desc:  "not",
+			input: "not close < sma3",
+			chart: chart,
+			want:  true,
+		},
+		{
+			desc:  "not enough sessions",
+			input: "close > sma50",
+			chart: chart,
+		},
+		{
+			desc:  "or skips a field without enough sessions",
+			input: "close > sma50 or close > 10",
+			chart: chart,
+			want:  true,
+		},
+		{
+			desc:  "not of a field without enough sessions",
+			input: "not close > sma50",
+			chart: chart,
+		},
+		{
+			desc:  "division by zero",
+			input: "close / (volume - volume) > 1",
+			chart: chart,
+		},
+		{
+			desc:  "nil chart",
+			input: "close > 1",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if got := f.Match(tt.chart); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"net/url"
 	"sort"
 	"strconv"
@@ -17,7 +16,6 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/btmura/ponzi2/internal/errs"
-	"github.com/btmura/ponzi2/internal/logger"
 )
 
 // Chart has points for a stock chart.
@@ -154,9 +152,9 @@ func (c *Client) GetCharts(ctx context.Context, req *GetChartsRequest) ([]*Chart
 				break
 			}
 
-			// Don't ask for data for weekends, since the market is closed.
-			// Keep iterating though.
-			if latest.Weekday() != time.Saturday && latest.Weekday() != time.Sunday {
+			// Don't ask for data for weekends, since the stock market is closed.
+			// Cryptocurrencies trade every day though. Keep iterating either way.
+			if IsCryptoSymbol(sym) || latest.Weekday() != time.Saturday && latest.Weekday() != time.Sunday {
 				if minChartLast == -1 {
 					minChartLast = 0
 				}
@@ -283,18 +281,65 @@ func (c *Client) noCacheGetCharts(ctx context.Context, req *GetChartsRequest) ([
 		return nil, errs.Errorf("iex: missing range for chart req")
 	}
 
-	var rangeStr string
-	switch req.Range {
+	if req.ChartLast < 0 {
+		return nil, errs.Errorf("iex: chart last must be greater than or equal to zero")
+	}
+
+	stockSymbols, cryptoSymbols := splitCryptoSymbols(req.Symbols)
+
+	var charts []*Chart
+
+	if len(stockSymbols) != 0 {
+		stockReq := *req
+		stockReq.Symbols = stockSymbols
+		chs, err := c.noCacheGetStockCharts(ctx, &stockReq)
+		if err != nil {
+			return nil, err
+		}
+		charts = append(charts, chs...)
+	}
+
+	if len(cryptoSymbols) != 0 {
+		cryptoReq := *req
+		cryptoReq.Symbols = cryptoSymbols
+		chs, err := c.noCacheGetCryptoCharts(ctx, &cryptoReq)
+		if err != nil {
+			return nil, err
+		}
+		charts = append(charts, chs...)
+	}
+
+	return charts, nil
+}
+
+// chartFilterFields are the fields to request from the chart endpoints.
+var chartFilterFields = []string{
+	"date",
+	"minute",
+	"open",
+	"high",
+	"low",
+	"close",
+	"volume",
+	"change",
+	"changePercent",
+}
+
+func chartRangeString(r Range) (string, error) {
+	switch r {
 	case OneDay:
-		rangeStr = "1d"
+		return "1d", nil
 	case TwoYears:
-		rangeStr = "2y"
+		return "2y", nil
 	default:
-		return nil, errs.Errorf("iex: unsupported range for chart req: %s", req.Range)
+		return "", errs.Errorf("iex: unsupported range for chart req: %s", r)
 	}
+}
 
-	if req.ChartLast < 0 {
-		return nil, errs.Errorf("iex: chart last must be greater than or equal to zero")
+func (c *Client) noCacheGetStockCharts(ctx context.Context, req *GetChartsRequest) ([]*Chart, error) {
+	rangeStr, err := chartRangeString(req.Range)
+	if err != nil {
+		return nil, err
 	}
 
 	u, err := url.Parse("https://cloud.iexapis.com/stable/stock/market/batch")
@@ -307,49 +352,21 @@ func (c *Client) noCacheGetCharts(ctx context.Context, req *GetChartsRequest) ([
 	v.Set("symbols", strings.Join(req.Symbols, ","))
 	v.Set("types", "chart")
 	v.Set("range", rangeStr)
-	v.Set("filter", strings.Join([]string{
-		"date",
-		"minute",
-		"open",
-		"high",
-		"low",
-		"close",
-		"volume",
-		"change",
-		"changePercent",
-	}, ","))
+	v.Set("filter", strings.Join(chartFilterFields, ","))
 	if req.ChartLast > 0 {
 		v.Set("chartLast", strconv.Itoa(req.ChartLast))
 	}
 	u.RawQuery = v.Encode()
 
-	httpReq, err := http.NewRequest(http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	ss := make([]string, len(req.Symbols))
+	copy(ss, req.Symbols)
+	sort.Strings(ss)
 
-	httpResp, err := http.DefaultClient.Do(httpReq.WithContext(ctx))
+	r, cleanup, err := c.get(ctx, u, fmt.Sprintf("iex-chart-%s-%v.txt", strings.Join(ss, "-"), rangeStr))
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := httpResp.Body.Close(); err != nil {
-			logger.Error(err)
-		}
-	}()
-
-	r := httpResp.Body
-	if c.dumpAPIResponses {
-		ss := make([]string, len(req.Symbols))
-		copy(ss, req.Symbols)
-		sort.Strings(ss)
-
-		rr, err := dumpResponse(fmt.Sprintf("iex-chart-%s-%v.txt", strings.Join(ss, "-"), rangeStr), r)
-		if err != nil {
-			return nil, errs.Errorf("iex: failed to dump chart resp: %v", err)
-		}
-		r = rr
-	}
+	defer cleanup()
 
 	charts, err := decodeCharts(r)
 	if err != nil {
@@ -358,21 +375,40 @@ func (c *Client) noCacheGetCharts(ctx context.Context, req *GetChartsRequest) ([
 	return charts, nil
 }
 
-func decodeCharts(r io.Reader) ([]*Chart, error) {
-	type chartPoint struct {
-		Date          string  `json:"date"`
-		Minute        string  `json:"minute"`
-		Open          float64 `json:"open"`
-		High          float64 `json:"high"`
-		Low           float64 `json:"low"`
-		Close         float64 `json:"close"`
-		Volume        float64 `json:"volume"`
-		Change        float64 `json:"change"`
-		ChangePercent float64 `json:"changePercent"`
-	}
+// jsonChartPoint is a single chart point in a chart response.
+type jsonChartPoint struct {
+	Date          string  `json:"date"`
+	Minute        string  `json:"minute"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Close         float64 `json:"close"`
+	Volume        float64 `json:"volume"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"`
+}
 
+func (pt *jsonChartPoint) chartPoint() (*ChartPoint, error) {
+	date, err := chartDate(pt.Date, pt.Minute)
+	if err != nil {
+		return nil, errs.Errorf("parsing date (%s) failed: %v", pt.Date, err)
+	}
+
+	return &ChartPoint{
+		Date:          date,
+		Open:          float32(pt.Open),
+		High:          float32(pt.High),
+		Low:           float32(pt.Low),
+		Close:         float32(pt.Close),
+		Volume:        int(pt.Volume),
+		Change:        float32(pt.Change),
+		ChangePercent: float32(pt.ChangePercent),
+	}, nil
+}
+
+func decodeCharts(r io.Reader) ([]*Chart, error) {
 	type stock struct {
-		Chart []*chartPoint `json:"chart"`
+		Chart []*jsonChartPoint `json:"chart"`
 	}
 
 	b, err := ioutil.ReadAll(r)
@@ -393,21 +429,11 @@ func decodeCharts(r io.Reader) ([]*Chart, error) {
 		charts = append(charts, ch)
 
 		for _, pt := range st.Chart {
-			date, err := chartDate(pt.Date, pt.Minute)
+			p, err := pt.chartPoint()
 			if err != nil {
-				return nil, errs.Errorf("parsing date (%s) failed: %v", pt.Date, err)
+				return nil, err
 			}
-
-			ch.ChartPoints = append(ch.ChartPoints, &ChartPoint{
-				Date:          date,
-				Open:          float32(pt.Open),
-				High:          float32(pt.High),
-				Low:           float32(pt.Low),
-				Close:         float32(pt.Close),
-				Volume:        int(pt.Volume),
-				Change:        float32(pt.Change),
-				ChangePercent: float32(pt.ChangePercent),
-			})
+			ch.ChartPoints = append(ch.ChartPoints, p)
 		}
 		sort.Slice(ch.ChartPoints, func(i, j int) bool {
 			return ch.ChartPoints[i].Date.Before(ch.ChartPoints[j].Date)
@@ -107,6 +107,18 @@ func (g *GOBChartCache) Get(ctx context.Context, key ChartCacheKey) (*ChartCache
 	return nil, nil
 }
 
+// Keys returns the keys of the cached charts in no particular order.
+func (g *GOBChartCache) Keys() []ChartCacheKey {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var keys []ChartCacheKey
+	for k := range g.Data {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 // Put implements the iexChartCacheInterface.
 func (g *GOBChartCache) Put(ctx context.Context, key ChartCacheKey, val *ChartCacheValue) error {
 	g.mu.Lock()
@@ -118,7 +130,7 @@ func (g *GOBChartCache) Put(ctx context.Context, key ChartCacheKey, val *ChartCa
 		return errs.Errorf("bad token: got %s, want: %v", key.Token, validTokenRegexp)
 	}
 
-	if !validSymbolRegexp.MatchString(key.Symbol) {
+	if !validSymbolRegexp.MatchString(key.Symbol) && !validCryptoSymbolRegexp.MatchString(key.Symbol) {
 		return errs.Errorf("bad symbol: got %s, want: %v", key.Symbol, validSymbolRegexp)
 	}
 
@@ -0,0 +1,257 @@
+package iex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/btmura/ponzi2/internal/errs"
+)
+
+// IsCryptoSymbol returns true if the symbol is a cryptocurrency pair like BTCUSD.
+// Cryptocurrencies trade around the clock, so they don't follow the stock market's hours.
+func IsCryptoSymbol(symbol string) bool {
+	return validCryptoSymbolRegexp.MatchString(symbol)
+}
+
+// splitCryptoSymbols splits the symbols into stock and cryptocurrency symbols.
+func splitCryptoSymbols(symbols []string) (stockSymbols, cryptoSymbols []string) {
+	for _, s := range symbols {
+		if IsCryptoSymbol(s) {
+			cryptoSymbols = append(cryptoSymbols, s)
+		} else {
+			stockSymbols = append(stockSymbols, s)
+		}
+	}
+	return stockSymbols, cryptoSymbols
+}
+
+// getCryptoQuotes gets quotes for cryptocurrency symbols.
+// The crypto endpoint only supports one symbol per request unlike the batch endpoint.
+func (c *Client) getCryptoQuotes(ctx context.Context, token string, symbols []string) ([]*Quote, error) {
+	quotes := make([]*Quote, len(symbols))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for i, sym := range symbols {
+		i, sym := i, sym
+		g.Go(func() error {
+			q, err := c.getCryptoQuote(gCtx, token, sym)
+			if err != nil {
+				return err
+			}
+			quotes[i] = q
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return quotes, nil
+}
+
+func (c *Client) getCryptoQuote(ctx context.Context, token, symbol string) (*Quote, error) {
+	u, err := url.Parse(fmt.Sprintf("https://cloud.iexapis.com/stable/crypto/%s/quote", url.PathEscape(symbol)))
+	if err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	v.Set("token", token)
+	u.RawQuery = v.Encode()
+
+	r, cleanup, err := c.get(ctx, u, fmt.Sprintf("iex-crypto-quote-%s.txt", symbol))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	q, err := decodeCryptoQuote(r)
+	if err != nil {
+		return nil, errs.Errorf("iex: failed to decode crypto quote resp: %v", err)
+	}
+	return q, nil
+}
+
+func decodeCryptoQuote(r io.Reader) (*Quote, error) {
+	// Crypto quotes have most of their numeric values encoded as strings.
+	type quote struct {
+		Symbol        string          `json:"symbol"`
+		LatestPrice   json.RawMessage `json:"latestPrice"`
+		LatestSource  string          `json:"latestSource"`
+		LatestUpdate  int64           `json:"latestUpdate"`
+		LatestVolume  json.RawMessage `json:"latestVolume"`
+		High          json.RawMessage `json:"high"`
+		Low           json.RawMessage `json:"low"`
+		PreviousClose json.RawMessage `json:"previousClose"`
+	}
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errs.Errorf("reading crypto quote json failed: %v", err)
+	}
+
+	var q quote
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if err := dec.Decode(&q); err != nil {
+		return nil, errs.Errorf("crypto quote json decode failed: %v, got: %s", err, string(b))
+	}
+
+	src, err := quoteSource(q.LatestSource)
+	if err != nil {
+		return nil, err
+	}
+
+	latestPrice, err := parseJSONFloat(q.LatestPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	latestVolume, err := parseJSONFloat(q.LatestVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	high, err := parseJSONFloat(q.High)
+	if err != nil {
+		return nil, err
+	}
+
+	low, err := parseJSONFloat(q.Low)
+	if err != nil {
+		return nil, err
+	}
+
+	previousClose, err := parseJSONFloat(q.PreviousClose)
+	if err != nil {
+		return nil, err
+	}
+
+	var change, changePercent float32
+	if previousClose != 0 {
+		change = latestPrice - previousClose
+		changePercent = change / previousClose
+	}
+
+	latestUpdate := millisToTime(q.LatestUpdate)
+
+	return &Quote{
+		Symbol:        q.Symbol,
+		CompanyName:   q.Symbol,
+		LatestPrice:   latestPrice,
+		LatestSource:  src,
+		LatestTime:    latestUpdate.In(loc),
+		LatestUpdate:  latestUpdate,
+		LatestVolume:  int(latestVolume),
+		High:          high,
+		Low:           low,
+		Change:        change,
+		ChangePercent: changePercent,
+	}, nil
+}
+
+// parseJSONFloat parses a JSON value that may be a number, a quoted number, an empty string, or null.
+func parseJSONFloat(raw json.RawMessage) (float32, error) {
+	s := strings.Trim(string(raw), `"`)
+	if s == "" || s == "null" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, errs.Errorf("parsing number (%s) failed: %v", s, err)
+	}
+	return float32(f), nil
+}
+
+// noCacheGetCryptoCharts gets charts for cryptocurrency symbols one request at a time.
+func (c *Client) noCacheGetCryptoCharts(ctx context.Context, req *GetChartsRequest) ([]*Chart, error) {
+	charts := make([]*Chart, len(req.Symbols))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for i, sym := range req.Symbols {
+		i, sym := i, sym
+		g.Go(func() error {
+			ch, err := c.noCacheGetCryptoChart(gCtx, req, sym)
+			if err != nil {
+				return err
+			}
+			charts[i] = ch
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return charts, nil
+}
+
+func (c *Client) noCacheGetCryptoChart(ctx context.Context, req *GetChartsRequest, symbol string) (*Chart, error) {
+	rangeStr, err := chartRangeString(req.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(fmt.Sprintf("https://cloud.iexapis.com/stable/stock/%s/chart/%s", url.PathEscape(symbol), rangeStr))
+	if err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	v.Set("token", req.Token)
+	v.Set("filter", strings.Join(chartFilterFields, ","))
+	if req.ChartLast > 0 {
+		v.Set("chartLast", strconv.Itoa(req.ChartLast))
+	}
+	u.RawQuery = v.Encode()
+
+	r, cleanup, err := c.get(ctx, u, fmt.Sprintf("iex-crypto-chart-%s-%v.txt", symbol, rangeStr))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	ch, err := decodeCryptoChart(symbol, r)
+	if err != nil {
+		return nil, errs.Errorf("iex: failed to decode crypto chart resp: %v", err)
+	}
+	return ch, nil
+}
+
+func decodeCryptoChart(symbol string, r io.Reader) (*Chart, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errs.Errorf("reading crypto chart json failed: %v", err)
+	}
+
+	var pts []*jsonChartPoint
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if err := dec.Decode(&pts); err != nil {
+		return nil, errs.Errorf("crypto chart json decode failed: %v, got: %s", err, string(b))
+	}
+
+	ch := &Chart{Symbol: symbol}
+	for _, pt := range pts {
+		p, err := pt.chartPoint()
+		if err != nil {
+			return nil, err
+		}
+		ch.ChartPoints = append(ch.ChartPoints, p)
+	}
+	sort.Slice(ch.ChartPoints, func(i, j int) bool {
+		return ch.ChartPoints[i].Date.Before(ch.ChartPoints[j].Date)
+	})
+
+	return ch, nil
+}
@@ -0,0 +1,162 @@
+package iex
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsCryptoSymbol(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		input string
+		want  bool
+	}{
+		{
+			desc:  "empty",
+			input: "",
+			want:  false,
+		},
+		{
+			desc:  "stock",
+			input: "SPY",
+			want:  false,
+		},
+		{
+			desc:  "five letter stock ending in usd",
+			input: "AAUSD",
+			want:  false,
+		},
+		{
+			desc:  "bitcoin",
+			input: "BTCUSD",
+			want:  true,
+		},
+		{
+			desc:  "tether pair",
+			input: "ETHUSDT",
+			want:  true,
+		},
+		{
+			desc:  "lowercase",
+			input: "btcusd",
+			want:  false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := IsCryptoSymbol(tt.input)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitCryptoSymbols(t *testing.T) {
+	gotStocks, gotCrypto := splitCryptoSymbols([]string{"SPY", "BTCUSD", "AAPL", "ETHUSD"})
+
+	if diff := cmp.Diff([]string{"SPY", "AAPL"}, gotStocks); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"BTCUSD", "ETHUSD"}, gotCrypto); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
+
+func TestDecodeCryptoQuote(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		data    string
+		want    *Quote
+		wantErr bool
+	}{
+		{
+			desc: "real time quote",
+			data: `{"symbol":"BTCUSD","sector":"cryptocurrency","calculationPrice":"realtime","latestPrice":"11000","latestSource":"Real time price","latestUpdate":1538153140524,"latestVolume":"12.5","high":"11200","low":"9800","previousClose":"10000"}`,
+			want: &Quote{
+				Symbol:        "BTCUSD",
+				CompanyName:   "BTCUSD",
+				LatestPrice:   11000,
+				LatestSource:  RealTimePrice,
+				LatestTime:    time.Unix(1538153140, 524000000).In(loc),
+				LatestUpdate:  time.Unix(1538153140, 524000000),
+				LatestVolume:  12,
+				High:          11200,
+				Low:           9800,
+				Change:        1000,
+				ChangePercent: 0.1,
+			},
+		},
+		{
+			desc: "missing previous close",
+			data: `{"symbol":"ETHUSD","latestPrice":"200.5","latestSource":"Real time price","latestUpdate":1538153140524,"latestVolume":"","high":null,"low":null,"previousClose":null}`,
+			want: &Quote{
+				Symbol:       "ETHUSD",
+				CompanyName:  "ETHUSD",
+				LatestPrice:  200.5,
+				LatestSource: RealTimePrice,
+				LatestTime:   time.Unix(1538153140, 524000000).In(loc),
+				LatestUpdate: time.Unix(1538153140, 524000000),
+			},
+		},
+		{
+			desc:    "bad price",
+			data:    `{"symbol":"BTCUSD","latestPrice":"abc","latestSource":"Real time price"}`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotErr := decodeCryptoQuote(strings.NewReader(tt.data))
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diff (-want, +got)\n%s", diff)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error: %v, wanted err: %t", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeCryptoChart(t *testing.T) {
+	data := `[
+		{"date":"2018-09-16","open":6500,"high":6600,"low":6400,"close":6550,"volume":120},
+		{"date":"2018-09-15","open":6450,"high":6520,"low":6420,"close":6500,"volume":100}
+	]`
+
+	want := &Chart{
+		Symbol: "BTCUSD",
+		ChartPoints: []*ChartPoint{
+			{
+				Date:   time.Date(2018, time.September, 15, 0, 0, 0, 0, loc),
+				Open:   6450,
+				High:   6520,
+				Low:    6420,
+				Close:  6500,
+				Volume: 100,
+			},
+			{
+				Date:   time.Date(2018, time.September, 16, 0, 0, 0, 0, loc),
+				Open:   6500,
+				High:   6600,
+				Low:    6400,
+				Close:  6550,
+				Volume: 120,
+			},
+		},
+	}
+
+	got, err := decodeCryptoChart("BTCUSD", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("decodeCryptoChart returned an error: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diff (-want, +got)\n%s", diff)
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"time"
 
+	"github.com/btmura/ponzi2/internal/errs"
 	"github.com/btmura/ponzi2/internal/logger"
 )
 
@@ -30,6 +33,9 @@ var (
 
 	// validSymbolRegexp is a regexp that accepts valid stock symbols. Examples: X, FB, SPY, AAPL
 	validSymbolRegexp = regexp.MustCompile("^[A-Z]{1,5}$")
+
+	// validCryptoSymbolRegexp is a regexp that accepts valid cryptocurrency symbols. Examples: BTCUSD, ETHUSDT
+	validCryptoSymbolRegexp = regexp.MustCompile("^[A-Z]{3,5}(USD|USDT|USDC)$")
 )
 
 var cacheClientVar = expvar.NewMap("iex-client-stats")
@@ -59,6 +65,37 @@ func NewClient(chartCache iexChartCacheInterface, dumpAPIResponses bool) *Client
 	}
 }
 
+// get makes a GET request and returns the response body with a function to close it.
+func (c *Client) get(ctx context.Context, u *url.URL, dumpFileName string) (r io.Reader, cleanup func(), err error) {
+	httpReq, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpResp, err := http.DefaultClient.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup = func() {
+		if err := httpResp.Body.Close(); err != nil {
+			logger.Error(err)
+		}
+	}
+
+	r = httpResp.Body
+	if c.dumpAPIResponses {
+		rr, err := dumpResponse(dumpFileName, r)
+		if err != nil {
+			cleanup()
+			return nil, nil, errs.Errorf("iex: failed to dump resp: %v", err)
+		}
+		r = rr
+	}
+
+	return r, cleanup, nil
+}
+
 func dumpResponse(fileName string, r io.Reader) (io.ReadCloser, error) {
 	file, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
 	if err != nil {
@@ -7,14 +7,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"net/url"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/btmura/ponzi2/internal/errs"
-	"github.com/btmura/ponzi2/internal/logger"
 )
 
 // Quote is a stock quote.
@@ -74,14 +72,38 @@ func (c *Client) GetQuotes(ctx context.Context, req *GetQuotesRequest) ([]*Quote
 		return nil, nil
 	}
 
+	stockSymbols, cryptoSymbols := splitCryptoSymbols(req.Symbols)
+
+	var quotes []*Quote
+
+	if len(stockSymbols) != 0 {
+		qs, err := c.getStockQuotes(ctx, req.Token, stockSymbols)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, qs...)
+	}
+
+	if len(cryptoSymbols) != 0 {
+		qs, err := c.getCryptoQuotes(ctx, req.Token, cryptoSymbols)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, qs...)
+	}
+
+	return quotes, nil
+}
+
+func (c *Client) getStockQuotes(ctx context.Context, token string, symbols []string) ([]*Quote, error) {
 	u, err := url.Parse("https://cloud.iexapis.com/stable/stock/market/batch")
 	if err != nil {
 		return nil, err
 	}
 
 	v := url.Values{}
-	v.Set("token", req.Token)
-	v.Set("symbols", strings.Join(req.Symbols, ","))
+	v.Set("token", token)
+	v.Set("symbols", strings.Join(symbols, ","))
 	v.Set("types", "quote")
 	v.Set("filter", strings.Join([]string{
 		"companyName",
@@ -99,33 +121,15 @@ func (c *Client) GetQuotes(ctx context.Context, req *GetQuotesRequest) ([]*Quote
 	}, ","))
 	u.RawQuery = v.Encode()
 
-	httpReq, err := http.NewRequest(http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	ss := make([]string, len(symbols))
+	copy(ss, symbols)
+	sort.Strings(ss)
 
-	httpResp, err := http.DefaultClient.Do(httpReq.WithContext(ctx))
+	r, cleanup, err := c.get(ctx, u, fmt.Sprintf("iex-quote-%s.txt", strings.Join(ss, "-")))
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := httpResp.Body.Close(); err != nil {
-			logger.Error(err)
-		}
-	}()
-
-	r := httpResp.Body
-	if c.dumpAPIResponses {
-		ss := make([]string, len(req.Symbols))
-		copy(ss, req.Symbols)
-		sort.Strings(ss)
-
-		rr, err := dumpResponse(fmt.Sprintf("iex-quote-%s.txt", strings.Join(ss, "-")), r)
-		if err != nil {
-			return nil, errs.Errorf("iex: failed to dump quote resp: %v", err)
-		}
-		r = rr
-	}
+	defer cleanup()
 
 	quotes, err := decodeQuotes(r)
 	if err != nil {
@@ -219,6 +223,8 @@ func quoteSource(latestSource string) (Source, error) {
 		return Price, nil
 	case "IEX Last Trade":
 		return LastTrade, nil
+	case "Real time price":
+		return RealTimePrice, nil
 	default:
 		return SourceUnspecified, errs.Errorf("unrecognized source: %q", latestSource)
 	}